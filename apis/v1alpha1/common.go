@@ -18,6 +18,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // StorageRetainPolicyType is the type of the storage retain policy.
@@ -50,6 +51,9 @@ const (
 
 	// PhaseTerminating means the cluster or standalone is terminating.
 	PhaseTerminating Phase = "Terminating"
+
+	// PhaseHibernated means all the components of the cluster are scaled to zero.
+	PhaseHibernated Phase = "Hibernated"
 )
 
 // RoleKind is the role of the component in the cluster.
@@ -83,7 +87,11 @@ type RoleSpec interface {
 }
 
 // SlimPodSpec is a slimmed down version of corev1.PodSpec.
-// Most of the fields in SlimPodSpec are copied from `corev1.PodSpec`.
+// Most of the fields in SlimPodSpec are copied from `corev1.PodSpec`, except the following ones:
+// - `containers`, which are the main container and the additional containers.
+// - `hostname`, `subdomain` and `setHostnameAsFQDN`, since the node ids of the datanodes and flownodes are allocated by the hostnames of the pods.
+// - `ephemeralContainers`, which are not allowed in the pod templates.
+// - `serviceAccount`, which is deprecated by `serviceAccountName`.
 type SlimPodSpec struct {
 	// NodeSelector is a selector which must be true for the pod to fit on a node.
 	// Selector which must match a node's labels for the pod to be scheduled on that node.
@@ -175,6 +183,11 @@ type SlimPodSpec struct {
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 
+	// TopologySpreadConstraints describes how the pods ought to spread across the topology domains.
+	// TopologySpreadConstraints field is from `corev1.PodSpec.TopologySpreadConstraints`.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
 	// If specified, the pod will be dispatched by specified scheduler.
 	// If not specified, the pod will be dispatched by default scheduler.
 	// SchedulerName field is from `corev1.PodSpec.SchedulerName`.
@@ -193,6 +206,104 @@ type SlimPodSpec struct {
 	// SecurityContext holds pod-level security attributes and common container settings.
 	// +optional
 	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// AutomountServiceAccountToken indicates whether a service account token should be automatically mounted.
+	// AutomountServiceAccountToken field is from `corev1.PodSpec.AutomountServiceAccountToken`.
+	// +optional
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+
+	// NodeName indicates in which node this pod is scheduled.
+	// If it's set, the scheduler simply schedules this pod onto that node.
+	// NodeName field is from `corev1.PodSpec.NodeName`.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// Use the host's pid namespace.
+	// HostPID field is from `corev1.PodSpec.HostPID`.
+	// +optional
+	HostPID bool `json:"hostPID,omitempty"`
+
+	// Use the host's ipc namespace.
+	// HostIPC field is from `corev1.PodSpec.HostIPC`.
+	// +optional
+	HostIPC bool `json:"hostIPC,omitempty"`
+
+	// Share a single process namespace between all of the containers in a pod.
+	// ShareProcessNamespace field is from `corev1.PodSpec.ShareProcessNamespace`.
+	// +optional
+	ShareProcessNamespace *bool `json:"shareProcessNamespace,omitempty"`
+
+	// HostAliases is an optional list of hosts and IPs that will be injected into the pod's hosts file.
+	// HostAliases field is from `corev1.PodSpec.HostAliases`.
+	// +optional
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
+	// If specified, indicates the pod's priority. `system-node-critical` and `system-cluster-critical` are two special keywords
+	// which indicate the highest priorities with the former being the highest priority.
+	// PriorityClassName field is from `corev1.PodSpec.PriorityClassName`.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// The priority value. It's usually populated from the PriorityClassName by the admission controller.
+	// Priority field is from `corev1.PodSpec.Priority`.
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+
+	// Specifies the DNS parameters of a pod.
+	// DNSConfig field is from `corev1.PodSpec.DNSConfig`.
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// If specified, all readiness gates will be evaluated for pod readiness.
+	// ReadinessGates field is from `corev1.PodSpec.ReadinessGates`.
+	// +optional
+	ReadinessGates []corev1.PodReadinessGate `json:"readinessGates,omitempty"`
+
+	// RuntimeClassName refers to a RuntimeClass object in the node.k8s.io group, which should be used to run this pod.
+	// RuntimeClassName field is from `corev1.PodSpec.RuntimeClassName`.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// EnableServiceLinks indicates whether information about services should be injected into pod's environment variables.
+	// EnableServiceLinks field is from `corev1.PodSpec.EnableServiceLinks`.
+	// +optional
+	EnableServiceLinks *bool `json:"enableServiceLinks,omitempty"`
+
+	// PreemptionPolicy is the Policy for preempting pods with lower priority. One of `Never`, `PreemptLowerPriority`.
+	// PreemptionPolicy field is from `corev1.PodSpec.PreemptionPolicy`.
+	// +optional
+	PreemptionPolicy *corev1.PreemptionPolicy `json:"preemptionPolicy,omitempty"`
+
+	// Overhead represents the resource overhead associated with running a pod for a given RuntimeClass.
+	// Overhead field is from `corev1.PodSpec.Overhead`.
+	// +optional
+	Overhead corev1.ResourceList `json:"overhead,omitempty"`
+
+	// Specifies the OS of the containers in the pod.
+	// OS field is from `corev1.PodSpec.OS`.
+	// +optional
+	OS *corev1.PodOS `json:"os,omitempty"`
+
+	// Use the host's user namespace.
+	// HostUsers field is from `corev1.PodSpec.HostUsers`.
+	// +optional
+	HostUsers *bool `json:"hostUsers,omitempty"`
+
+	// SchedulingGates is an opaque list of values that if specified will block scheduling the pod.
+	// SchedulingGates field is from `corev1.PodSpec.SchedulingGates`.
+	// +optional
+	SchedulingGates []corev1.PodSchedulingGate `json:"schedulingGates,omitempty"`
+
+	// ResourceClaims defines which ResourceClaims must be allocated and reserved before the Pod is allowed to start.
+	// ResourceClaims field is from `corev1.PodSpec.ResourceClaims`.
+	// +optional
+	ResourceClaims []corev1.PodResourceClaim `json:"resourceClaims,omitempty"`
+
+	// Resources is the total amount of CPU and Memory resources required by all the containers in the pod.
+	// It's different from the resources of the main container.
+	// Resources field is from `corev1.PodSpec.Resources`.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // MainContainerSpec describes the specification of the main container of a pod.
@@ -301,6 +412,41 @@ type MainContainerSpec struct {
 	// SecurityContext holds container-level security attributes and common settings.
 	// +optional
 	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// ResizePolicy is the resources resize policy for the container.
+	// ResizePolicy field is from `corev1.Container.ResizePolicy`.
+	// +optional
+	ResizePolicy []corev1.ContainerResizePolicy `json:"resizePolicy,omitempty"`
+
+	// VolumeDevices is the list of block devices to be used by the container.
+	// VolumeDevices field is from `corev1.Container.VolumeDevices`.
+	// +optional
+	VolumeDevices []corev1.VolumeDevice `json:"volumeDevices,omitempty"`
+
+	// Path at which the file to which the container's termination message will be written is mounted into the container's filesystem.
+	// TerminationMessagePath field is from `corev1.Container.TerminationMessagePath`.
+	// +optional
+	TerminationMessagePath string `json:"terminationMessagePath,omitempty"`
+
+	// Indicate how the termination message should be populated. One of `File`, `FallbackToLogsOnError`.
+	// TerminationMessagePolicy field is from `corev1.Container.TerminationMessagePolicy`.
+	// +optional
+	TerminationMessagePolicy corev1.TerminationMessagePolicy `json:"terminationMessagePolicy,omitempty"`
+
+	// Whether this container should allocate a buffer for stdin in the container runtime.
+	// Stdin field is from `corev1.Container.Stdin`.
+	// +optional
+	Stdin bool `json:"stdin,omitempty"`
+
+	// Whether the container runtime should close the stdin channel after it has been opened by a single attach.
+	// StdinOnce field is from `corev1.Container.StdinOnce`.
+	// +optional
+	StdinOnce bool `json:"stdinOnce,omitempty"`
+
+	// Whether this container should allocate a TTY for itself, also requires 'stdin' to be true.
+	// TTY field is from `corev1.Container.TTY`.
+	// +optional
+	TTY bool `json:"tty,omitempty"`
 }
 
 func (in *MainContainerSpec) GetImage() string {
@@ -335,15 +481,29 @@ type FileStorage struct {
 	// +optional
 	Name string `json:"name,omitempty"`
 
-	// UseEmptyDir is a flag to indicate whether to use an empty dir. If true, the PVC will not be created and the whole storage of datanode will be cleaned up when the datanode restarts.
+	// UseEmptyDir is a flag to indicate whether to use an empty dir. If true, the PVC will not be created and the whole storage will be cleaned up when the pod is deleted.
 	// +optional
 	UseEmptyDir *bool `json:"useEmptyDir,omitempty"`
 
+	// EmptyDirMedium is the storage medium of the empty dir. It can be `Memory` to use the tmpfs, and the storage size is counted against the memory limit of the container.
+	// It's only used when UseEmptyDir is true.
+	// +optional
+	// +kubebuilder:validation:Enum:={"", "Memory"}
+	EmptyDirMedium corev1.StorageMedium `json:"emptyDirMedium,omitempty"`
+
+	// UseEphemeralVolume is a flag to indicate whether to use a generic ephemeral volume.
+	// If true, the PVC is created with the pod by the StatefulSet pod template instead of the volume claim template, and it's deleted when the pod is deleted.
+	// It can't be used together with UseEmptyDir.
+	// +optional
+	UseEphemeralVolume *bool `json:"useEphemeralVolume,omitempty"`
+
 	// StorageClassName is the name of the StorageClass to use for the PVC.
 	// +optional
 	StorageClassName *string `json:"storageClassName,omitempty"`
 
 	// StorageSize is the size of the storage.
+	// It can be increased to expand the existing PVCs if the StorageClass allows volume expansion, but it can't be decreased.
+	// If the webhook is disabled, the decrease is skipped by the operator and reported by the StorageShrinkRejected condition.
 	// +optional
 	// +kubebuilder:validation:Pattern=(^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$)
 	StorageSize string `json:"storageSize,omitempty"`
@@ -357,6 +517,13 @@ type FileStorage struct {
 	// +kubebuilder:validation:Enum:={"Retain", "Delete"}
 	StorageRetainPolicy StorageRetainPolicyType `json:"storageRetainPolicy,omitempty"`
 
+	// StorageRetainPolicyWhenScaled is the policy of the PVCs of the removed replicas when the StatefulSet is scaled in. It can be `Retain` or `Delete`.
+	// It's mapped to the `whenScaled` of the StatefulSet `persistentVolumeClaimRetentionPolicy` together with the StorageRetainPolicy as `whenDeleted`.
+	// Since the policy is applied to the whole StatefulSet, the WAL and cache PVCs of the datanodes follow the policy of the datanode storage.
+	// +optional
+	// +kubebuilder:validation:Enum:={"Retain", "Delete"}
+	StorageRetainPolicyWhenScaled StorageRetainPolicyType `json:"storageRetainPolicyWhenScaled,omitempty"`
+
 	// Labels is the labels for the PVC.
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
@@ -364,6 +531,38 @@ type FileStorage struct {
 	// Annotations is the annotations for the PVC.
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// AccessModes are the access modes of the PVC, for example, `ReadWriteOncePod`.
+	// Default to `ReadWriteOnce`. The storage must be writable, so `ReadOnlyMany` is not allowed.
+	// AccessModes field is from `corev1.PersistentVolumeClaimSpec.AccessModes`.
+	// +optional
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+
+	// VolumeMode is the volume mode of the PVC.
+	// Only `Filesystem` is allowed for all the components since GreptimeDB reads and writes the data, the WAL and the cache
+	// as files in a directory and can't use the raw block devices.
+	// VolumeMode field is from `corev1.PersistentVolumeClaimSpec.VolumeMode`.
+	// +kubebuilder:validation:Enum:={"Filesystem"}
+	// +optional
+	VolumeMode *corev1.PersistentVolumeMode `json:"volumeMode,omitempty"`
+
+	// DataSource is the data source of the PVC, for example, a VolumeSnapshot to restore from.
+	// It can only be set for the datanode storage of a single replica, because every replica would be restored from the same data.
+	// DataSource field is from `corev1.PersistentVolumeClaimSpec.DataSource`.
+	// +optional
+	DataSource *corev1.TypedLocalObjectReference `json:"dataSource,omitempty"`
+
+	// DataSourceRef is the object from which the PVC is populated, and it can be an object of the custom populators.
+	// It must be the same as the DataSource if both are set.
+	// DataSourceRef field is from `corev1.PersistentVolumeClaimSpec.DataSourceRef`.
+	// +optional
+	DataSourceRef *corev1.TypedObjectReference `json:"dataSourceRef,omitempty"`
+
+	// VolumeAttributesClassName is the name of the VolumeAttributesClass of the PVC.
+	// It can be changed after the PVCs are created, and the existing PVCs are patched with the new class.
+	// VolumeAttributesClassName field is from `corev1.PersistentVolumeClaimSpec.VolumeAttributesClassName`.
+	// +optional
+	VolumeAttributesClassName *string `json:"volumeAttributesClassName,omitempty"`
 }
 
 // FileStorageAccessor is the interface that wraps the basic methods for the FileStorage.
@@ -376,6 +575,11 @@ type FileStorageAccessor interface {
 	GetPolicy() StorageRetainPolicyType
 	GetLabels() map[string]string
 	GetAnnotations() map[string]string
+	GetAccessModes() []corev1.PersistentVolumeAccessMode
+	GetVolumeMode() *corev1.PersistentVolumeMode
+	GetDataSource() *corev1.TypedLocalObjectReference
+	GetDataSourceRef() *corev1.TypedObjectReference
+	GetVolumeAttributesClassName() *string
 }
 
 func (in *FileStorage) GetName() string {
@@ -413,6 +617,13 @@ func (in *FileStorage) GetPolicy() StorageRetainPolicyType {
 	return ""
 }
 
+func (in *FileStorage) GetPolicyWhenScaled() StorageRetainPolicyType {
+	if in != nil {
+		return in.StorageRetainPolicyWhenScaled
+	}
+	return ""
+}
+
 func (in *FileStorage) GetLabels() map[string]string {
 	if in != nil {
 		return in.Labels
@@ -427,6 +638,41 @@ func (in *FileStorage) GetAnnotations() map[string]string {
 	return nil
 }
 
+func (in *FileStorage) GetAccessModes() []corev1.PersistentVolumeAccessMode {
+	if in != nil {
+		return in.AccessModes
+	}
+	return nil
+}
+
+func (in *FileStorage) GetVolumeMode() *corev1.PersistentVolumeMode {
+	if in != nil {
+		return in.VolumeMode
+	}
+	return nil
+}
+
+func (in *FileStorage) GetDataSource() *corev1.TypedLocalObjectReference {
+	if in != nil {
+		return in.DataSource
+	}
+	return nil
+}
+
+func (in *FileStorage) GetDataSourceRef() *corev1.TypedObjectReference {
+	if in != nil {
+		return in.DataSourceRef
+	}
+	return nil
+}
+
+func (in *FileStorage) GetVolumeAttributesClassName() *string {
+	if in != nil {
+		return in.VolumeAttributesClassName
+	}
+	return nil
+}
+
 func (in *FileStorage) IsUseEmptyDir() bool {
 	if in != nil {
 		return in.UseEmptyDir != nil && *in.UseEmptyDir
@@ -434,6 +680,55 @@ func (in *FileStorage) IsUseEmptyDir() bool {
 	return false
 }
 
+func (in *FileStorage) GetEmptyDirMedium() corev1.StorageMedium {
+	if in != nil {
+		return in.EmptyDirMedium
+	}
+	return corev1.StorageMediumDefault
+}
+
+func (in *FileStorage) IsUseEphemeralVolume() bool {
+	if in != nil {
+		return in.UseEphemeralVolume != nil && *in.UseEphemeralVolume
+	}
+	return false
+}
+
+// IsUsePVC returns true if the storage is allocated by the volume claim template of the StatefulSet.
+func (in *FileStorage) IsUsePVC() bool {
+	return in != nil && !in.IsUseEmptyDir() && !in.IsUseEphemeralVolume()
+}
+
+// PVCResizePhase is the phase of resizing the PVC.
+type PVCResizePhase string
+
+const (
+	// PVCResizePhaseResizing means the volume is being expanded by the storage provider.
+	PVCResizePhaseResizing PVCResizePhase = "Resizing"
+
+	// PVCResizePhaseFileSystemResizePending means the volume is expanded and the file system will be resized when the pod is restarted.
+	PVCResizePhaseFileSystemResizePending PVCResizePhase = "FileSystemResizePending"
+
+	// PVCResizePhaseCompleted means the capacity of the PVC reaches the requested size.
+	PVCResizePhaseCompleted PVCResizePhase = "Completed"
+)
+
+// PVCResizeStatus is the progress of expanding the PVC after the storage size is increased.
+type PVCResizeStatus struct {
+	// Name is the name of the PVC.
+	Name string `json:"name"`
+
+	// RequestedSize is the requested storage size of the PVC.
+	RequestedSize string `json:"requestedSize"`
+
+	// CapacitySize is the actual storage size of the PVC.
+	// +optional
+	CapacitySize string `json:"capacitySize,omitempty"`
+
+	// Phase is the phase of resizing the PVC.
+	Phase PVCResizePhase `json:"phase"`
+}
+
 // WALProviderSpec defines the WAL provider for the cluster.
 type WALProviderSpec struct {
 	// RaftEngineWAL is the specification for local WAL that uses raft-engine.
@@ -1047,6 +1342,52 @@ func (in *PrometheusMonitorSpec) IsEnablePrometheusMonitor() bool {
 	return in != nil && in.Enabled
 }
 
+// PodDisruptionBudgetSpec defines the PodDisruptionBudget of the component to limit the pods that are evicted at the same time,
+// for example, when the nodes are drained. Only one of minAvailable and maxUnavailable can be set,
+// and the maxUnavailable is `1` if neither of them is set.
+type PodDisruptionBudgetSpec struct {
+	// Enabled indicates whether to create the PodDisruptionBudget.
+	// It's enabled by default for the meta and datanode, and it can be set to `false` to opt out.
+	// It's opt-in for the flownode, frontend and standalone since the frontend is stateless and often scaled by the autoscaler,
+	// the flownode keeps no persistent data, and the standalone only has one replica, which the default `maxUnavailable: 1`
+	// doesn't protect and `minAvailable: 1` would block the node drains of.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MinAvailable is the number or the percentage of the pods that must be available after the eviction, for example, `2` or `50%`.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or the percentage of the pods that can be unavailable after the eviction, for example, `1` or `25%`.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+func (in *PodDisruptionBudgetSpec) IsEnabled() bool {
+	return in != nil && in.Enabled != nil && *in.Enabled
+}
+
+func (in *PodDisruptionBudgetSpec) GetMinAvailable() *intstr.IntOrString {
+	if in != nil {
+		return in.MinAvailable
+	}
+	return nil
+}
+
+// GetMaxUnavailable returns the maxUnavailable, and the default value if neither of minAvailable and maxUnavailable is set.
+func (in *PodDisruptionBudgetSpec) GetMaxUnavailable() *intstr.IntOrString {
+	if in != nil && in.MaxUnavailable != nil {
+		return in.MaxUnavailable
+	}
+
+	if in.GetMinAvailable() == nil {
+		defaultMaxUnavailable := intstr.FromInt32(DefaultPodDisruptionBudgetMaxUnavailable)
+		return &defaultMaxUnavailable
+	}
+
+	return nil
+}
+
 type SlowQueryRecordType string
 
 const (
@@ -1098,6 +1439,25 @@ const (
 
 	// ConditionTypeProgressing indicates that the GreptimeDB cluster is progressing.
 	ConditionTypeProgressing ConditionType = "Progressing"
+
+	// ConditionTypeUpgrading indicates that the GreptimeDB cluster is upgrading to a new version.
+	ConditionTypeUpgrading ConditionType = "Upgrading"
+
+	// ConditionTypeRolledBack indicates that the GreptimeDB cluster is rolled back because the rollout failed to be ready in time.
+	ConditionTypeRolledBack ConditionType = "RolledBack"
+
+	// ConditionTypePaused indicates that the reconciliation of the GreptimeDB cluster or standalone is paused.
+	ConditionTypePaused ConditionType = "Paused"
+
+	// ConditionTypeRebalancing indicates that the regions are being rebalanced after the datanodes are scaled out.
+	ConditionTypeRebalancing ConditionType = "Rebalancing"
+
+	// ConditionTypePendingMaintenanceWindow indicates that the changes that restart the pods are waiting for the next maintenance window.
+	ConditionTypePendingMaintenanceWindow ConditionType = "PendingMaintenanceWindow"
+
+	// ConditionTypeStorageShrinkRejected indicates that the storage sizes are decreased in the spec.
+	// The PVCs can't be shrunk, so the decreases are skipped and the other changes are still applied.
+	ConditionTypeStorageShrinkRejected ConditionType = "StorageShrinkRejected"
 )
 
 // Condition describes the state of a deployment at a certain point.
@@ -1151,7 +1511,8 @@ func SetCondition(conditions []Condition, condition Condition) []Condition {
 	currentCondition := GetCondition(conditions, condition.Type)
 	if currentCondition != nil &&
 		currentCondition.Status == condition.Status &&
-		currentCondition.Reason == condition.Reason {
+		currentCondition.Reason == condition.Reason &&
+		currentCondition.Message == condition.Message {
 		currentCondition.LastUpdateTime = condition.LastUpdateTime
 		return conditions
 	}
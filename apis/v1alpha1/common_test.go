@@ -0,0 +1,72 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TestPodSpecParity checks that the new fields of the PodSpec and Container are added to the SlimPodSpec and MainContainerSpec
+// when the Kubernetes dependencies are upgraded.
+func TestPodSpecParity(t *testing.T) {
+	tests := []struct {
+		name     string
+		original interface{}
+		slim     interface{}
+		excluded []string
+	}{
+		{
+			name:     "SlimPodSpec",
+			original: corev1.PodSpec{},
+			slim:     SlimPodSpec{},
+			excluded: []string{"containers", "ephemeralContainers", "serviceAccount", "hostname", "subdomain", "setHostnameAsFQDN"},
+		},
+		{
+			name:     "MainContainerSpec",
+			original: corev1.Container{},
+			slim:     MainContainerSpec{},
+			// The name and ports are set by the operator, and the restart policy is only for the init containers.
+			excluded: []string{"name", "ports", "restartPolicy"},
+		},
+	}
+
+	for _, test := range tests {
+		fields := jsonFields(reflect.TypeOf(test.slim))
+		for _, excluded := range test.excluded {
+			fields[excluded] = true
+		}
+
+		for name := range jsonFields(reflect.TypeOf(test.original)) {
+			if !fields[name] {
+				t.Errorf("%s: the field '%s' is missing", test.name, name)
+			}
+		}
+	}
+}
+
+func jsonFields(typ reflect.Type) map[string]bool {
+	fields := make(map[string]bool)
+	for i := 0; i < typ.NumField(); i++ {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		if name != "" {
+			fields[name] = true
+		}
+	}
+	return fields
+}
@@ -14,6 +14,10 @@
 
 package v1alpha1
 
+import (
+	"time"
+)
+
 // The following constants are the default values for the GreptimeDBCluster and GreptimeDBStandalone.
 const (
 	// DefaultVersion is the default version of the GreptimeDB.
@@ -87,6 +91,18 @@ const (
 
 	// DefaultMonitoringTTL is the default retention time for monitoring data.
 	DefaultMonitoringTTL = "30d"
+
+	// DefaultBackupFormat is the default file format of the exported data for the backup.
+	DefaultBackupFormat = BackupFormatParquet
+
+	// DefaultBackupPrefix is the default directory to store the backup data under the root of the object storage.
+	DefaultBackupPrefix = "backups"
+
+	// DefaultBackupDeletionPolicy is the default deletion policy of the backup data.
+	DefaultBackupDeletionPolicy = BackupDeletionPolicyRetain
+
+	// DefaultBackupPendingTimeout is the default duration that the backup waits for the cluster to be running.
+	DefaultBackupPendingTimeout = time.Hour
 )
 
 // The following constants are the constant configuration for the GreptimeDBCluster and GreptimeDBStandalone.
@@ -120,4 +136,35 @@ const (
 
 	// MetaDatabasePasswordKey is the key for the password in the secret when using MySQL or PostgreSQL as the backend storage.
 	MetaDatabasePasswordKey = "password"
+
+	// UsernameSecretKey is the key for the username in the secret of the frontend MySQL service credentials.
+	UsernameSecretKey = "username"
+
+	// PasswordSecretKey is the key for the password in the secret of the frontend MySQL service credentials.
+	PasswordSecretKey = "password"
+)
+
+const (
+	// DefaultCanarySoakDuration is the default duration to check the updated pods in every step of the canary rollout.
+	DefaultCanarySoakDuration = 5 * time.Minute
+
+	// DefaultMaxConcurrentRegionMigrations is the default maximum number of the region migrations that run at the same time when rebalancing the regions.
+	DefaultMaxConcurrentRegionMigrations = 1
+
+	// DefaultPodDisruptionBudgetMaxUnavailable is the default maximum number of the unavailable pods of the PodDisruptionBudget.
+	DefaultPodDisruptionBudgetMaxUnavailable = 1
+
+	// DefaultScalingInterval is the default interval to evaluate the metrics of the datanode scaling policy.
+	DefaultScalingInterval = time.Minute
+
+	// DefaultScaleOutCooldown is the default duration to wait after the last scaling before the datanodes are scaled out again.
+	DefaultScaleOutCooldown = 5 * time.Minute
+
+	// DefaultScaleInCooldown is the default duration to wait after the last scaling before the datanodes are scaled in again.
+	DefaultScaleInCooldown = 15 * time.Minute
+)
+
+const (
+	// PausedAnnotationKey is the annotation to pause the reconciliation of the GreptimeDBCluster and GreptimeDBStandalone when its value is `true`.
+	PausedAnnotationKey = "greptime.io/paused"
 )
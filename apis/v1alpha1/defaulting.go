@@ -15,6 +15,7 @@
 package v1alpha1
 
 import (
+	"path"
 	"reflect"
 	"strings"
 
@@ -22,6 +23,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 )
@@ -40,7 +42,7 @@ func (in *GreptimeDBCluster) SetDefaults() error {
 		return err
 	}
 
-	// Merge the default spec into the datanode groups and frontend groups.
+	// Merge the default spec into the datanode groups, frontend groups and flownode groups.
 	if err := in.mergeDefaultGroups(); err != nil {
 		return err
 	}
@@ -54,7 +56,9 @@ func (in *GreptimeDBCluster) SetDefaults() error {
 // We need to execute another merge operation for slice struct because mergo still don't support to merge Slice without override(https://github.com/darccio/mergo/issues/233).
 func (in *GreptimeDBCluster) mergeDefaultGroups() error {
 	for _, datanodeGroup := range in.GetDatanodeGroups() {
-		if err := mergo.Merge(datanodeGroup, in.defaultDatanode()); err != nil {
+		defaultSpec := in.defaultDatanode()
+		defaultSpec.PodDisruptionBudget = defaultPodDisruptionBudget(datanodeGroup.GetPodDisruptionBudget())
+		if err := mergo.Merge(datanodeGroup, defaultSpec); err != nil {
 			return err
 		}
 	}
@@ -65,6 +69,12 @@ func (in *GreptimeDBCluster) mergeDefaultGroups() error {
 		}
 	}
 
+	for _, flownode := range in.GetFlownodeGroups() {
+		if err := mergo.Merge(flownode, in.defaultFlownodeSpec()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -123,6 +133,27 @@ func (in *GreptimeDBCluster) MergeWithGlobalTracing() error {
 	return nil
 }
 
+// ExpandDatanodeZones expands the datanode into one datanode group per zone if the zones of the datanode are set.
+// Each datanode group is the copy of the datanode, and it's named by the zone and placed in the zone.
+func (in *GreptimeDBCluster) ExpandDatanodeZones() {
+	datanode := in.GetDatanode()
+	if len(datanode.GetZones()) == 0 {
+		return
+	}
+
+	var groups []*DatanodeSpec
+	for _, zone := range datanode.GetZones() {
+		group := datanode.DeepCopy()
+		group.Name = zone
+		group.Zone = zone
+		group.Zones = nil
+		groups = append(groups, group)
+	}
+
+	in.Spec.Datanode = nil
+	in.Spec.DatanodeGroups = groups
+}
+
 func (in *GreptimeDBCluster) defaultSpec() *GreptimeDBClusterSpec {
 	var defaultSpec = &GreptimeDBClusterSpec{
 		Base: &PodTemplateSpec{
@@ -153,6 +184,10 @@ func (in *GreptimeDBCluster) defaultSpec() *GreptimeDBClusterSpec {
 		defaultSpec.Flownode = in.defaultFlownodeSpec()
 	}
 
+	for range in.GetFlownodeGroups() {
+		defaultSpec.FlownodeGroups = append(defaultSpec.FlownodeGroups, in.defaultFlownodeSpec())
+	}
+
 	if in.GetDatanode() != nil {
 		defaultSpec.Datanode = in.defaultDatanode()
 	}
@@ -233,6 +268,8 @@ func (in *GreptimeDBCluster) defaultMeta() *MetaSpec {
 			Template: &PodTemplateSpec{},
 			Logging:  &LoggingSpec{},
 			Tracing:  &TracingSpec{},
+
+			PodDisruptionBudget: defaultPodDisruptionBudget(in.GetMeta().GetPodDisruptionBudget()),
 		},
 		RPCPort:              DefaultMetaRPCPort,
 		HTTPPort:             DefaultHTTPPort,
@@ -253,6 +290,8 @@ func (in *GreptimeDBCluster) defaultDatanode() *DatanodeSpec {
 			Template: &PodTemplateSpec{},
 			Logging:  &LoggingSpec{},
 			Tracing:  &TracingSpec{},
+
+			PodDisruptionBudget: defaultPodDisruptionBudget(in.GetDatanode().GetPodDisruptionBudget()),
 		},
 		RPCPort:       DefaultRPCPort,
 		HTTPPort:      DefaultHTTPPort,
@@ -358,6 +397,12 @@ func (in *GreptimeDBCluster) mergeDatanodeTemplate() error {
 }
 
 func (in *GreptimeDBCluster) mergeFlownodeTemplate() error {
+	for _, flownode := range in.GetFlownodeGroups() {
+		if err := in.doMergeWithBaseTemplate(flownode.Template, flownode.HTTPPort); err != nil {
+			return err
+		}
+	}
+
 	if flownode := in.GetFlownode(); flownode != nil {
 		if err := in.doMergeWithBaseTemplate(flownode.Template, flownode.HTTPPort); err != nil {
 			return err
@@ -438,6 +483,64 @@ func (in *GreptimeDBStandalone) defaultSpec() *GreptimeDBStandaloneSpec {
 	return defaultSpec
 }
 
+// SetDefaults sets the default values for the GreptimeDBBackup.
+func (in *GreptimeDBBackup) SetDefaults() error {
+	if in == nil {
+		return nil
+	}
+
+	if in.Spec.Prefix == "" {
+		in.Spec.Prefix = path.Join(DefaultBackupPrefix, in.Name)
+	}
+
+	if in.Spec.Format == "" {
+		in.Spec.Format = DefaultBackupFormat
+	}
+
+	if in.Spec.DeletionPolicy == "" {
+		in.Spec.DeletionPolicy = DefaultBackupDeletionPolicy
+	}
+
+	if in.Spec.PendingTimeout == nil {
+		in.Spec.PendingTimeout = &metav1.Duration{Duration: DefaultBackupPendingTimeout}
+	}
+
+	return nil
+}
+
+// SetDefaults sets the default values for the GreptimeDBBackupSchedule.
+// The prefix of the backup template is kept empty, so every backup will be exported to `backups/${backup-name}` by default.
+func (in *GreptimeDBBackupSchedule) SetDefaults() error {
+	if in == nil {
+		return nil
+	}
+
+	if in.Spec.BackupTemplate.Format == "" {
+		in.Spec.BackupTemplate.Format = DefaultBackupFormat
+	}
+
+	// The expired backups of the schedule should not leave their data in the object storage.
+	if in.Spec.BackupTemplate.DeletionPolicy == "" {
+		in.Spec.BackupTemplate.DeletionPolicy = BackupDeletionPolicyDelete
+	}
+
+	return nil
+}
+
+// SetDefaults sets the default values for the GreptimeDBRestore.
+// The format of the restore from a backup is resolved from the backup by the controller.
+func (in *GreptimeDBRestore) SetDefaults() error {
+	if in == nil {
+		return nil
+	}
+
+	if in.GetSource() != nil && in.Spec.Format == "" {
+		in.Spec.Format = DefaultBackupFormat
+	}
+
+	return nil
+}
+
 func defaultDatanodeStorage() *DatanodeStorageSpec {
 	return &DatanodeStorageSpec{
 		DataHome: DefaultDataHome,
@@ -527,6 +630,17 @@ func defaultReadinessProbe() *corev1.Probe {
 	}
 }
 
+// defaultPodDisruptionBudget keeps the quorum of the meta and the regions of the datanodes available when the nodes are drained.
+// The other components are opt-in, see PodDisruptionBudgetSpec.Enabled.
+// The maxUnavailable is not set here since only one of minAvailable and maxUnavailable can be set, and it's `1` if neither of them is set.
+// It returns nil if the PodDisruptionBudget is enabled or disabled explicitly, otherwise mergo will overwrite the `false`.
+func defaultPodDisruptionBudget(spec *PodDisruptionBudgetSpec) *PodDisruptionBudgetSpec {
+	if spec != nil && spec.Enabled != nil {
+		return nil
+	}
+	return &PodDisruptionBudgetSpec{Enabled: ptr.To(true)}
+}
+
 // Same as the default rolling update strategy of Deployment.
 func defaultRollingUpdateForDeployment() *appsv1.RollingUpdateDeployment {
 	return &appsv1.RollingUpdateDeployment{
@@ -23,6 +23,7 @@ import (
 	"dario.cat/mergo"
 	"github.com/sergi/go-diff/diffmatchpatch"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/yaml"
 )
 
@@ -214,6 +215,71 @@ func TestStandaloneSetDefaults(t *testing.T) {
 	}
 }
 
+func TestExpandDatanodeZones(t *testing.T) {
+	cluster := &GreptimeDBCluster{
+		Spec: GreptimeDBClusterSpec{
+			Datanode: &DatanodeSpec{
+				ComponentSpec: ComponentSpec{Replicas: ptr.To(int32(3))},
+				Zones:         []string{"zone-a", "zone-b"},
+			},
+		},
+	}
+
+	cluster.ExpandDatanodeZones()
+
+	if cluster.GetDatanode() != nil {
+		t.Fatalf("expected the datanode to be expanded into the datanode groups")
+	}
+
+	groups := cluster.GetDatanodeGroups()
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 datanode groups, got: %d", len(groups))
+	}
+
+	for i, zone := range []string{"zone-a", "zone-b"} {
+		if groups[i].GetName() != zone || groups[i].GetZone() != zone || len(groups[i].GetZones()) != 0 || *groups[i].GetReplicas() != 3 {
+			t.Errorf("unexpected datanode group of zone '%s': %+v", zone, groups[i])
+		}
+	}
+}
+
+func TestPodDisruptionBudgetDefaults(t *testing.T) {
+	cluster := &GreptimeDBCluster{
+		Spec: GreptimeDBClusterSpec{
+			Meta: &MetaSpec{
+				ComponentSpec: ComponentSpec{PodDisruptionBudget: &PodDisruptionBudgetSpec{Enabled: ptr.To(false)}},
+			},
+			Datanode: &DatanodeSpec{
+				ComponentSpec: ComponentSpec{PodDisruptionBudget: &PodDisruptionBudgetSpec{MinAvailable: ptr.To(intstr.FromInt32(2))}},
+			},
+			DatanodeGroups: []*DatanodeSpec{{Name: "read"}},
+			Frontend:       &FrontendSpec{},
+		},
+	}
+
+	if err := cluster.SetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+
+	if cluster.GetMeta().GetPodDisruptionBudget().IsEnabled() {
+		t.Errorf("expected the PodDisruptionBudget of meta to be opted out")
+	}
+
+	pdb := cluster.GetDatanode().GetPodDisruptionBudget()
+	if !pdb.IsEnabled() || pdb.GetMaxUnavailable() != nil || pdb.GetMinAvailable().IntValue() != 2 {
+		t.Errorf("expected the PodDisruptionBudget of datanode to be enabled with the minAvailable, got: %+v", pdb)
+	}
+
+	pdb = cluster.GetDatanodeGroups()[0].GetPodDisruptionBudget()
+	if !pdb.IsEnabled() || pdb.GetMaxUnavailable().IntValue() != 1 {
+		t.Errorf("expected the PodDisruptionBudget of datanode group to be enabled with maxUnavailable 1, got: %+v", pdb)
+	}
+
+	if cluster.GetFrontend().GetPodDisruptionBudget().IsEnabled() {
+		t.Errorf("expected the PodDisruptionBudget of frontend to be disabled by default")
+	}
+}
+
 func TestIntOrStringTransformer(t *testing.T) {
 	type foo struct {
 		Val *intstr.IntOrString
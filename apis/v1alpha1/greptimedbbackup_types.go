@@ -0,0 +1,225 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupPhase defines the phase of the backup.
+type BackupPhase string
+
+const (
+	// BackupPhasePending means the backup is waiting for the cluster to be ready.
+	BackupPhasePending BackupPhase = "Pending"
+
+	// BackupPhaseRunning means the backup is exporting the databases.
+	BackupPhaseRunning BackupPhase = "Running"
+
+	// BackupPhaseCompleted means all the databases are exported successfully.
+	BackupPhaseCompleted BackupPhase = "Completed"
+
+	// BackupPhaseFailed means the backup is failed and will not be retried.
+	BackupPhaseFailed BackupPhase = "Failed"
+)
+
+// BackupFormat is the file format of the exported data.
+type BackupFormat string
+
+const (
+	// BackupFormatParquet exports the data as parquet files.
+	BackupFormatParquet BackupFormat = "parquet"
+
+	// BackupFormatCSV exports the data as csv files.
+	BackupFormatCSV BackupFormat = "csv"
+
+	// BackupFormatJSON exports the data as json files.
+	BackupFormatJSON BackupFormat = "json"
+)
+
+// BackupDeletionPolicy defines what happens to the backup data when the backup is deleted.
+type BackupDeletionPolicy string
+
+const (
+	// BackupDeletionPolicyRetain keeps the backup data in the object storage when the backup is deleted.
+	BackupDeletionPolicyRetain BackupDeletionPolicy = "Retain"
+
+	// BackupDeletionPolicyDelete deletes the backup data from the object storage when the backup is deleted.
+	BackupDeletionPolicyDelete BackupDeletionPolicy = "Delete"
+)
+
+// GreptimeDBBackupSpec defines the desired state of GreptimeDBBackup.
+type GreptimeDBBackupSpec struct {
+	// ClusterName is the name of the GreptimeDBCluster to back up.
+	// The cluster must be in the same namespace with the GreptimeDBBackup resource.
+	// +required
+	ClusterName string `json:"clusterName"`
+
+	// Databases are the databases to export.
+	// If it's empty, all the databases except the system databases will be exported.
+	// +optional
+	Databases []string `json:"databases,omitempty"`
+
+	// ObjectStorage is the object storage to store the backup data.
+	// If it's not set, the backup data will be stored in the object storage of the cluster.
+	// +optional
+	ObjectStorage *ObjectStorageProviderSpec `json:"objectStorage,omitempty"`
+
+	// Prefix is the directory under the root of the object storage to store the backup data.
+	// Every database will be exported to the `${prefix}/${database}/` directory. Default to `backups/${backup-name}`.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Format is the file format of the exported data. Default to `parquet`.
+	// +kubebuilder:validation:Enum:={"parquet", "csv", "json"}
+	// +optional
+	Format BackupFormat `json:"format,omitempty"`
+
+	// CredentialsSecretName is the name of the secret that stores the credentials of the frontend MySQL service.
+	// The secret should contain keys named `username` and `password`.
+	// The secret must be the same namespace with the GreptimeDBBackup resource.
+	// +optional
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+
+	// DeletionPolicy is the policy of the backup data when the backup is deleted. Default to `Retain`.
+	// If it's `Delete`, the operator runs a job to delete the `${prefix}` directory from the object storage before the backup is deleted.
+	// +kubebuilder:validation:Enum:={"Retain", "Delete"}
+	// +optional
+	DeletionPolicy BackupDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// PendingTimeout is the maximum duration that the backup waits for the cluster to be running before it starts, for example, `1h`. Default to `1h`.
+	// The backup is failed if it's still pending after the timeout, so the backup schedule can create the next backup.
+	// +optional
+	PendingTimeout *metav1.Duration `json:"pendingTimeout,omitempty"`
+}
+
+// GreptimeDBBackupStatus defines the observed state of GreptimeDBBackup.
+type GreptimeDBBackupStatus struct {
+	// BackupPhase is the phase of the backup.
+	// +optional
+	BackupPhase BackupPhase `json:"backupPhase,omitempty"`
+
+	// StartTime is the time when the backup started to export the databases.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time when the backup is completed or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Location is the object storage location of the backup data, for example, `s3://bucket/root/backups/my-backup/`.
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// ObjectStorage is the object storage that stores the backup data, which is resolved when the backup starts.
+	// It only refers to the credentials secrets and can be used to restore the backup even if the cluster is deleted.
+	// +optional
+	ObjectStorage *ObjectStorageProviderSpec `json:"objectStorage,omitempty"`
+
+	// Databases are the databases that will be exported by the backup.
+	// +optional
+	Databases []string `json:"databases,omitempty"`
+
+	// ExportedDatabases are the databases that have been exported successfully.
+	// +optional
+	ExportedDatabases []string `json:"exportedDatabases,omitempty"`
+
+	// ExportedBytes is the total size of the exported tables, which is reported by `information_schema.tables`.
+	// +optional
+	ExportedBytes int64 `json:"exportedBytes,omitempty"`
+
+	// Message is the error message when the backup is failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Conditions represent the latest available observations of an object's current state.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the last observed generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=gtbak
+// +kubebuilder:printcolumn:name="CLUSTER",type=string,JSONPath=".spec.clusterName"
+// +kubebuilder:printcolumn:name="PHASE",type=string,JSONPath=".status.backupPhase"
+// +kubebuilder:printcolumn:name="LOCATION",type=string,JSONPath=".status.location"
+// +kubebuilder:printcolumn:name="AGE",type=date,JSONPath=".metadata.creationTimestamp"
+
+// GreptimeDBBackup is the Schema for the greptimedbbackups API
+type GreptimeDBBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the specification of the desired state of the GreptimeDBBackup.
+	Spec GreptimeDBBackupSpec `json:"spec,omitempty"`
+
+	// Status is the most recently observed status of the GreptimeDBBackup.
+	Status GreptimeDBBackupStatus `json:"status,omitempty"`
+}
+
+func (in *GreptimeDBBackup) GetObjectStorage() *ObjectStorageProviderSpec {
+	if in != nil {
+		return in.Spec.ObjectStorage
+	}
+	return nil
+}
+
+func (in *GreptimeDBBackup) GetFormat() BackupFormat {
+	if in != nil {
+		return in.Spec.Format
+	}
+	return ""
+}
+
+func (in *GreptimeDBBackup) GetPendingTimeout() *metav1.Duration {
+	if in != nil {
+		return in.Spec.PendingTimeout
+	}
+	return nil
+}
+
+// IsFinished returns true if the backup is completed or failed.
+func (in *GreptimeDBBackup) IsFinished() bool {
+	if in == nil {
+		return false
+	}
+	return in.Status.BackupPhase == BackupPhaseCompleted || in.Status.BackupPhase == BackupPhaseFailed
+}
+
+func (in *GreptimeDBBackupStatus) GetCondition(conditionType ConditionType) *Condition {
+	return GetCondition(in.Conditions, conditionType)
+}
+
+func (in *GreptimeDBBackupStatus) SetCondition(condition Condition) {
+	in.Conditions = SetCondition(in.Conditions, condition)
+}
+
+// +kubebuilder:object:root=true
+
+// GreptimeDBBackupList contains a list of GreptimeDBBackup
+type GreptimeDBBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GreptimeDBBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GreptimeDBBackup{}, &GreptimeDBBackupList{})
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupRetentionSpec defines how long the completed backups of the schedule are kept.
+// The backups that exceed any of the limits will be deleted.
+type BackupRetentionSpec struct {
+	// Count is the maximum number of the completed backups to keep.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Count *int32 `json:"count,omitempty"`
+
+	// MaxAge is the maximum age of the completed backups to keep, for example, `168h`.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+// GreptimeDBBackupScheduleSpec defines the desired state of GreptimeDBBackupSchedule.
+type GreptimeDBBackupScheduleSpec struct {
+	// Schedule is the cron expression of the schedule, for example, `0 2 * * *` or `@daily`.
+	// The time zone can also be set by the `CRON_TZ=` prefix, for example, `CRON_TZ=Asia/Shanghai 0 2 * * *`, if timeZone is not set.
+	// +required
+	Schedule string `json:"schedule"`
+
+	// TimeZone is the time zone name of the schedule, for example, `Asia/Shanghai`. Default to the time zone of the operator.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Suspend stops creating the new backups. It does not affect the running backups.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Retention is the retention policy of the completed backups.
+	// If it's not set, the backups will be kept forever.
+	// +optional
+	Retention *BackupRetentionSpec `json:"retention,omitempty"`
+
+	// BackupTemplate is the spec of the backups that created by the schedule.
+	// Every backup will be exported to the `${prefix}/${backup-name}` directory and its `deletionPolicy` is default to `Delete`,
+	// so the expired backup data will be deleted from the object storage.
+	// +required
+	BackupTemplate GreptimeDBBackupSpec `json:"backupTemplate"`
+}
+
+// GreptimeDBBackupScheduleStatus defines the observed state of GreptimeDBBackupSchedule.
+type GreptimeDBBackupScheduleStatus struct {
+	// LastScheduleTime is the last time when a backup was scheduled.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// NextScheduleTime is the next time when a backup will be scheduled.
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// LastSuccessfulTime is the completion time of the last successful backup.
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// LastFailureTime is the completion time of the last failed backup.
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+
+	// LastBackupName is the name of the last backup that created by the schedule.
+	// +optional
+	LastBackupName string `json:"lastBackupName,omitempty"`
+
+	// Conditions represent the latest available observations of an object's current state.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the last observed generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=gtbaks
+// +kubebuilder:printcolumn:name="CLUSTER",type=string,JSONPath=".spec.backupTemplate.clusterName"
+// +kubebuilder:printcolumn:name="SCHEDULE",type=string,JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="SUSPEND",type=boolean,JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="LAST-SUCCESS",type=date,JSONPath=".status.lastSuccessfulTime"
+// +kubebuilder:printcolumn:name="AGE",type=date,JSONPath=".metadata.creationTimestamp"
+
+// GreptimeDBBackupSchedule is the Schema for the greptimedbbackupschedules API
+type GreptimeDBBackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the specification of the desired state of the GreptimeDBBackupSchedule.
+	Spec GreptimeDBBackupScheduleSpec `json:"spec,omitempty"`
+
+	// Status is the most recently observed status of the GreptimeDBBackupSchedule.
+	Status GreptimeDBBackupScheduleStatus `json:"status,omitempty"`
+}
+
+func (in *GreptimeDBBackupSchedule) GetRetention() *BackupRetentionSpec {
+	if in != nil {
+		return in.Spec.Retention
+	}
+	return nil
+}
+
+func (in *BackupRetentionSpec) GetCount() *int32 {
+	if in != nil {
+		return in.Count
+	}
+	return nil
+}
+
+func (in *BackupRetentionSpec) GetMaxAge() *metav1.Duration {
+	if in != nil {
+		return in.MaxAge
+	}
+	return nil
+}
+
+func (in *GreptimeDBBackupScheduleStatus) GetCondition(conditionType ConditionType) *Condition {
+	return GetCondition(in.Conditions, conditionType)
+}
+
+func (in *GreptimeDBBackupScheduleStatus) SetCondition(condition Condition) {
+	in.Conditions = SetCondition(in.Conditions, condition)
+}
+
+// +kubebuilder:object:root=true
+
+// GreptimeDBBackupScheduleList contains a list of GreptimeDBBackupSchedule
+type GreptimeDBBackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GreptimeDBBackupSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GreptimeDBBackupSchedule{}, &GreptimeDBBackupScheduleList{})
+}
@@ -15,7 +15,11 @@
 package v1alpha1
 
 import (
+	"strconv"
+	"time"
+
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -42,6 +46,16 @@ type ComponentSpec struct {
 	// Tracing defines the tracing configuration for the component.
 	// +optional
 	Tracing *TracingSpec `json:"tracing,omitempty"`
+
+	// RestartedAt requests a rolling restart of the pods of the component when it's changed, for example, `2026-10-16T10:00:00Z`.
+	// It's set as the pod template annotation `controller.greptime.io/restarted-at`, so the pods are restarted as the other pod template changes,
+	// for example, the maintenance mode is turned on when the datanodes are restarted, and the restart is held until the maintenance window.
+	// +optional
+	RestartedAt *metav1.Time `json:"restartedAt,omitempty"`
+
+	// PodDisruptionBudget is the PodDisruptionBudget of the pods of the component.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
 }
 
 // MetaSpec is the specification for meta component.
@@ -71,6 +85,12 @@ type MetaSpec struct {
 	// RollingUpdate is the rolling update configuration. We always use `RollingUpdate` strategyt.
 	// +optional
 	RollingUpdate *appsv1.RollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+
+	// LeaderAwareRollingUpdate indicates whether to update the follower pods of the meta before the leader.
+	// The deployment is kept paused between the steps of the rollout, and each step is resumed to replace one pod
+	// only after all the pods are available and the leader is elected. The `rollingUpdate` is ignored if it's enabled.
+	// +optional
+	LeaderAwareRollingUpdate *bool `json:"leaderAwareRollingUpdate,omitempty"`
 }
 
 var _ RoleSpec = &MetaSpec{}
@@ -84,6 +104,11 @@ func (in *MetaSpec) GetName() string {
 	return ""
 }
 
+// IsLeaderAwareRollingUpdate returns true if the follower pods of the meta are updated before the leader.
+func (in *MetaSpec) IsLeaderAwareRollingUpdate() bool {
+	return in != nil && in.LeaderAwareRollingUpdate != nil && *in.LeaderAwareRollingUpdate
+}
+
 func (in *MetaSpec) GetBackendStorage() *BackendStorage {
 	if in != nil {
 		return in.BackendStorage
@@ -250,6 +275,20 @@ func (in *MetaSpec) GetReplicas() *int32 {
 	return nil
 }
 
+func (in *MetaSpec) GetPodDisruptionBudget() *PodDisruptionBudgetSpec {
+	if in != nil {
+		return in.PodDisruptionBudget
+	}
+	return nil
+}
+
+func (in *MetaSpec) GetRestartedAt() *metav1.Time {
+	if in != nil {
+		return in.RestartedAt
+	}
+	return nil
+}
+
 func (in *MetaSpec) GetConfig() string {
 	if in != nil {
 		return in.Config
@@ -334,6 +373,11 @@ type FrontendSpec struct {
 	// Default to false.
 	// +optional
 	EnableObjectStorage *bool `json:"enableObjectStorage,omitempty"`
+
+	// Autoscaling is the horizontal autoscaling configuration of the frontend.
+	// If it's enabled, the replicas of the frontend are managed by the HorizontalPodAutoscaler.
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
 }
 
 var _ RoleSpec = &FrontendSpec{}
@@ -350,6 +394,20 @@ func (in *FrontendSpec) GetReplicas() *int32 {
 	return nil
 }
 
+func (in *FrontendSpec) GetPodDisruptionBudget() *PodDisruptionBudgetSpec {
+	if in != nil {
+		return in.PodDisruptionBudget
+	}
+	return nil
+}
+
+func (in *FrontendSpec) GetRestartedAt() *metav1.Time {
+	if in != nil {
+		return in.RestartedAt
+	}
+	return nil
+}
+
 func (in *FrontendSpec) GetTLS() *TLSSpec {
 	if in != nil {
 		return in.TLS
@@ -404,6 +462,62 @@ func (in *FrontendSpec) GetSlowQuery() *SlowQuery {
 	return nil
 }
 
+func (in *FrontendSpec) GetAutoscaling() *AutoscalingSpec {
+	if in != nil {
+		return in.Autoscaling
+	}
+	return nil
+}
+
+// AutoscalingSpec defines the horizontal autoscaling of the component by the HorizontalPodAutoscaler.
+type AutoscalingSpec struct {
+	// Enabled indicates whether to enable the horizontal autoscaling.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MinReplicas is the lower limit of the replicas. Default to the replicas of the component.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit of the replicas.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilizationPercentage is the target average CPU utilization of the pods in percentage of the requested CPU.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// TargetMemoryUtilizationPercentage is the target average memory utilization of the pods in percentage of the requested memory.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+
+	// Metrics is the custom metrics to scale the component, which are appended to the CPU and memory metrics.
+	// +optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+
+	// Behavior is the scaling behavior of the HorizontalPodAutoscaler in both up and down directions.
+	// +optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+func (in *AutoscalingSpec) IsEnabled() bool {
+	return in != nil && in.Enabled
+}
+
+// GetMinReplicas returns the lower limit of the replicas, and the replicas of the component is used if it's not set.
+func (in *AutoscalingSpec) GetMinReplicas(replicas *int32) int32 {
+	if in != nil && in.MinReplicas != nil {
+		return *in.MinReplicas
+	}
+	if replicas != nil && *replicas > 0 {
+		return *replicas
+	}
+	return 1
+}
+
 // DatanodeSpec is the specification for datanode component.
 type DatanodeSpec struct {
 	ComponentSpec `json:",inline"`
@@ -432,9 +546,36 @@ type DatanodeSpec struct {
 	// +optional
 	RollingUpdate *appsv1.RollingUpdateStatefulSetStrategy `json:"rollingUpdate,omitempty"`
 
+	// Canary is the canary rollout policy. If it's set, the partition of the rolling update is managed by the operator during the rollout.
+	// +optional
+	Canary *CanarySpec `json:"canary,omitempty"`
+
+	// Rebalance is the policy to rebalance the regions after the datanodes are scaled out.
+	// +optional
+	Rebalance *RebalancePolicy `json:"rebalance,omitempty"`
+
+	// ScalingPolicy scales the datanodes by the metrics, for example, the region count, the ingestion rate or the disk usage.
+	// If it's enabled, the replicas of the datanode are managed by the operator,
+	// and the scaling goes through the region migrations before scale-in and the rebalance after scale-out.
+	// +optional
+	ScalingPolicy *DatanodeScalingPolicy `json:"scalingPolicy,omitempty"`
+
 	// StartNodeID is the start node id of the datanode.
 	// +optional
 	StartNodeID *int32 `json:"startNodeID,omitempty"`
+
+	// Zones are the values of the zone label `topology.kubernetes.io/zone` of the nodes that the datanodes are placed in.
+	// If it's set, the datanode is expanded into one datanode group per zone, which is named by the zone and has the replicas of the datanode.
+	// It can only be set for the datanode when the cluster is created, and it can't be changed afterwards,
+	// since the datanode groups are named by the zones and the regions on the datanodes of the removed zones would be lost.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+
+	// Zone is the zone that the datanodes are placed in. The datanodes are scheduled to the nodes in the zone
+	// and spread across the hosts by default, and the zone is set to the config of the datanodes for the region placement.
+	// It's set to each datanode group that is expanded from the zones of the datanode.
+	// +optional
+	Zone string `json:"zone,omitempty"`
 }
 
 var _ RoleSpec = &DatanodeSpec{}
@@ -444,6 +585,27 @@ func (in *DatanodeSpec) GetRoleKind() RoleKind {
 	return DatanodeRoleKind
 }
 
+func (in *DatanodeSpec) GetCanary() *CanarySpec {
+	if in != nil {
+		return in.Canary
+	}
+	return nil
+}
+
+func (in *DatanodeSpec) GetRebalance() *RebalancePolicy {
+	if in != nil {
+		return in.Rebalance
+	}
+	return nil
+}
+
+func (in *DatanodeSpec) GetScalingPolicy() *DatanodeScalingPolicy {
+	if in != nil {
+		return in.ScalingPolicy
+	}
+	return nil
+}
+
 func (in *DatanodeSpec) GetName() string {
 	if in != nil {
 		return in.Name
@@ -451,6 +613,20 @@ func (in *DatanodeSpec) GetName() string {
 	return ""
 }
 
+func (in *DatanodeSpec) GetZones() []string {
+	if in != nil {
+		return in.Zones
+	}
+	return nil
+}
+
+func (in *DatanodeSpec) GetZone() string {
+	if in != nil {
+		return in.Zone
+	}
+	return ""
+}
+
 func (in *DatanodeSpec) GetReplicas() *int32 {
 	if in != nil && in.Replicas != nil {
 		return in.Replicas
@@ -458,6 +634,20 @@ func (in *DatanodeSpec) GetReplicas() *int32 {
 	return nil
 }
 
+func (in *DatanodeSpec) GetPodDisruptionBudget() *PodDisruptionBudgetSpec {
+	if in != nil {
+		return in.PodDisruptionBudget
+	}
+	return nil
+}
+
+func (in *DatanodeSpec) GetRestartedAt() *metav1.Time {
+	if in != nil {
+		return in.RestartedAt
+	}
+	return nil
+}
+
 func (in *DatanodeSpec) GetConfig() string {
 	if in != nil {
 		return in.Config
@@ -504,6 +694,10 @@ func (in *DatanodeSpec) GetStartNodeID() *int32 {
 type FlownodeSpec struct {
 	ComponentSpec `json:",inline"`
 
+	// Name is the name of the flownode.
+	// +optional
+	Name string `json:"name,omitempty"`
+
 	// The gRPC port of the flownode.
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=65535
@@ -520,6 +714,10 @@ type FlownodeSpec struct {
 	// +optional
 	RollingUpdate *appsv1.RollingUpdateStatefulSetStrategy `json:"rollingUpdate,omitempty"`
 
+	// Canary is the canary rollout policy. If it's set, the partition of the rolling update is managed by the operator during the rollout.
+	// +optional
+	Canary *CanarySpec `json:"canary,omitempty"`
+
 	// StartNodeID is the start node id of the flownode.
 	// +optional
 	StartNodeID *int32 `json:"startNodeID,omitempty"`
@@ -532,7 +730,17 @@ func (in *FlownodeSpec) GetRoleKind() RoleKind {
 	return FlownodeRoleKind
 }
 
+func (in *FlownodeSpec) GetCanary() *CanarySpec {
+	if in != nil {
+		return in.Canary
+	}
+	return nil
+}
+
 func (in *FlownodeSpec) GetName() string {
+	if in != nil {
+		return in.Name
+	}
 	return ""
 }
 
@@ -543,6 +751,20 @@ func (in *FlownodeSpec) GetReplicas() *int32 {
 	return nil
 }
 
+func (in *FlownodeSpec) GetPodDisruptionBudget() *PodDisruptionBudgetSpec {
+	if in != nil {
+		return in.PodDisruptionBudget
+	}
+	return nil
+}
+
+func (in *FlownodeSpec) GetRestartedAt() *metav1.Time {
+	if in != nil {
+		return in.RestartedAt
+	}
+	return nil
+}
+
 func (in *FlownodeSpec) GetConfig() string {
 	if in != nil {
 		return in.Config
@@ -604,6 +826,10 @@ type GreptimeDBClusterSpec struct {
 	// +optional
 	Flownode *FlownodeSpec `json:"flownode,omitempty"`
 
+	// FlownodeGroups is a group of flownode statefulsets.
+	// +optional
+	FlownodeGroups []*FlownodeSpec `json:"flownodeGroups,omitempty"`
+
 	// FrontendGroups is groups of frontend node.
 	// +optional
 	FrontendGroups []*FrontendSpec `json:"frontendGroups,omitempty"`
@@ -678,6 +904,285 @@ type GreptimeDBClusterSpec struct {
 	// +optional
 	// +kubebuilder:default=false
 	EnableIPv6 bool `json:"enableIPv6,omitempty"`
+
+	// Paused stops the operator from reconciling the cluster, and the changes of the cluster will not be applied until it's unpaused.
+	// It can also be set by the annotation `greptime.io/paused: "true"`.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Hibernate scales all the frontends, flownodes, datanodes and metas of the cluster to zero in order, and the PVCs are always kept.
+	// When it's set to false, the previous replicas are restored in reverse order.
+	// +optional
+	Hibernate bool `json:"hibernate,omitempty"`
+
+	// RolloutPolicy is the policy of rolling out the changes of the cluster.
+	// +optional
+	RolloutPolicy *RolloutPolicy `json:"rolloutPolicy,omitempty"`
+
+	// CloneFrom clones the cluster from an existing cluster in the same namespace.
+	// The regions of the source cluster are flushed, and the PVCs of the datanodes are restored from the VolumeSnapshots of the source datanode PVCs
+	// before the datanodes are created. The meta backend key prefix or table is aliased, and the meta keys of the source cluster are copied into it.
+	// If the source cluster stores the data in the object storage, the object storage root is aliased when the clone doesn't set its own object storage,
+	// and the data under the root of the source cluster is copied into it by a job before the datanodes are created.
+	// +optional
+	CloneFrom *CloneSource `json:"cloneFrom,omitempty"`
+
+	// MaintenanceWindows are the time windows in which the changes that restart the pods can be rolled out.
+	// If it's set, the pod template and config changes of the components are held outside the windows and applied when the next window opens,
+	// and the other changes, for example, scaling, are applied at once.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+}
+
+// MaintenanceWindow is the time window that starts at the cron schedule and lasts for the duration.
+type MaintenanceWindow struct {
+	// Schedule is the cron expression of the start time of the window, for example, `0 2 * * 6` or `@daily`.
+	// The time zone can also be set by the `CRON_TZ=` prefix, for example, `CRON_TZ=Asia/Shanghai 0 2 * * 6`, if timeZone is not set.
+	// +required
+	Schedule string `json:"schedule"`
+
+	// Duration is the length of the window, for example, `4h`.
+	// +required
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the time zone name of the schedule, for example, `Asia/Shanghai`. Default to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// CloneSource is the source cluster to clone from.
+type CloneSource struct {
+	// Name is the name of the source cluster in the same namespace.
+	// +required
+	Name string `json:"name"`
+
+	// VolumeSnapshotClassName is the name of the VolumeSnapshotClass to take the snapshots of the source datanode PVCs.
+	// The default VolumeSnapshotClass is used if it's not set.
+	// +optional
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+
+	// CredentialsSecretName is the name of the secret that contains the credentials to flush the regions through the frontend MySQL service of the source cluster.
+	// The secret must contain keys named `username` and `password`.
+	// +optional
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+}
+
+// RebalancePolicy defines how to rebalance the regions after the datanodes are scaled out.
+// When the new datanodes are ready, the regions are migrated from the datanodes with more regions to the new ones.
+type RebalancePolicy struct {
+	// Enabled indicates whether to rebalance the regions after the datanodes are scaled out.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MaxConcurrentMigrations is the maximum number of the region migrations that run at the same time.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentMigrations *int32 `json:"maxConcurrentMigrations,omitempty"`
+}
+
+func (in *RebalancePolicy) IsEnabled() bool {
+	return in != nil && in.Enabled
+}
+
+func (in *RebalancePolicy) GetMaxConcurrentMigrations() int32 {
+	if in != nil && in.MaxConcurrentMigrations != nil {
+		return *in.MaxConcurrentMigrations
+	}
+	return DefaultMaxConcurrentRegionMigrations
+}
+
+// DatanodeScalingPolicy defines how to scale the datanodes by the metrics.
+// The metrics are queried periodically, and the datanodes are scaled out by the step if any of the metrics exceeds its scale-out threshold,
+// or scaled in by the step if all the metrics are below their scale-in thresholds. The replicas are kept in the range of [minReplicas, maxReplicas].
+type DatanodeScalingPolicy struct {
+	// Enabled indicates whether to scale the datanodes by the metrics.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MinReplicas is the lower limit of the replicas.
+	// +kubebuilder:validation:Minimum=1
+	// +required
+	MinReplicas int32 `json:"minReplicas"`
+
+	// MaxReplicas is the upper limit of the replicas.
+	// +kubebuilder:validation:Minimum=1
+	// +required
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Endpoint is the URL of the Prometheus-compatible HTTP API to query the metrics, for example, `http://prometheus.monitoring:9090`.
+	// If it's not set, the Prometheus HTTP API of the monitoring standalone of the cluster is used.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Interval is the interval to evaluate the metrics. Default to `1m`.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Metrics are the PromQL queries to decide whether to scale the datanodes.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Metrics []ScalingMetric `json:"metrics"`
+
+	// ScaleOut is the rule of scaling out the datanodes.
+	// +optional
+	ScaleOut *ScalingRule `json:"scaleOut,omitempty"`
+
+	// ScaleIn is the rule of scaling in the datanodes.
+	// +optional
+	ScaleIn *ScalingRule `json:"scaleIn,omitempty"`
+}
+
+// ScalingMetric is the metric that is evaluated by the PromQL query. The values of all the series of the query result are summed up.
+type ScalingMetric struct {
+	// Name is the name of the metric, which is used in the status and events.
+	// +required
+	Name string `json:"name"`
+
+	// Query is the PromQL query of the metric, for example, `sum(greptime_datanode_region_count)`.
+	// +required
+	Query string `json:"query"`
+
+	// ScaleOutThreshold is the value above which the datanodes are scaled out, for example, `1000` or `0.8`.
+	// +optional
+	ScaleOutThreshold string `json:"scaleOutThreshold,omitempty"`
+
+	// ScaleInThreshold is the value below which the datanodes can be scaled in, for example, `200` or `0.3`.
+	// The datanodes are never scaled in if it's not set.
+	// +optional
+	ScaleInThreshold string `json:"scaleInThreshold,omitempty"`
+}
+
+// ScalingRule defines the step and the cooldown of the scaling in one direction.
+type ScalingRule struct {
+	// Step is the number of the datanodes that are added or removed in one scaling. Default to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Step *int32 `json:"step,omitempty"`
+
+	// Cooldown is the duration to wait after the last scaling before scaling again,
+	// default to `5m` for the scale-out and `15m` for the scale-in.
+	// +optional
+	Cooldown *metav1.Duration `json:"cooldown,omitempty"`
+}
+
+// GetThresholds returns the parsed scale-out and scale-in thresholds, and nil if the threshold is not set.
+func (in *ScalingMetric) GetThresholds() (scaleOut *float64, scaleIn *float64, err error) {
+	if in.ScaleOutThreshold != "" {
+		value, err := strconv.ParseFloat(in.ScaleOutThreshold, 64)
+		if err != nil {
+			return nil, nil, err
+		}
+		scaleOut = &value
+	}
+
+	if in.ScaleInThreshold != "" {
+		value, err := strconv.ParseFloat(in.ScaleInThreshold, 64)
+		if err != nil {
+			return nil, nil, err
+		}
+		scaleIn = &value
+	}
+
+	return scaleOut, scaleIn, nil
+}
+
+func (in *DatanodeScalingPolicy) IsEnabled() bool {
+	return in != nil && in.Enabled
+}
+
+func (in *DatanodeScalingPolicy) GetInterval() time.Duration {
+	if in != nil && in.Interval != nil {
+		return in.Interval.Duration
+	}
+	return DefaultScalingInterval
+}
+
+func (in *DatanodeScalingPolicy) GetScaleOutStep() int32 {
+	if in != nil && in.ScaleOut != nil && in.ScaleOut.Step != nil {
+		return *in.ScaleOut.Step
+	}
+	return 1
+}
+
+func (in *DatanodeScalingPolicy) GetScaleInStep() int32 {
+	if in != nil && in.ScaleIn != nil && in.ScaleIn.Step != nil {
+		return *in.ScaleIn.Step
+	}
+	return 1
+}
+
+func (in *DatanodeScalingPolicy) GetScaleOutCooldown() time.Duration {
+	if in != nil && in.ScaleOut != nil && in.ScaleOut.Cooldown != nil {
+		return in.ScaleOut.Cooldown.Duration
+	}
+	return DefaultScaleOutCooldown
+}
+
+func (in *DatanodeScalingPolicy) GetScaleInCooldown() time.Duration {
+	if in != nil && in.ScaleIn != nil && in.ScaleIn.Cooldown != nil {
+		return in.ScaleIn.Cooldown.Duration
+	}
+	return DefaultScaleInCooldown
+}
+
+// CanarySpec defines the canary rollout of the StatefulSet.
+// When the pod template is changed, the pods are updated step by step by lowering the partition of the StatefulSet.
+// After every step, the updated pods are soaked with the health checks for a duration.
+// The rollout continues if all the checks pass, otherwise it's aborted and the updated pods are rolled back.
+// The progress deadline of the rollout policy is not checked while the canary steps are in progress. Instead, the canary rollout
+// is aborted if the pods of a step are not ready within the progress deadline, and the deadline restarts after the last step.
+type CanarySpec struct {
+	// Steps are the numbers of the updated pods in every step, for example, `[1, 3]`.
+	// All the pods are updated after the last step passes.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Steps []int32 `json:"steps"`
+
+	// SoakDuration is the duration to check the updated pods after every step. Default to `5m`.
+	// +optional
+	SoakDuration *metav1.Duration `json:"soakDuration,omitempty"`
+
+	// HealthCheck is the additional health check during the soak. The readiness of the updated pods is always checked.
+	// +optional
+	HealthCheck *CanaryHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// CanaryHealthCheck defines the health check during the soak of the canary rollout.
+type CanaryHealthCheck struct {
+	// SQL is the query that is executed through the frontend MySQL service, for example, `SELECT 1`.
+	// The check fails if the query returns an error.
+	// +optional
+	SQL string `json:"sql,omitempty"`
+
+	// CredentialsSecretName is the name of the secret that contains the `username` and `password` to execute the SQL.
+	// +optional
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+
+	// Metrics is the check of the metrics that exposed by the updated pods.
+	// +optional
+	Metrics *CanaryMetricsCheck `json:"metrics,omitempty"`
+}
+
+// CanaryMetricsCheck checks the metric that exposed by the `/metrics` endpoint of the updated pods.
+type CanaryMetricsCheck struct {
+	// Name is the name of the metric. The values of all the series of the metric are summed up for every pod.
+	// +required
+	Name string `json:"name"`
+
+	// MaxValue is the maximum value of the metric of every updated pod, for example, `0` or `0.5`.
+	// +required
+	MaxValue string `json:"maxValue"`
+}
+
+// RolloutPolicy defines how the operator handles the rollout that fails to become ready.
+type RolloutPolicy struct {
+	// ProgressDeadline is the maximum duration for the components to be ready after the rollout started, for example, `10m`.
+	// If it's exceeded, the StatefulSets and Deployments that are not ready will be rolled back to the previous applied spec,
+	// and the failed spec will not be applied again until the cluster spec is changed.
+	// The volume claim templates of the StatefulSets are kept since they are immutable.
+	// If it's not set, the operator will wait for the components to be ready forever.
+	// +optional
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
 }
 
 // MonitoringSpec is the specification for monitor bootstrapping. It will create a standalone greptimedb instance to monitor the cluster.
@@ -835,20 +1340,48 @@ func (in *GreptimeDBCluster) GetFlownode() *FlownodeSpec {
 	return in.Spec.Flownode
 }
 
-func (in *GreptimeDBCluster) GetWALProvider() *WALProviderSpec {
+func (in *GreptimeDBCluster) GetFlownodeGroups() []*FlownodeSpec {
 	if in != nil {
-		return in.Spec.WALProvider
+		return in.Spec.FlownodeGroups
 	}
 	return nil
 }
 
-func (in *GreptimeDBCluster) GetWALDir() string {
-	if in == nil {
-		return ""
+func (in *GreptimeDBCluster) GetRolloutPolicy() *RolloutPolicy {
+	if in != nil {
+		return in.Spec.RolloutPolicy
 	}
+	return nil
+}
 
-	if in.Spec.WALProvider != nil && in.Spec.WALProvider.RaftEngineWAL != nil {
-		return in.Spec.WALProvider.RaftEngineWAL.FileStorage.MountPath
+func (in *GreptimeDBCluster) GetMaintenanceWindows() []MaintenanceWindow {
+	if in != nil {
+		return in.Spec.MaintenanceWindows
+	}
+	return nil
+}
+
+func (in *RolloutPolicy) GetProgressDeadline() *metav1.Duration {
+	if in != nil {
+		return in.ProgressDeadline
+	}
+	return nil
+}
+
+func (in *GreptimeDBCluster) GetWALProvider() *WALProviderSpec {
+	if in != nil {
+		return in.Spec.WALProvider
+	}
+	return nil
+}
+
+func (in *GreptimeDBCluster) GetWALDir() string {
+	if in == nil {
+		return ""
+	}
+
+	if in.Spec.WALProvider != nil && in.Spec.WALProvider.RaftEngineWAL != nil {
+		return in.Spec.WALProvider.RaftEngineWAL.FileStorage.MountPath
 	}
 
 	if in.Spec.Datanode != nil &&
@@ -898,6 +1431,11 @@ func (in *GreptimeDBCluster) getAllLoggingSpecs() []*LoggingSpec {
 	if logging := in.GetFlownode().GetLogging(); logging != nil {
 		specs = append(specs, logging)
 	}
+	for _, flownode := range in.GetFlownodeGroups() {
+		if logging := flownode.GetLogging(); logging != nil {
+			specs = append(specs, logging)
+		}
+	}
 
 	if logging := in.GetDatanode().GetLogging(); logging != nil {
 		specs = append(specs, logging)
@@ -931,6 +1469,12 @@ func (in *GreptimeDBCluster) getAllTracingSpecs() []*TracingSpec {
 		specs = append(specs, tracing)
 	}
 
+	for _, flownode := range in.GetFlownodeGroups() {
+		if tracing := flownode.GetTracing(); tracing != nil {
+			specs = append(specs, tracing)
+		}
+	}
+
 	if tracing := in.GetDatanode().GetTracing(); tracing != nil {
 		specs = append(specs, tracing)
 	}
@@ -977,9 +1521,39 @@ type GreptimeDBClusterStatus struct {
 	Monitoring MonitoringStatus `json:"monitoring,omitempty"`
 
 	// Version is the version of greptimedb.
+	// It's updated when all the components are rolled out to the version of the spec.
 	// +optional
 	Version string `json:"version,omitempty"`
 
+	// RolledBackGeneration is the generation of the cluster spec that is rolled back because it failed to be ready in the progress deadline.
+	// The spec of the generation will not be applied again.
+	// +optional
+	RolledBackGeneration int64 `json:"rolledBackGeneration,omitempty"`
+
+	// Canaries are the canary rollouts of the StatefulSets.
+	// +optional
+	Canaries []CanaryStatus `json:"canaries,omitempty"`
+
+	// Hibernation is the status of the hibernation. It's kept until the cluster is fully resumed.
+	// +optional
+	Hibernation *HibernationStatus `json:"hibernation,omitempty"`
+
+	// Clone is the status of cloning the cluster from the source cluster.
+	// +optional
+	Clone *CloneStatus `json:"clone,omitempty"`
+
+	// Upgrade is the progress of the last version upgrade.
+	// +optional
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+
+	// MaintenanceWindow is the status of the changes that are waiting for the next maintenance window.
+	// +optional
+	MaintenanceWindow *MaintenanceWindowStatus `json:"maintenanceWindow,omitempty"`
+
+	// Restarts are the last restart requests of the components and the groups.
+	// +optional
+	Restarts []RestartStatus `json:"restarts,omitempty"`
+
 	// ClusterPhase is the phase of the greptimedb cluster.
 	// +optional
 	ClusterPhase Phase `json:"clusterPhase,omitempty"`
@@ -1000,6 +1574,22 @@ type FrontendStatus struct {
 
 	// ReadyReplicas is the number of ready replicas of the frontend.
 	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// Autoscaling is the status of the HorizontalPodAutoscalers of the frontend and frontend groups.
+	// +optional
+	Autoscaling []AutoscalingStatus `json:"autoscaling,omitempty"`
+}
+
+// AutoscalingStatus is the status of the HorizontalPodAutoscaler of the component.
+type AutoscalingStatus struct {
+	// Name is the name of the HorizontalPodAutoscaler.
+	Name string `json:"name"`
+
+	// CurrentReplicas is the current number of replicas of the pods managed by the autoscaler.
+	CurrentReplicas int32 `json:"currentReplicas"`
+
+	// DesiredReplicas is the desired number of replicas of the pods managed by the autoscaler.
+	DesiredReplicas int32 `json:"desiredReplicas"`
 }
 
 // MetaStatus is the status of meta node.
@@ -1021,6 +1611,219 @@ type DatanodeStatus struct {
 
 	// ReadyReplicas is the number of ready replicas of the datanode.
 	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// ScaleIns are the status of the datanode StatefulSets that are scaling in.
+	// The regions are migrated off the datanodes that will be removed before the replicas are decreased.
+	// +optional
+	ScaleIns []DatanodeScaleInStatus `json:"scaleIns,omitempty"`
+
+	// Rebalances are the status of rebalancing the regions of the datanode StatefulSets.
+	// +optional
+	Rebalances []DatanodeRebalanceStatus `json:"rebalances,omitempty"`
+
+	// PVCResizes are the progress of expanding the datanode PVCs after the storage size is increased.
+	// +optional
+	PVCResizes []PVCResizeStatus `json:"pvcResizes,omitempty"`
+
+	// Scalings are the status of scaling the datanode StatefulSets by the scaling policies.
+	// +optional
+	Scalings []DatanodeScalingStatus `json:"scalings,omitempty"`
+
+	// Zones are the zones of the datanode when the cluster is created, which are used to reject the changes of the zones.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+}
+
+// DatanodeScalingStatus is the status of scaling the datanode StatefulSet by the scaling policy.
+type DatanodeScalingStatus struct {
+	// StatefulSet is the name of the datanode StatefulSet.
+	StatefulSet string `json:"statefulSet"`
+
+	// Metrics are the values of the metrics in the last evaluation.
+	// +optional
+	Metrics []ScalingMetricStatus `json:"metrics,omitempty"`
+
+	// LastEvaluateTime is the time when the metrics are evaluated last time.
+	// +optional
+	LastEvaluateTime *metav1.Time `json:"lastEvaluateTime,omitempty"`
+
+	// LastScaleTime is the time when the datanodes are scaled last time.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+
+	// LastScaleReplicas is the replicas that the datanodes are scaled to last time.
+	// +optional
+	LastScaleReplicas int32 `json:"lastScaleReplicas,omitempty"`
+}
+
+// ScalingMetricStatus is the value of the scaling metric.
+type ScalingMetricStatus struct {
+	// Name is the name of the metric.
+	Name string `json:"name"`
+
+	// Value is the value of the metric.
+	Value string `json:"value"`
+}
+
+// GetScaling returns the scaling status of the datanode StatefulSet.
+func (in *DatanodeStatus) GetScaling(statefulSet string) *DatanodeScalingStatus {
+	for i := range in.Scalings {
+		if in.Scalings[i].StatefulSet == statefulSet {
+			return &in.Scalings[i]
+		}
+	}
+	return nil
+}
+
+// SetScaling sets the scaling status of the datanode StatefulSet.
+func (in *DatanodeStatus) SetScaling(status DatanodeScalingStatus) {
+	for i := range in.Scalings {
+		if in.Scalings[i].StatefulSet == status.StatefulSet {
+			in.Scalings[i] = status
+			return
+		}
+	}
+	in.Scalings = append(in.Scalings, status)
+}
+
+// DatanodeRebalanceStatus is the status of rebalancing the regions of the datanode StatefulSet.
+type DatanodeRebalanceStatus struct {
+	// StatefulSet is the name of the datanode StatefulSet.
+	StatefulSet string `json:"statefulSet"`
+
+	// BalancedReplicas is the replicas of the StatefulSet when its regions are balanced last time.
+	// The regions will be rebalanced when the replicas are greater than it.
+	BalancedReplicas int32 `json:"balancedReplicas"`
+
+	// PendingMigrations is the number of the region migrations that are not submitted yet.
+	// +optional
+	PendingMigrations int32 `json:"pendingMigrations,omitempty"`
+
+	// Migrations are the running region migrations.
+	// +optional
+	Migrations []RegionMigrationStatus `json:"migrations,omitempty"`
+
+	// StartTime is the time when the rebalance started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+// GetRebalance returns the rebalance status of the datanode StatefulSet.
+func (in *DatanodeStatus) GetRebalance(statefulSet string) *DatanodeRebalanceStatus {
+	for i := range in.Rebalances {
+		if in.Rebalances[i].StatefulSet == statefulSet {
+			return &in.Rebalances[i]
+		}
+	}
+	return nil
+}
+
+// SetRebalance sets the rebalance status of the datanode StatefulSet.
+func (in *DatanodeStatus) SetRebalance(status DatanodeRebalanceStatus) {
+	for i := range in.Rebalances {
+		if in.Rebalances[i].StatefulSet == status.StatefulSet {
+			in.Rebalances[i] = status
+			return
+		}
+	}
+	in.Rebalances = append(in.Rebalances, status)
+}
+
+// DatanodeScaleInStatus is the status of scaling in the datanode StatefulSet.
+type DatanodeScaleInStatus struct {
+	// StatefulSet is the name of the datanode StatefulSet.
+	StatefulSet string `json:"statefulSet"`
+
+	// Replicas is the target replicas of the StatefulSet.
+	Replicas int32 `json:"replicas"`
+
+	// RemovedNodeIDs are the node ids of the datanodes that will be removed.
+	// +optional
+	RemovedNodeIDs []uint64 `json:"removedNodeIDs,omitempty"`
+
+	// PendingRegions is the number of the regions that are still on the datanodes that will be removed.
+	PendingRegions int32 `json:"pendingRegions"`
+
+	// Migrations are the submitted region migrations.
+	// +optional
+	Migrations []RegionMigrationStatus `json:"migrations,omitempty"`
+
+	// StartTime is the time when the scale in started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+// RegionMigrationStatus is the status of the region migration.
+type RegionMigrationStatus struct {
+	// RegionID is the id of the migrated region.
+	RegionID uint64 `json:"regionID"`
+
+	// FromPeerID is the node id of the datanode that the region is migrated from.
+	FromPeerID uint64 `json:"fromPeerID"`
+
+	// ToPeerID is the node id of the datanode that the region is migrated to.
+	ToPeerID uint64 `json:"toPeerID"`
+
+	// ProcedureID is the id of the metasrv procedure that migrates the region.
+	// +optional
+	ProcedureID string `json:"procedureID,omitempty"`
+
+	// SubmitTime is the time when the region migration is submitted.
+	// +optional
+	SubmitTime *metav1.Time `json:"submitTime,omitempty"`
+}
+
+// GetScaleIn returns the scale in status of the datanode StatefulSet.
+func (in *DatanodeStatus) GetScaleIn(statefulSet string) *DatanodeScaleInStatus {
+	for i := range in.ScaleIns {
+		if in.ScaleIns[i].StatefulSet == statefulSet {
+			return &in.ScaleIns[i]
+		}
+	}
+	return nil
+}
+
+// SetScaleIn sets the scale in status of the datanode StatefulSet.
+func (in *DatanodeStatus) SetScaleIn(status DatanodeScaleInStatus) {
+	for i := range in.ScaleIns {
+		if in.ScaleIns[i].StatefulSet == status.StatefulSet {
+			in.ScaleIns[i] = status
+			return
+		}
+	}
+	in.ScaleIns = append(in.ScaleIns, status)
+}
+
+// RemoveScaleIn removes the scale in status of the datanode StatefulSet and returns true if it exists.
+func (in *DatanodeStatus) RemoveScaleIn(statefulSet string) bool {
+	for i := range in.ScaleIns {
+		if in.ScaleIns[i].StatefulSet == statefulSet {
+			in.ScaleIns = append(in.ScaleIns[:i], in.ScaleIns[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// GetMigration returns the submitted migration of the region.
+func (in *DatanodeScaleInStatus) GetMigration(regionID uint64) *RegionMigrationStatus {
+	for i := range in.Migrations {
+		if in.Migrations[i].RegionID == regionID {
+			return &in.Migrations[i]
+		}
+	}
+	return nil
+}
+
+// SetMigration sets the submitted migration of the region.
+func (in *DatanodeScaleInStatus) SetMigration(migration RegionMigrationStatus) {
+	for i := range in.Migrations {
+		if in.Migrations[i].RegionID == migration.RegionID {
+			in.Migrations[i] = migration
+			return
+		}
+	}
+	in.Migrations = append(in.Migrations, migration)
 }
 
 // FlownodeStatus is the status of flownode node.
@@ -1039,6 +1842,312 @@ type MonitoringStatus struct {
 	InternalDNSName string `json:"internalDNSName,omitempty"`
 }
 
+// ClonePhase is the phase of cloning the cluster.
+type ClonePhase string
+
+const (
+	// ClonePhaseSnapshotting means the VolumeSnapshots of the source datanode PVCs are being taken, and the data in the object storage is being copied.
+	ClonePhaseSnapshotting ClonePhase = "Snapshotting"
+
+	// ClonePhaseCompleted means the PVCs of the datanodes are created from the VolumeSnapshots, and the data in the object storage is copied.
+	ClonePhaseCompleted ClonePhase = "Completed"
+
+	// ClonePhaseFailed means the cluster can't be cloned, and it's created as a new cluster.
+	ClonePhaseFailed ClonePhase = "Failed"
+)
+
+// CloneStatus is the status of cloning the cluster.
+type CloneStatus struct {
+	// Source is the name of the source cluster.
+	Source string `json:"source"`
+
+	// Phase is the phase of cloning the cluster.
+	Phase ClonePhase `json:"phase"`
+
+	// Snapshots are the VolumeSnapshots of the source datanode PVCs.
+	// +optional
+	Snapshots []CloneSnapshot `json:"snapshots,omitempty"`
+
+	// CopyJob is the name of the job that copies the data in the object storage of the source cluster to the clone.
+	// +optional
+	CopyJob string `json:"copyJob,omitempty"`
+
+	// Message is the detail of the phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// CompletionTime is the time when the PVCs of the datanodes are created.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// CloneSnapshot is the VolumeSnapshot of the source datanode PVC.
+type CloneSnapshot struct {
+	// Name is the name of the VolumeSnapshot.
+	Name string `json:"name"`
+
+	// SourcePVC is the name of the source datanode PVC.
+	SourcePVC string `json:"sourcePVC"`
+
+	// TargetPVC is the name of the datanode PVC that is restored from the VolumeSnapshot.
+	TargetPVC string `json:"targetPVC"`
+
+	// ReadyToUse indicates whether the VolumeSnapshot is ready to restore the PVC.
+	ReadyToUse bool `json:"readyToUse"`
+}
+
+// IsFinished returns true if the PVCs are created from the snapshots or the clone failed.
+func (in *CloneStatus) IsFinished() bool {
+	return in != nil && (in.Phase == ClonePhaseCompleted || in.Phase == ClonePhaseFailed)
+}
+
+// HibernationStatus is the status of the cluster hibernation.
+type HibernationStatus struct {
+	// Workloads are the workloads that are scaled to zero and their previous replicas.
+	// +optional
+	Workloads []HibernatedWorkload `json:"workloads,omitempty"`
+
+	// HibernatedTime is the time when all the workloads are scaled to zero.
+	// +optional
+	HibernatedTime *metav1.Time `json:"hibernatedTime,omitempty"`
+}
+
+// MaintenanceWindowStatus is the status of the changes that are held until the next maintenance window.
+type MaintenanceWindowStatus struct {
+	// PendingWorkloads are the names of the workloads whose pod template changes are waiting for the next maintenance window.
+	// +optional
+	PendingWorkloads []string `json:"pendingWorkloads,omitempty"`
+
+	// NextWindowTime is the start time of the next maintenance window.
+	// +optional
+	NextWindowTime *metav1.Time `json:"nextWindowTime,omitempty"`
+}
+
+// RestartStatus is the last restart request of the component or the group.
+type RestartStatus struct {
+	// Component is the role kind of the component.
+	Component RoleKind `json:"component"`
+
+	// Name is the name of the group. It's empty if the component has no groups.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// RestartedAt is the restartedAt of the request.
+	RestartedAt metav1.Time `json:"restartedAt"`
+
+	// CompletionTime is the time when all the pods are restarted and ready.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// GetRestart returns the last restart request of the component or the group.
+func (in *GreptimeDBClusterStatus) GetRestart(kind RoleKind, name string) *RestartStatus {
+	for i := range in.Restarts {
+		if in.Restarts[i].Component == kind && in.Restarts[i].Name == name {
+			return &in.Restarts[i]
+		}
+	}
+	return nil
+}
+
+// HibernatedWorkload is the workload that is scaled to zero by the hibernation.
+type HibernatedWorkload struct {
+	// Component is the component of the workload.
+	Component RoleKind `json:"component"`
+
+	// Kind is the kind of the workload, `Deployment` or `StatefulSet`.
+	Kind string `json:"kind"`
+
+	// Name is the name of the workload.
+	Name string `json:"name"`
+
+	// Replicas is the replicas of the workload before the hibernation.
+	Replicas int32 `json:"replicas"`
+}
+
+// GetWorkload returns the hibernated workload.
+func (in *HibernationStatus) GetWorkload(kind, name string) *HibernatedWorkload {
+	if in == nil {
+		return nil
+	}
+	for i := range in.Workloads {
+		if in.Workloads[i].Kind == kind && in.Workloads[i].Name == name {
+			return &in.Workloads[i]
+		}
+	}
+	return nil
+}
+
+// CanaryPhase is the phase of the canary rollout.
+type CanaryPhase string
+
+const (
+	// CanaryPhaseProgressing means the pods of the current step are updating.
+	CanaryPhaseProgressing CanaryPhase = "Progressing"
+
+	// CanaryPhaseSoaking means the updated pods of the current step are checking.
+	CanaryPhaseSoaking CanaryPhase = "Soaking"
+
+	// CanaryPhaseCompleted means all the steps pass and the rest pods are updating.
+	CanaryPhaseCompleted CanaryPhase = "Completed"
+
+	// CanaryPhaseAborted means the check fails and the updated pods are rolled back.
+	CanaryPhaseAborted CanaryPhase = "Aborted"
+)
+
+// CanaryStatus is the progress of the canary rollout of a StatefulSet.
+type CanaryStatus struct {
+	// StatefulSet is the name of the StatefulSet.
+	StatefulSet string `json:"statefulSet"`
+
+	// Revision is the hash of the pod template that is rolling out.
+	Revision string `json:"revision"`
+
+	// Phase is the phase of the canary rollout.
+	Phase CanaryPhase `json:"phase"`
+
+	// Step is the index of the current step.
+	Step int32 `json:"step"`
+
+	// UpdatedReplicas is the number of the pods that should be updated in the current step.
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+
+	// StepStartTime is the time when the current phase of the step started.
+	// +optional
+	StepStartTime *metav1.Time `json:"stepStartTime,omitempty"`
+
+	// Message is the reason why the canary rollout is aborted.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IsInProgress returns true if the partition of the StatefulSet is managed by the canary rollout.
+func (in *CanaryStatus) IsInProgress() bool {
+	return in != nil && (in.Phase == CanaryPhaseProgressing || in.Phase == CanaryPhaseSoaking)
+}
+
+// GetCanary returns the canary rollout status of the StatefulSet.
+func (in *GreptimeDBClusterStatus) GetCanary(statefulSet string) *CanaryStatus {
+	for i := range in.Canaries {
+		if in.Canaries[i].StatefulSet == statefulSet {
+			return &in.Canaries[i]
+		}
+	}
+	return nil
+}
+
+// SetCanary adds or replaces the canary rollout status of the StatefulSet.
+func (in *GreptimeDBClusterStatus) SetCanary(canary CanaryStatus) {
+	if current := in.GetCanary(canary.StatefulSet); current != nil {
+		*current = canary
+		return
+	}
+	in.Canaries = append(in.Canaries, canary)
+}
+
+func (in *CanarySpec) GetSoakDuration() time.Duration {
+	if in != nil && in.SoakDuration != nil {
+		return in.SoakDuration.Duration
+	}
+	return DefaultCanarySoakDuration
+}
+
+func (in *CanarySpec) GetHealthCheck() *CanaryHealthCheck {
+	if in != nil {
+		return in.HealthCheck
+	}
+	return nil
+}
+
+func (in *CanaryHealthCheck) GetMetrics() *CanaryMetricsCheck {
+	if in != nil {
+		return in.Metrics
+	}
+	return nil
+}
+
+// UpgradePhase is the phase of the version upgrade or the component in the upgrade.
+type UpgradePhase string
+
+const (
+	// UpgradePhasePending means the component is waiting for the previous components to be upgraded.
+	UpgradePhasePending UpgradePhase = "Pending"
+
+	// UpgradePhaseUpgrading means the upgrade or the component is rolling out.
+	UpgradePhaseUpgrading UpgradePhase = "Upgrading"
+
+	// UpgradePhaseCompleted means the upgrade or the component is rolled out and healthy.
+	UpgradePhaseCompleted UpgradePhase = "Completed"
+
+	// UpgradePhaseRejected means the upgrade path is not supported and nothing is changed.
+	UpgradePhaseRejected UpgradePhase = "Rejected"
+)
+
+// UpgradeStatus is the progress of the version upgrade.
+// The components are upgraded in the order of meta, datanode, flownode and frontend,
+// and the next component will not start until the previous one is fully rolled out and healthy.
+type UpgradeStatus struct {
+	// FromVersion is the version before the upgrade.
+	FromVersion string `json:"fromVersion"`
+
+	// ToVersion is the target version of the upgrade.
+	ToVersion string `json:"toVersion"`
+
+	// Phase is the phase of the upgrade.
+	Phase UpgradePhase `json:"phase"`
+
+	// CurrentComponent is the component that is rolling out.
+	// +optional
+	CurrentComponent RoleKind `json:"currentComponent,omitempty"`
+
+	// Components are the upgrade progress of every component.
+	// +optional
+	Components []ComponentUpgradeStatus `json:"components,omitempty"`
+
+	// StartTime is the time when the upgrade started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time when the upgrade completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message is the reason why the upgrade is rejected.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ComponentUpgradeStatus is the upgrade progress of a component.
+type ComponentUpgradeStatus struct {
+	// Component is the role kind of the component.
+	Component RoleKind `json:"component"`
+
+	// Phase is the upgrade phase of the component.
+	Phase UpgradePhase `json:"phase"`
+
+	// CompletionTime is the time when the component is rolled out.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// IsInProgress returns true if the upgrade is rolling out.
+func (in *UpgradeStatus) IsInProgress() bool {
+	return in != nil && in.Phase == UpgradePhaseUpgrading
+}
+
+// GetComponent returns the upgrade progress of the component.
+func (in *UpgradeStatus) GetComponent(kind RoleKind) *ComponentUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	for i := range in.Components {
+		if in.Components[i].Component == kind {
+			return &in.Components[i]
+		}
+	}
+	return nil
+}
+
 func (in *GreptimeDBClusterStatus) GetCondition(conditionType ConditionType) *Condition {
 	return GetCondition(in.Conditions, conditionType)
 }
@@ -1087,6 +2196,14 @@ func (in *GreptimeDBCluster) GetDatanodeReplicas() int32 {
 	return count
 }
 
+// IsPaused returns true if the reconciliation of the cluster is paused by the spec or the annotation.
+func (in *GreptimeDBCluster) IsPaused() bool {
+	if in == nil {
+		return false
+	}
+	return in.Spec.Paused || in.GetAnnotations()[PausedAnnotationKey] == "true"
+}
+
 // +kubebuilder:object:root=true
 
 // GreptimeDBClusterList contains a list of GreptimeDBCluster
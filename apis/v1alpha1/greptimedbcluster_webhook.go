@@ -58,15 +58,33 @@ func (r *GreptimeDBCluster) ValidateCreate(_ context.Context, obj runtime.Object
 func (r *GreptimeDBCluster) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	greptimedbclusterlog.Info("validate update", "name", r.Name)
 
-	_, ok := newObj.(*GreptimeDBCluster)
+	newCluster, ok := newObj.(*GreptimeDBCluster)
 	if !ok {
 		return nil, fmt.Errorf("BUG: unexpected type: %T", newObj)
 	}
 
+	oldCluster, ok := oldObj.(*GreptimeDBCluster)
+	if !ok {
+		return nil, fmt.Errorf("BUG: unexpected type: %T", oldObj)
+	}
+
 	if err := r.Validate(); err != nil {
 		return nil, err
 	}
 
+	if err := newCluster.ValidateStorageUpdate(oldCluster); err != nil {
+		return nil, err
+	}
+
+	if err := newCluster.ValidateZonesUpdate(oldCluster.GetDatanode().GetZones()); err != nil {
+		return nil, err
+	}
+
+	// The version of the status is the version that all the components are running.
+	if err := CheckUpgradePath(oldCluster.Status.Version, getVersionFromImage(newCluster.GetBaseMainContainer().GetImage())); err != nil {
+		return nil, err
+	}
+
 	return nil, nil
 }
 
@@ -0,0 +1,212 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RestorePhase defines the phase of the restore or the database in the restore.
+type RestorePhase string
+
+const (
+	// RestorePhasePending means the restore is waiting for the cluster or the backup to be ready.
+	RestorePhasePending RestorePhase = "Pending"
+
+	// RestorePhaseRunning means the restore is importing the databases.
+	RestorePhaseRunning RestorePhase = "Running"
+
+	// RestorePhaseCompleted means all the databases are imported successfully.
+	RestorePhaseCompleted RestorePhase = "Completed"
+
+	// RestorePhaseFailed means the restore is failed and will not be retried.
+	RestorePhaseFailed RestorePhase = "Failed"
+)
+
+// RestoreSource is the raw object storage location of the exported data.
+type RestoreSource struct {
+	// ObjectStorage is the object storage that stores the exported data.
+	// If it's not set, the object storage of the target cluster will be used.
+	// +optional
+	ObjectStorage *ObjectStorageProviderSpec `json:"objectStorage,omitempty"`
+
+	// Prefix is the directory under the root of the object storage that stores the exported data.
+	// The data of every database should be in the `${prefix}/${database}/` directory.
+	// +required
+	Prefix string `json:"prefix"`
+}
+
+// GreptimeDBRestoreSpec defines the desired state of GreptimeDBRestore.
+type GreptimeDBRestoreSpec struct {
+	// ClusterName is the name of the target GreptimeDBCluster to import the data into.
+	// The cluster must be in the same namespace with the GreptimeDBRestore resource.
+	// +required
+	ClusterName string `json:"clusterName"`
+
+	// BackupName is the name of the GreptimeDBBackup to restore.
+	// The backup must be in the same namespace with the GreptimeDBRestore resource.
+	// Only one of the `backupName` and `source` can be set.
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// Source is the raw object storage location of the exported data.
+	// The tables must already exist in the target cluster because the raw location has no table schemas.
+	// Only one of the `backupName` and `source` can be set.
+	// +optional
+	Source *RestoreSource `json:"source,omitempty"`
+
+	// Databases are the databases to import.
+	// If it's empty, all the exported databases of the backup will be imported. It's required when using `source`.
+	// +optional
+	Databases []string `json:"databases,omitempty"`
+
+	// Format is the file format of the exported data.
+	// If it's not set, the format of the backup will be used. Default to `parquet` when using `source`.
+	// +kubebuilder:validation:Enum:={"parquet", "csv", "json"}
+	// +optional
+	Format BackupFormat `json:"format,omitempty"`
+
+	// Overwrite allows importing the data into the non-empty databases.
+	// The rows that have the same primary keys and timestamp will be overwritten by the imported data.
+	// +optional
+	Overwrite bool `json:"overwrite,omitempty"`
+
+	// CredentialsSecretName is the name of the secret that stores the credentials of the frontend MySQL service.
+	// The secret should contain keys named `username` and `password`.
+	// The secret must be the same namespace with the GreptimeDBRestore resource.
+	// +optional
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+}
+
+// RestoreDatabaseStatus is the status of importing a database.
+type RestoreDatabaseStatus struct {
+	// Name is the name of the database.
+	Name string `json:"name"`
+
+	// Phase is the phase of importing the database.
+	// +optional
+	Phase RestorePhase `json:"phase,omitempty"`
+
+	// ImportedRows is the number of the imported rows.
+	// +optional
+	ImportedRows int64 `json:"importedRows,omitempty"`
+
+	// CompletionTime is the time when the database is imported.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message is the error message when importing the database is failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// GreptimeDBRestoreStatus defines the observed state of GreptimeDBRestore.
+type GreptimeDBRestoreStatus struct {
+	// RestorePhase is the phase of the restore.
+	// +optional
+	RestorePhase RestorePhase `json:"restorePhase,omitempty"`
+
+	// StartTime is the time when the restore started to import the databases.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time when the restore is completed or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Location is the object storage location of the imported data.
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Databases are the status of every database in the restore.
+	// +optional
+	Databases []RestoreDatabaseStatus `json:"databases,omitempty"`
+
+	// Message is the reason why the restore is pending or failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Conditions represent the latest available observations of an object's current state.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the last observed generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=gtrs
+// +kubebuilder:printcolumn:name="CLUSTER",type=string,JSONPath=".spec.clusterName"
+// +kubebuilder:printcolumn:name="BACKUP",type=string,JSONPath=".spec.backupName"
+// +kubebuilder:printcolumn:name="PHASE",type=string,JSONPath=".status.restorePhase"
+// +kubebuilder:printcolumn:name="AGE",type=date,JSONPath=".metadata.creationTimestamp"
+
+// GreptimeDBRestore is the Schema for the greptimedbrestores API
+type GreptimeDBRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the specification of the desired state of the GreptimeDBRestore.
+	Spec GreptimeDBRestoreSpec `json:"spec,omitempty"`
+
+	// Status is the most recently observed status of the GreptimeDBRestore.
+	Status GreptimeDBRestoreStatus `json:"status,omitempty"`
+}
+
+func (in *GreptimeDBRestore) GetSource() *RestoreSource {
+	if in != nil {
+		return in.Spec.Source
+	}
+	return nil
+}
+
+// IsFinished returns true if the restore is completed or failed.
+func (in *GreptimeDBRestore) IsFinished() bool {
+	if in == nil {
+		return false
+	}
+	return in.Status.RestorePhase == RestorePhaseCompleted || in.Status.RestorePhase == RestorePhaseFailed
+}
+
+func (in *RestoreSource) GetObjectStorage() *ObjectStorageProviderSpec {
+	if in != nil {
+		return in.ObjectStorage
+	}
+	return nil
+}
+
+func (in *GreptimeDBRestoreStatus) GetCondition(conditionType ConditionType) *Condition {
+	return GetCondition(in.Conditions, conditionType)
+}
+
+func (in *GreptimeDBRestoreStatus) SetCondition(condition Condition) {
+	in.Conditions = SetCondition(in.Conditions, condition)
+}
+
+// +kubebuilder:object:root=true
+
+// GreptimeDBRestoreList contains a list of GreptimeDBRestore
+type GreptimeDBRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GreptimeDBRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GreptimeDBRestore{}, &GreptimeDBRestoreList{})
+}
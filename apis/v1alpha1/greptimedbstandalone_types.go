@@ -116,6 +116,15 @@ type GreptimeDBStandaloneSpec struct {
 	// +optional
 	// +kubebuilder:default=false
 	EnableIPv6 bool `json:"enableIPv6,omitempty"`
+
+	// Paused stops the operator from reconciling the standalone, and the changes of the standalone will not be applied until it's unpaused.
+	// It can also be set by the annotation `greptime.io/paused: "true"`.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// PodDisruptionBudget is the PodDisruptionBudget of the standalone pod.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
 }
 
 // GreptimeDBStandaloneStatus defines the observed state of GreptimeDBStandalone
@@ -141,6 +150,10 @@ type GreptimeDBStandaloneStatus struct {
 	// ObservedGeneration is the most recent generation observed for this GreptimeDBStandalone.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// PVCResizes are the progress of expanding the PVCs after the storage size is increased.
+	// +optional
+	PVCResizes []PVCResizeStatus `json:"pvcResizes,omitempty"`
 }
 
 // +genclient
@@ -192,6 +205,13 @@ func (in *GreptimeDBStandalone) GetPrometheusMonitor() *PrometheusMonitorSpec {
 	return nil
 }
 
+func (in *GreptimeDBStandalone) GetPodDisruptionBudget() *PodDisruptionBudgetSpec {
+	if in != nil {
+		return in.Spec.PodDisruptionBudget
+	}
+	return nil
+}
+
 func (in *GreptimeDBStandalone) GetTLS() *TLSSpec {
 	if in != nil {
 		return in.Spec.TLS
@@ -271,6 +291,14 @@ func (in *GreptimeDBStandaloneStatus) SetCondition(condition Condition) {
 	in.Conditions = SetCondition(in.Conditions, condition)
 }
 
+// IsPaused returns true if the reconciliation of the standalone is paused by the spec or the annotation.
+func (in *GreptimeDBStandalone) IsPaused() bool {
+	if in == nil {
+		return false
+	}
+	return in.Spec.Paused || in.GetAnnotations()[PausedAnnotationKey] == "true"
+}
+
 // +kubebuilder:object:root=true
 
 // GreptimeDBStandaloneList contains a list of GreptimeDBStandalone
@@ -58,15 +58,24 @@ func (r *GreptimeDBStandalone) ValidateCreate(_ context.Context, obj runtime.Obj
 func (r *GreptimeDBStandalone) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	greptimedbstandalonelog.Info("validate update", "name", r.Name)
 
-	_, ok := newObj.(*GreptimeDBStandalone)
+	newStandalone, ok := newObj.(*GreptimeDBStandalone)
 	if !ok {
 		return nil, fmt.Errorf("unexpected type: %T", newObj)
 	}
 
+	oldStandalone, ok := oldObj.(*GreptimeDBStandalone)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type: %T", oldObj)
+	}
+
 	if err := r.Validate(); err != nil {
 		return nil, err
 	}
 
+	if err := newStandalone.ValidateStorageUpdate(oldStandalone); err != nil {
+		return nil, err
+	}
+
 	return nil, nil
 }
 
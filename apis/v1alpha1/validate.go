@@ -17,12 +17,24 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pelletier/go-toml"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/util/cron"
 )
 
 // Validate checks the GreptimeDBCluster and returns an error if it is invalid.
@@ -55,12 +67,20 @@ func (in *GreptimeDBCluster) Validate() error {
 		return err
 	}
 
+	if in.GetFlownode() != nil && len(in.GetFlownodeGroups()) > 0 {
+		return fmt.Errorf("flownode and flownodeGroups cannot be set at the same time")
+	}
+
 	if in.GetFlownode() != nil {
 		if err := in.validateFlownode(); err != nil {
 			return err
 		}
 	}
 
+	if err := in.validateFlownodeGroups(); err != nil {
+		return err
+	}
+
 	if wal := in.GetWALProvider(); wal != nil {
 		if err := validateWALProvider(wal); err != nil {
 			return err
@@ -73,6 +93,18 @@ func (in *GreptimeDBCluster) Validate() error {
 		}
 	}
 
+	if deadline := in.GetRolloutPolicy().GetProgressDeadline(); deadline != nil && deadline.Duration <= 0 {
+		return fmt.Errorf("rolloutPolicy.progressDeadline must be greater than 0")
+	}
+
+	if err := in.validateCloneFrom(); err != nil {
+		return err
+	}
+
+	if err := in.validateMaintenanceWindows(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -136,6 +168,14 @@ func (in *GreptimeDBCluster) validateFrontend() error {
 		return fmt.Errorf("invalid frontend toml config: '%v'", err)
 	}
 
+	if err := validateAutoscaling(in.GetFrontend().GetAutoscaling(), in.GetFrontend().GetReplicas()); err != nil {
+		return fmt.Errorf("invalid frontend autoscaling: %v", err)
+	}
+
+	if err := validatePodDisruptionBudget(in.GetFrontend().GetPodDisruptionBudget()); err != nil {
+		return fmt.Errorf("invalid frontend podDisruptionBudget: %v", err)
+	}
+
 	return nil
 }
 
@@ -148,20 +188,98 @@ func (in *GreptimeDBCluster) validateFrontendGroups() error {
 		if err := validateTomlConfig(frontend.GetConfig()); err != nil {
 			return fmt.Errorf("invalid frontend toml config: '%v'", err)
 		}
+
+		if err := validateAutoscaling(frontend.GetAutoscaling(), frontend.GetReplicas()); err != nil {
+			return fmt.Errorf("invalid autoscaling of frontend group '%s': %v", frontend.GetName(), err)
+		}
+
+		if err := validatePodDisruptionBudget(frontend.GetPodDisruptionBudget()); err != nil {
+			return fmt.Errorf("invalid podDisruptionBudget of frontend group '%s': %v", frontend.GetName(), err)
+		}
 	}
 
 	return nil
 }
 
 func (in *GreptimeDBCluster) validateDatanodeGroups() error {
+	seen := make(map[string]bool)
 	for _, datanode := range in.GetDatanodeGroups() {
 		if len(datanode.GetName()) == 0 {
 			return fmt.Errorf("the datanode group name must be specified")
 		}
 
+		// The datanode groups are deployed by the StatefulSets that are named by the group names.
+		if seen[datanode.GetName()] {
+			return fmt.Errorf("duplicate datanode group '%s'", datanode.GetName())
+		}
+		seen[datanode.GetName()] = true
+
+		if len(datanode.GetZones()) > 0 {
+			return fmt.Errorf("zones of datanode group '%s' cannot be set, please set zone instead", datanode.GetName())
+		}
+
 		if err := validateTomlConfig(datanode.GetConfig()); err != nil {
 			return fmt.Errorf("invalid datanode toml config: '%v'", err)
 		}
+
+		if err := validateCanary(datanode.GetCanary()); err != nil {
+			return fmt.Errorf("invalid canary of datanode group '%s': %v", datanode.GetName(), err)
+		}
+
+		if err := validateRebalance(datanode.GetRebalance()); err != nil {
+			return fmt.Errorf("invalid rebalance of datanode group '%s': %v", datanode.GetName(), err)
+		}
+
+		if err := in.validateScalingPolicy(datanode.GetScalingPolicy()); err != nil {
+			return fmt.Errorf("invalid scaling policy of datanode group '%s': %v", datanode.GetName(), err)
+		}
+
+		if err := validatePodDisruptionBudget(datanode.GetPodDisruptionBudget()); err != nil {
+			return fmt.Errorf("invalid podDisruptionBudget of datanode group '%s': %v", datanode.GetName(), err)
+		}
+
+		if err := validatePVCSpec(datanode.GetFileStorage(), fileStorageKindData, ptr.Deref(datanode.GetReplicas(), 0)); err != nil {
+			return fmt.Errorf("invalid storage of datanode group '%s': %v", datanode.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func (in *GreptimeDBCluster) validateCloneFrom() error {
+	if in.Spec.CloneFrom == nil {
+		return nil
+	}
+
+	if in.Spec.CloneFrom.Name == "" {
+		return fmt.Errorf("the name of the source cluster must be specified")
+	}
+
+	if in.Spec.CloneFrom.Name == in.Name {
+		return fmt.Errorf("the cluster can't be cloned from itself")
+	}
+
+	return nil
+}
+
+func (in *GreptimeDBCluster) validateMaintenanceWindows() error {
+	for i, window := range in.GetMaintenanceWindows() {
+		if _, err := cron.Parse(window.Schedule); err != nil {
+			return fmt.Errorf("invalid schedule in maintenanceWindows[%d]: %v", i, err)
+		}
+
+		if window.Duration.Duration <= 0 {
+			return fmt.Errorf("duration must be greater than 0 in maintenanceWindows[%d]", i)
+		}
+
+		if window.TimeZone != "" {
+			if _, err := time.LoadLocation(window.TimeZone); err != nil {
+				return fmt.Errorf("invalid timeZone in maintenanceWindows[%d]: %v", i, err)
+			}
+			if hasCronTimeZone(window.Schedule) {
+				return fmt.Errorf("timeZone can't be set with the time zone prefix of the schedule in maintenanceWindows[%d]", i)
+			}
+		}
 	}
 
 	return nil
@@ -172,6 +290,10 @@ func (in *GreptimeDBCluster) validateMeta() error {
 		return fmt.Errorf("invalid meta toml config: '%v'", err)
 	}
 
+	if err := validatePodDisruptionBudget(in.GetMeta().GetPodDisruptionBudget()); err != nil {
+		return fmt.Errorf("invalid meta podDisruptionBudget: %v", err)
+	}
+
 	if err := in.validateMetaBackendStorage(); err != nil {
 		return err
 	}
@@ -198,11 +320,66 @@ func (in *GreptimeDBCluster) validateDatanode() error {
 		if fs.IsUseEmptyDir() && fs.GetStorageClassName() != nil {
 			return fmt.Errorf("cannot set storageClassName when useEmptyDir is true")
 		}
+
+		if err := validatePVCSpec(fs, fileStorageKindData, ptr.Deref(in.GetDatanode().GetReplicas(), 0)); err != nil {
+			return fmt.Errorf("invalid datanode storage: %v", err)
+		}
 	}
 
 	if err := validateTomlConfig(in.GetDatanode().GetConfig()); err != nil {
 		return fmt.Errorf("invalid datanode toml config: '%v'", err)
 	}
+
+	if err := validateCanary(in.GetDatanode().GetCanary()); err != nil {
+		return fmt.Errorf("invalid datanode canary: %v", err)
+	}
+
+	if err := validateRebalance(in.GetDatanode().GetRebalance()); err != nil {
+		return fmt.Errorf("invalid datanode rebalance: %v", err)
+	}
+
+	if err := in.validateScalingPolicy(in.GetDatanode().GetScalingPolicy()); err != nil {
+		return fmt.Errorf("invalid datanode scaling policy: %v", err)
+	}
+
+	if err := validatePodDisruptionBudget(in.GetDatanode().GetPodDisruptionBudget()); err != nil {
+		return fmt.Errorf("invalid datanode podDisruptionBudget: %v", err)
+	}
+
+	if err := validateZones(in.GetDatanode()); err != nil {
+		return fmt.Errorf("invalid datanode zones: %v", err)
+	}
+	return nil
+}
+
+func validateZones(datanode *DatanodeSpec) error {
+	zones := datanode.GetZones()
+	if len(zones) == 0 {
+		return nil
+	}
+
+	if datanode.GetZone() != "" {
+		return fmt.Errorf("zones and zone cannot be set at the same time")
+	}
+
+	// The datanodes in the zones are scaled together by the replicas of the datanode.
+	if datanode.GetScalingPolicy().IsEnabled() {
+		return fmt.Errorf("the scaling policy is not supported with zones")
+	}
+
+	seen := make(map[string]bool)
+	for _, zone := range zones {
+		// The zone is used as the name of the datanode group.
+		if errs := validation.IsDNS1123Label(zone); len(errs) > 0 {
+			return fmt.Errorf("invalid zone '%s': %v", zone, errs)
+		}
+
+		if seen[zone] {
+			return fmt.Errorf("duplicate zone '%s'", zone)
+		}
+		seen[zone] = true
+	}
+
 	return nil
 }
 
@@ -210,6 +387,170 @@ func (in *GreptimeDBCluster) validateFlownode() error {
 	if err := validateTomlConfig(in.GetFlownode().GetConfig()); err != nil {
 		return fmt.Errorf("invalid flownode toml config: '%v'", err)
 	}
+
+	if err := validateCanary(in.GetFlownode().GetCanary()); err != nil {
+		return fmt.Errorf("invalid flownode canary: %v", err)
+	}
+
+	if err := validatePodDisruptionBudget(in.GetFlownode().GetPodDisruptionBudget()); err != nil {
+		return fmt.Errorf("invalid flownode podDisruptionBudget: %v", err)
+	}
+	return nil
+}
+
+func (in *GreptimeDBCluster) validateFlownodeGroups() error {
+	seen := make(map[string]bool)
+	for _, flownode := range in.GetFlownodeGroups() {
+		if len(flownode.GetName()) == 0 {
+			return fmt.Errorf("the flownode group name must be specified")
+		}
+
+		// The flownode groups are deployed by the StatefulSets that are named by the group names.
+		if seen[flownode.GetName()] {
+			return fmt.Errorf("duplicate flownode group '%s'", flownode.GetName())
+		}
+		seen[flownode.GetName()] = true
+
+		if err := validateTomlConfig(flownode.GetConfig()); err != nil {
+			return fmt.Errorf("invalid flownode toml config: '%v'", err)
+		}
+
+		if err := validateCanary(flownode.GetCanary()); err != nil {
+			return fmt.Errorf("invalid canary of flownode group '%s': %v", flownode.GetName(), err)
+		}
+
+		if err := validatePodDisruptionBudget(flownode.GetPodDisruptionBudget()); err != nil {
+			return fmt.Errorf("invalid podDisruptionBudget of flownode group '%s': %v", flownode.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func validateRebalance(rebalance *RebalancePolicy) error {
+	if rebalance != nil && rebalance.MaxConcurrentMigrations != nil && *rebalance.MaxConcurrentMigrations <= 0 {
+		return fmt.Errorf("maxConcurrentMigrations must be greater than 0")
+	}
+	return nil
+}
+
+func (in *GreptimeDBCluster) validateScalingPolicy(policy *DatanodeScalingPolicy) error {
+	if !policy.IsEnabled() {
+		return nil
+	}
+
+	if policy.MinReplicas <= 0 {
+		return fmt.Errorf("minReplicas must be greater than 0")
+	}
+
+	if policy.MaxReplicas < policy.MinReplicas {
+		return fmt.Errorf("maxReplicas must be greater than or equal to minReplicas")
+	}
+
+	if policy.Endpoint == "" && !in.GetMonitoring().IsEnabled() {
+		return fmt.Errorf("the endpoint must be specified if the monitoring is not enabled")
+	}
+
+	if policy.Interval != nil && policy.Interval.Duration <= 0 {
+		return fmt.Errorf("interval must be greater than 0")
+	}
+
+	if len(policy.Metrics) == 0 {
+		return fmt.Errorf("metrics must be specified")
+	}
+
+	names := make(map[string]bool)
+	for _, metric := range policy.Metrics {
+		if metric.Name == "" || metric.Query == "" {
+			return fmt.Errorf("the name and query of the metric must be specified")
+		}
+
+		if names[metric.Name] {
+			return fmt.Errorf("duplicated metric '%s'", metric.Name)
+		}
+		names[metric.Name] = true
+
+		if metric.ScaleOutThreshold == "" && metric.ScaleInThreshold == "" {
+			return fmt.Errorf("at least one of the thresholds of the metric '%s' must be specified", metric.Name)
+		}
+
+		scaleOut, scaleIn, err := metric.GetThresholds()
+		if err != nil {
+			return fmt.Errorf("invalid threshold of the metric '%s': %v", metric.Name, err)
+		}
+
+		if scaleOut != nil && scaleIn != nil && *scaleIn >= *scaleOut {
+			return fmt.Errorf("the scale-in threshold of the metric '%s' must be less than the scale-out threshold", metric.Name)
+		}
+	}
+
+	return nil
+}
+
+func validateAutoscaling(autoscaling *AutoscalingSpec, replicas *int32) error {
+	if !autoscaling.IsEnabled() {
+		return nil
+	}
+
+	if autoscaling.MaxReplicas <= 0 {
+		return fmt.Errorf("maxReplicas must be greater than 0")
+	}
+
+	minReplicas := autoscaling.GetMinReplicas(replicas)
+	if minReplicas <= 0 {
+		return fmt.Errorf("minReplicas must be greater than 0")
+	}
+
+	if autoscaling.MaxReplicas < minReplicas {
+		return fmt.Errorf("maxReplicas must be greater than or equal to minReplicas")
+	}
+
+	return nil
+}
+
+func validatePodDisruptionBudget(pdb *PodDisruptionBudgetSpec) error {
+	if pdb == nil {
+		return nil
+	}
+
+	if pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+		return fmt.Errorf("minAvailable and maxUnavailable cannot be set at the same time")
+	}
+
+	return nil
+}
+
+func validateCanary(canary *CanarySpec) error {
+	if canary == nil {
+		return nil
+	}
+
+	if len(canary.Steps) == 0 {
+		return fmt.Errorf("steps must be specified")
+	}
+
+	for i, step := range canary.Steps {
+		if step <= 0 {
+			return fmt.Errorf("step must be greater than 0")
+		}
+		if i > 0 && step <= canary.Steps[i-1] {
+			return fmt.Errorf("steps must be in ascending order")
+		}
+	}
+
+	if canary.SoakDuration != nil && canary.SoakDuration.Duration <= 0 {
+		return fmt.Errorf("soakDuration must be greater than 0")
+	}
+
+	if metrics := canary.GetHealthCheck().GetMetrics(); metrics != nil {
+		if metrics.Name == "" {
+			return fmt.Errorf("the name of the metrics check must be specified")
+		}
+		if _, err := strconv.ParseFloat(metrics.MaxValue, 64); err != nil {
+			return fmt.Errorf("invalid maxValue '%s' of the metrics check: %v", metrics.MaxValue, err)
+		}
+	}
+
 	return nil
 }
 
@@ -223,6 +564,15 @@ func (in *GreptimeDBStandalone) Validate() error {
 		return fmt.Errorf("invalid standalone toml config: '%v'", err)
 	}
 
+	// The standalone always has a single replica.
+	if err := validatePVCSpec(in.GetDatanodeFileStorage(), fileStorageKindData, 1); err != nil {
+		return fmt.Errorf("invalid datanode storage: %v", err)
+	}
+
+	if err := validatePodDisruptionBudget(in.GetPodDisruptionBudget()); err != nil {
+		return fmt.Errorf("invalid podDisruptionBudget: %v", err)
+	}
+
 	if wal := in.GetWALProvider(); wal != nil {
 		if err := validateWALProvider(wal); err != nil {
 			return err
@@ -281,6 +631,285 @@ func (in *GreptimeDBStandalone) Check(ctx context.Context, client client.Client)
 	return nil
 }
 
+// crossMajorUpgradePaths are the supported upgrades across the major versions.
+// The key is the last minor version of the major version and the value is the first minor version of the next major version.
+var crossMajorUpgradePaths = map[string]string{
+	"0.17": "1.0",
+}
+
+// CheckUpgradePath checks whether the cluster can be upgraded from the version to the other version directly.
+// The downgrade and the upgrade that skips minor versions are rejected, for example, from `v0.14.x` to `v0.16.x`.
+// The versions that are not semantic versions, for example, `latest`, are not checked.
+func CheckUpgradePath(from, to string) error {
+	if from == "" || to == "" || from == to {
+		return nil
+	}
+
+	fromVersion, err := version.ParseSemantic(from)
+	if err != nil {
+		return nil
+	}
+
+	toVersion, err := version.ParseSemantic(to)
+	if err != nil {
+		return nil
+	}
+
+	if toVersion.LessThan(fromVersion) {
+		return fmt.Errorf("downgrade from '%s' to '%s' is not supported", from, to)
+	}
+
+	// The upgrade in the same minor version or to the next minor version.
+	if fromVersion.Major() == toVersion.Major() && toVersion.Minor() <= fromVersion.Minor()+1 {
+		return nil
+	}
+
+	fromMinor := fmt.Sprintf("%d.%d", fromVersion.Major(), fromVersion.Minor())
+	toMinor := fmt.Sprintf("%d.%d", toVersion.Major(), toVersion.Minor())
+	if crossMajorUpgradePaths[fromMinor] == toMinor {
+		return nil
+	}
+
+	next := fmt.Sprintf("%d.%d", fromVersion.Major(), fromVersion.Minor()+1)
+	if nextMinor, ok := crossMajorUpgradePaths[fromMinor]; ok {
+		next = nextMinor
+	}
+
+	return fmt.Errorf("upgrade from '%s' to '%s' skips minor versions, please upgrade to 'v%s.x' first", from, to, next)
+}
+
+// ValidateZonesUpdate checks that the zones of the datanode are not changed, since the datanode groups are named by the zones.
+// Adding, removing or renaming a zone would rename the datanode StatefulSets, and the regions on the old ones would be lost.
+func (in *GreptimeDBCluster) ValidateZonesUpdate(oldZones []string) error {
+	if zones := in.GetDatanode().GetZones(); !slices.Equal(oldZones, zones) {
+		return fmt.Errorf("zones of datanode can't be changed from %v to %v", oldZones, zones)
+	}
+	return nil
+}
+
+// ValidateStorageUpdate checks that the storage sizes of the cluster are not decreased and the other PVC fields are not changed, since the PVCs can only be expanded.
+func (in *GreptimeDBCluster) ValidateStorageUpdate(old *GreptimeDBCluster) error {
+	if err := validateFileStorageUpdate(old.GetDatanode().GetFileStorage(), in.GetDatanode().GetFileStorage()); err != nil {
+		return fmt.Errorf("invalid datanode storage: %v", err)
+	}
+
+	for _, group := range in.GetDatanodeGroups() {
+		for _, oldGroup := range old.GetDatanodeGroups() {
+			if group.GetName() != oldGroup.GetName() {
+				continue
+			}
+			if err := validateFileStorageUpdate(oldGroup.GetFileStorage(), group.GetFileStorage()); err != nil {
+				return fmt.Errorf("invalid storage of datanode group '%s': %v", group.GetName(), err)
+			}
+		}
+	}
+
+	if err := validateFileStorageUpdate(old.GetWALProvider().GetRaftEngineWAL().GetFileStorage(), in.GetWALProvider().GetRaftEngineWAL().GetFileStorage()); err != nil {
+		return fmt.Errorf("invalid raft-engine WAL storage: %v", err)
+	}
+
+	if err := validateFileStorageUpdate(old.GetObjectStorageProvider().GetCacheFileStorage(), in.GetObjectStorageProvider().GetCacheFileStorage()); err != nil {
+		return fmt.Errorf("invalid cache storage: %v", err)
+	}
+
+	return nil
+}
+
+// ValidateStorageUpdate checks that the storage sizes of the standalone are not decreased and the other PVC fields are not changed, since the PVCs can only be expanded.
+func (in *GreptimeDBStandalone) ValidateStorageUpdate(old *GreptimeDBStandalone) error {
+	if err := validateFileStorageUpdate(old.GetDatanodeFileStorage(), in.GetDatanodeFileStorage()); err != nil {
+		return fmt.Errorf("invalid datanode storage: %v", err)
+	}
+
+	if err := validateFileStorageUpdate(old.GetWALProvider().GetRaftEngineWAL().GetFileStorage(), in.GetWALProvider().GetRaftEngineWAL().GetFileStorage()); err != nil {
+		return fmt.Errorf("invalid raft-engine WAL storage: %v", err)
+	}
+
+	if err := validateFileStorageUpdate(old.GetObjectStorageProvider().GetCacheFileStorage(), in.GetObjectStorageProvider().GetCacheFileStorage()); err != nil {
+		return fmt.Errorf("invalid cache storage: %v", err)
+	}
+
+	return nil
+}
+
+func validateFileStorageUpdate(old, new *FileStorage) error {
+	if old == nil || new == nil {
+		return nil
+	}
+
+	// The volume claim templates of the StatefulSet are immutable. Only the storage size and the volumeAttributesClassName
+	// can be changed by patching the PVCs, and the StatefulSet is recreated with the new volume claim templates.
+	if !reflect.DeepEqual(old.GetAccessModes(), new.GetAccessModes()) || !reflect.DeepEqual(old.GetVolumeMode(), new.GetVolumeMode()) ||
+		!reflect.DeepEqual(old.GetDataSource(), new.GetDataSource()) || !reflect.DeepEqual(old.GetDataSourceRef(), new.GetDataSourceRef()) {
+		return fmt.Errorf("accessModes, volumeMode, dataSource and dataSourceRef can't be changed after the PVCs are created")
+	}
+
+	if old.IsUsePVC() != new.IsUsePVC() {
+		return fmt.Errorf("the storage can't be switched between the PVC and the empty dir or the ephemeral volume after the PVCs are created")
+	}
+
+	// The empty dir and the ephemeral volume are recreated with the pods, so the size can be changed freely.
+	if !new.IsUsePVC() || old.GetSize() == "" || new.GetSize() == "" {
+		return nil
+	}
+
+	oldSize, err := resource.ParseQuantity(old.GetSize())
+	if err != nil {
+		return nil
+	}
+
+	newSize, err := resource.ParseQuantity(new.GetSize())
+	if err != nil {
+		return fmt.Errorf("invalid storageSize '%s': %v", new.GetSize(), err)
+	}
+
+	if newSize.Cmp(oldSize) < 0 {
+		return fmt.Errorf("storageSize can't be decreased from '%s' to '%s'", old.GetSize(), new.GetSize())
+	}
+
+	return nil
+}
+
+// Validate checks the GreptimeDBBackup and returns an error if it is invalid.
+func (in *GreptimeDBBackup) Validate() error {
+	if in == nil {
+		return nil
+	}
+
+	if in.Spec.ClusterName == "" {
+		return fmt.Errorf("clusterName is required in backup")
+	}
+
+	if osp := in.GetObjectStorage(); osp != nil {
+		if osp.getSetObjectStorageCount() != 1 {
+			return fmt.Errorf("one and only one storage provider must be set in backup objectStorage")
+		}
+	}
+
+	for _, database := range in.Spec.Databases {
+		if database == "" {
+			return fmt.Errorf("database name can not be empty in backup")
+		}
+	}
+
+	if timeout := in.GetPendingTimeout(); timeout != nil && timeout.Duration <= 0 {
+		return fmt.Errorf("pendingTimeout must be greater than 0 in backup")
+	}
+
+	return nil
+}
+
+// Check checks the GreptimeDBBackup with other resources and returns an error if it is invalid.
+func (in *GreptimeDBBackup) Check(ctx context.Context, client client.Client) error {
+	if secretName := in.Spec.CredentialsSecretName; secretName != "" {
+		if err := checkSecretData(ctx, client, in.GetNamespace(), secretName, []string{UsernameSecretKey, PasswordSecretKey}); err != nil {
+			return err
+		}
+	}
+
+	if err := checkObjectStorageCredentialsSecret(ctx, client, in.GetNamespace(), in.GetObjectStorage()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Validate checks the GreptimeDBBackupSchedule and returns an error if it is invalid.
+func (in *GreptimeDBBackupSchedule) Validate() error {
+	if in == nil {
+		return nil
+	}
+
+	if _, err := cron.Parse(in.Spec.Schedule); err != nil {
+		return fmt.Errorf("invalid schedule in backup schedule: %v", err)
+	}
+
+	if in.Spec.TimeZone != "" {
+		if _, err := time.LoadLocation(in.Spec.TimeZone); err != nil {
+			return fmt.Errorf("invalid timeZone in backup schedule: %v", err)
+		}
+		if hasCronTimeZone(in.Spec.Schedule) {
+			return fmt.Errorf("timeZone can't be set with the time zone prefix of the schedule in backup schedule")
+		}
+	}
+
+	if count := in.GetRetention().GetCount(); count != nil && *count < 1 {
+		return fmt.Errorf("retention count must be greater than 0 in backup schedule")
+	}
+
+	if maxAge := in.GetRetention().GetMaxAge(); maxAge != nil && maxAge.Duration <= 0 {
+		return fmt.Errorf("retention maxAge must be greater than 0 in backup schedule")
+	}
+
+	backup := &GreptimeDBBackup{Spec: in.Spec.BackupTemplate}
+	if err := backup.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Check checks the GreptimeDBBackupSchedule with other resources and returns an error if it is invalid.
+func (in *GreptimeDBBackupSchedule) Check(ctx context.Context, client client.Client) error {
+	backup := &GreptimeDBBackup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: in.GetNamespace()},
+		Spec:       in.Spec.BackupTemplate,
+	}
+	return backup.Check(ctx, client)
+}
+
+// Validate checks the GreptimeDBRestore and returns an error if it is invalid.
+func (in *GreptimeDBRestore) Validate() error {
+	if in == nil {
+		return nil
+	}
+
+	if in.Spec.ClusterName == "" {
+		return fmt.Errorf("clusterName is required in restore")
+	}
+
+	if (in.Spec.BackupName == "") == (in.GetSource() == nil) {
+		return fmt.Errorf("one and only one of backupName and source must be set in restore")
+	}
+
+	if source := in.GetSource(); source != nil {
+		if source.Prefix == "" {
+			return fmt.Errorf("prefix is required in restore source")
+		}
+
+		if len(in.Spec.Databases) == 0 {
+			return fmt.Errorf("databases are required when restoring from source")
+		}
+
+		if osp := source.GetObjectStorage(); osp != nil && osp.getSetObjectStorageCount() != 1 {
+			return fmt.Errorf("one and only one storage provider must be set in restore source objectStorage")
+		}
+	}
+
+	for _, database := range in.Spec.Databases {
+		if database == "" {
+			return fmt.Errorf("database name can not be empty in restore")
+		}
+	}
+
+	return nil
+}
+
+// Check checks the GreptimeDBRestore with other resources and returns an error if it is invalid.
+func (in *GreptimeDBRestore) Check(ctx context.Context, client client.Client) error {
+	if secretName := in.Spec.CredentialsSecretName; secretName != "" {
+		if err := checkSecretData(ctx, client, in.GetNamespace(), secretName, []string{UsernameSecretKey, PasswordSecretKey}); err != nil {
+			return err
+		}
+	}
+
+	if err := checkObjectStorageCredentialsSecret(ctx, client, in.GetNamespace(), in.GetSource().GetObjectStorage()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func validateTomlConfig(input string) error {
 	if len(input) > 0 {
 		data := make(map[string]interface{})
@@ -302,7 +931,7 @@ func validateWALProvider(input *WALProviderSpec) error {
 	}
 
 	if fs := input.GetRaftEngineWAL().GetFileStorage(); fs != nil {
-		if err := validateFileStorage(fs); err != nil {
+		if err := validateFileStorage(fs, fileStorageKindWAL); err != nil {
 			return err
 		}
 	}
@@ -320,7 +949,7 @@ func validateObjectStorageProvider(input *ObjectStorageProviderSpec) error {
 	}
 
 	if fs := input.GetCacheFileStorage(); fs != nil {
-		if err := validateFileStorage(fs); err != nil {
+		if err := validateFileStorage(fs, fileStorageKindCache); err != nil {
 			return err
 		}
 	}
@@ -328,7 +957,7 @@ func validateObjectStorageProvider(input *ObjectStorageProviderSpec) error {
 	return nil
 }
 
-func validateFileStorage(input *FileStorage) error {
+func validateFileStorage(input *FileStorage, kind fileStorageKind) error {
 	if input == nil {
 		return nil
 	}
@@ -345,6 +974,101 @@ func validateFileStorage(input *FileStorage) error {
 		return fmt.Errorf("storageSize is required in file storage")
 	}
 
+	return validatePVCSpec(input, kind, 0)
+}
+
+// fileStorageKind is the usage of the file storage, which decides the PVC fields that can be used.
+type fileStorageKind string
+
+const (
+	fileStorageKindData  fileStorageKind = "data"
+	fileStorageKindWAL   fileStorageKind = "wal"
+	fileStorageKindCache fileStorageKind = "cache"
+)
+
+// validatePVCSpec checks the PVC fields of the file storage that can't be used by the components.
+// The replicas is the number of the pods that use the PVCs created from the file storage.
+func validatePVCSpec(input *FileStorage, kind fileStorageKind, replicas int32) error {
+	if input == nil {
+		return nil
+	}
+
+	if input.IsUseEmptyDir() && input.IsUseEphemeralVolume() {
+		return fmt.Errorf("useEmptyDir and useEphemeralVolume can't be both true")
+	}
+
+	if input.GetEmptyDirMedium() != corev1.StorageMediumDefault && !input.IsUseEmptyDir() {
+		return fmt.Errorf("emptyDirMedium can only be set when useEmptyDir is true")
+	}
+
+	if input.IsUseEmptyDir() && (input.GetStorageClassName() != nil || len(input.GetAccessModes()) > 0 || input.GetVolumeMode() != nil ||
+		input.GetDataSource() != nil || input.GetDataSourceRef() != nil || input.GetVolumeAttributesClassName() != nil) {
+		return fmt.Errorf("cannot set the PVC fields when useEmptyDir is true")
+	}
+
+	for _, accessMode := range input.GetAccessModes() {
+		if accessMode == corev1.ReadOnlyMany {
+			return fmt.Errorf("accessMode '%s' is not allowed because the storage must be writable", accessMode)
+		}
+	}
+
+	if volumeMode := input.GetVolumeMode(); volumeMode != nil && *volumeMode == corev1.PersistentVolumeBlock {
+		return fmt.Errorf("volumeMode '%s' is not allowed because GreptimeDB can't use the raw block devices", *volumeMode)
+	}
+
+	if input.GetDataSource() != nil || input.GetDataSourceRef() != nil {
+		// The WAL and the cache only make sense with the data of the same datanode, and the cache can be rebuilt from the object storage.
+		if kind != fileStorageKindData {
+			return fmt.Errorf("dataSource and dataSourceRef can't be set for the %s storage", kind)
+		}
+
+		// Every replica would be restored from the same data, and the datanodes would open the same regions.
+		if replicas > 1 {
+			return fmt.Errorf("dataSource and dataSourceRef can't be set when there are %d replicas", replicas)
+		}
+	}
+
+	// The same as the validation of the PVC, the dataSource and dataSourceRef must be the same if both are set.
+	if dataSource, dataSourceRef := input.GetDataSource(), input.GetDataSourceRef(); dataSource != nil && dataSourceRef != nil {
+		if dataSourceRef.Namespace != nil && *dataSourceRef.Namespace != "" {
+			return fmt.Errorf("dataSourceRef.namespace can't be set when dataSource is set")
+		}
+
+		if ptr.Deref(dataSource.APIGroup, "") != ptr.Deref(dataSourceRef.APIGroup, "") ||
+			dataSource.Kind != dataSourceRef.Kind || dataSource.Name != dataSourceRef.Name {
+			return fmt.Errorf("dataSource and dataSourceRef must be the same if both are set")
+		}
+	}
+
+	return nil
+}
+
+// checkObjectStorageCredentialsSecret checks if the credentials secret of the object storage exists and contains the required keys.
+func checkObjectStorageCredentialsSecret(ctx context.Context, client client.Client, namespace string, osp *ObjectStorageProviderSpec) error {
+	if secretName := osp.GetS3Storage().GetSecretName(); secretName != "" {
+		if err := checkS3CredentialsSecret(ctx, client, namespace, secretName); err != nil {
+			return err
+		}
+	}
+
+	if secretName := osp.GetOSSStorage().GetSecretName(); secretName != "" {
+		if err := checkOSSCredentialsSecret(ctx, client, namespace, secretName); err != nil {
+			return err
+		}
+	}
+
+	if secretName := osp.GetGCSStorage().GetSecretName(); secretName != "" {
+		if err := checkGCSCredentialsSecret(ctx, client, namespace, secretName); err != nil {
+			return err
+		}
+	}
+
+	if secretName := osp.GetAZBlobStorage().GetSecretName(); secretName != "" {
+		if err := checkAZBlobCredentialsSecret(ctx, client, namespace, secretName); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -403,3 +1127,9 @@ func checkSecretData(ctx context.Context, client client.Client, namespace, name
 
 	return nil
 }
+
+// hasCronTimeZone returns true if the cron expression has the time zone prefix, for example, `CRON_TZ=Asia/Shanghai 0 2 * * *`.
+func hasCronTimeZone(schedule string) bool {
+	schedule = strings.TrimSpace(schedule)
+	return strings.HasPrefix(schedule, "CRON_TZ=") || strings.HasPrefix(schedule, "TZ=")
+}
@@ -20,6 +20,8 @@ import (
 	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/yaml"
 )
 
@@ -48,8 +50,12 @@ func TestClusterValidation(t *testing.T) {
 				t.Fatalf("failed to unmarshal %s: %v", inputFile, err)
 			}
 
-			if err := input.Validate(); err != nil && !expectError(input.GetName()) {
-				t.Errorf("expected no error, but got %v", err)
+			err = input.Validate()
+			if expectError(input.GetName()) && err == nil {
+				t.Errorf("%s: expected an error, but got nil", input.GetName())
+			}
+			if !expectError(input.GetName()) && err != nil {
+				t.Errorf("%s: expected no error, but got %v", input.GetName(), err)
 			}
 		}
 	}
@@ -80,14 +86,277 @@ func TestStandaloneValidation(t *testing.T) {
 				t.Fatalf("failed to unmarshal %s: %v", inputFile, err)
 			}
 
-			if err := input.Validate(); err != nil && !expectError(input.GetName()) {
-				t.Errorf("expected no error, but got %v", err)
+			err = input.Validate()
+			if expectError(input.GetName()) && err == nil {
+				t.Errorf("%s: expected an error, but got nil", input.GetName())
+			}
+			if !expectError(input.GetName()) && err != nil {
+				t.Errorf("%s: expected no error, but got %v", input.GetName(), err)
 			}
 		}
 	}
 }
 
+func TestCheckUpgradePath(t *testing.T) {
+	tests := []struct {
+		from, to string
+		wantErr  bool
+	}{
+		{"", "v0.14.0", false},
+		{"v0.14.0", "v0.14.3", false},
+		{"v0.14.3", "v0.15.0", false},
+		{"v0.17.2", "v1.0.0", false},
+		{"latest", "v0.15.0", false},
+		{"v0.14.0", "v0.16.0", true},
+		{"v0.15.0", "v0.14.0", true},
+		{"v0.15.0", "v1.0.0", true},
+	}
+
+	for _, tt := range tests {
+		if err := CheckUpgradePath(tt.from, tt.to); (err != nil) != tt.wantErr {
+			t.Errorf("CheckUpgradePath(%q, %q): wantErr %v, got: %v", tt.from, tt.to, tt.wantErr, err)
+		}
+	}
+}
+
 // If the resource name contains the word "error", we expect an error in the validation.
 func expectError(name string) bool {
 	return strings.Contains(name, "error")
 }
+
+func TestValidateStorageUpdate(t *testing.T) {
+	newCluster := func(size string) *GreptimeDBCluster {
+		return &GreptimeDBCluster{
+			Spec: GreptimeDBClusterSpec{
+				Datanode: &DatanodeSpec{
+					Storage: &DatanodeStorageSpec{FileStorage: &FileStorage{StorageSize: size}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		old, new string
+		wantErr  bool
+	}{
+		{"10Gi", "10Gi", false},
+		{"10Gi", "20Gi", false},
+		{"1Ti", "1024Gi", false},
+		{"", "10Gi", false},
+		{"20Gi", "10Gi", true},
+	}
+
+	for _, tt := range tests {
+		if err := newCluster(tt.new).ValidateStorageUpdate(newCluster(tt.old)); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateStorageUpdate(%q, %q): wantErr %v, got: %v", tt.old, tt.new, tt.wantErr, err)
+		}
+	}
+}
+
+func TestValidateZonesUpdate(t *testing.T) {
+	tests := []struct {
+		old, new []string
+		wantErr  bool
+	}{
+		{nil, nil, false},
+		{[]string{"zone-a", "zone-b"}, []string{"zone-a", "zone-b"}, false},
+		{nil, []string{"zone-a", "zone-b"}, true},
+		{[]string{"zone-a", "zone-b"}, []string{"zone-a", "zone-b", "zone-c"}, true},
+		{[]string{"zone-a", "zone-b"}, []string{"zone-a", "zone-c"}, true},
+		{[]string{"zone-a", "zone-b"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		cluster := &GreptimeDBCluster{Spec: GreptimeDBClusterSpec{Datanode: &DatanodeSpec{Zones: tt.new}}}
+		if err := cluster.ValidateZonesUpdate(tt.old); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateZonesUpdate(%v, %v): wantErr %v, got: %v", tt.old, tt.new, tt.wantErr, err)
+		}
+	}
+}
+
+func TestValidatePVCSpec(t *testing.T) {
+	snapshot := &corev1.TypedLocalObjectReference{APIGroup: ptr.To("snapshot.storage.k8s.io"), Kind: "VolumeSnapshot", Name: "snapshot"}
+	snapshotRef := &corev1.TypedObjectReference{APIGroup: ptr.To("snapshot.storage.k8s.io"), Kind: "VolumeSnapshot", Name: "snapshot"}
+
+	tests := []struct {
+		name     string
+		fs       *FileStorage
+		kind     fileStorageKind
+		replicas int32
+		wantErr  bool
+	}{
+		{
+			name: "nil",
+			kind: fileStorageKindData,
+		},
+		{
+			name:     "pvc fields",
+			fs:       &FileStorage{AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOncePod}, VolumeMode: ptr.To(corev1.PersistentVolumeFilesystem), VolumeAttributesClassName: ptr.To("gold")},
+			kind:     fileStorageKindData,
+			replicas: 3,
+		},
+		{
+			name:    "empty dir and ephemeral volume",
+			fs:      &FileStorage{UseEmptyDir: ptr.To(true), UseEphemeralVolume: ptr.To(true)},
+			kind:    fileStorageKindCache,
+			wantErr: true,
+		},
+		{
+			name:    "empty dir medium without empty dir",
+			fs:      &FileStorage{EmptyDirMedium: corev1.StorageMediumMemory},
+			kind:    fileStorageKindCache,
+			wantErr: true,
+		},
+		{
+			name:    "pvc fields with empty dir",
+			fs:      &FileStorage{UseEmptyDir: ptr.To(true), VolumeAttributesClassName: ptr.To("gold")},
+			kind:    fileStorageKindCache,
+			wantErr: true,
+		},
+		{
+			name:    "read only access mode",
+			fs:      &FileStorage{AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany}},
+			kind:    fileStorageKindData,
+			wantErr: true,
+		},
+		{
+			name:    "block volume mode",
+			fs:      &FileStorage{VolumeMode: ptr.To(corev1.PersistentVolumeBlock)},
+			kind:    fileStorageKindData,
+			wantErr: true,
+		},
+		{
+			name:     "data source of a single datanode",
+			fs:       &FileStorage{DataSource: snapshot, DataSourceRef: snapshotRef},
+			kind:     fileStorageKindData,
+			replicas: 1,
+		},
+		{
+			name:     "data source of multiple datanodes",
+			fs:       &FileStorage{DataSource: snapshot},
+			kind:     fileStorageKindData,
+			replicas: 3,
+			wantErr:  true,
+		},
+		{
+			name:     "data source ref of multiple datanodes",
+			fs:       &FileStorage{DataSourceRef: snapshotRef},
+			kind:     fileStorageKindData,
+			replicas: 3,
+			wantErr:  true,
+		},
+		{
+			name:    "data source of the wal",
+			fs:      &FileStorage{DataSource: snapshot},
+			kind:    fileStorageKindWAL,
+			wantErr: true,
+		},
+		{
+			name:    "data source ref of the cache",
+			fs:      &FileStorage{DataSourceRef: snapshotRef},
+			kind:    fileStorageKindCache,
+			wantErr: true,
+		},
+		{
+			name:     "different data source and data source ref",
+			fs:       &FileStorage{DataSource: snapshot, DataSourceRef: &corev1.TypedObjectReference{Kind: "PersistentVolumeClaim", Name: "pvc"}},
+			kind:     fileStorageKindData,
+			replicas: 1,
+			wantErr:  true,
+		},
+		{
+			name:     "data source ref in another namespace",
+			fs:       &FileStorage{DataSource: snapshot, DataSourceRef: &corev1.TypedObjectReference{APIGroup: snapshotRef.APIGroup, Kind: snapshotRef.Kind, Name: snapshotRef.Name, Namespace: ptr.To("other")}},
+			kind:     fileStorageKindData,
+			replicas: 1,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePVCSpec(tt.fs, tt.kind, tt.replicas); (err != nil) != tt.wantErr {
+				t.Errorf("wantErr %v, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateFileStorageUpdate(t *testing.T) {
+	newFileStorage := func(modify func(fs *FileStorage)) *FileStorage {
+		fs := &FileStorage{
+			Name:                      "datanode",
+			StorageSize:               "10Gi",
+			AccessModes:               []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			VolumeAttributesClassName: ptr.To("silver"),
+		}
+		if modify != nil {
+			modify(fs)
+		}
+		return fs
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(fs *FileStorage)
+		wantErr bool
+	}{
+		{
+			name: "unchanged",
+		},
+		{
+			name:   "expand the storage size",
+			modify: func(fs *FileStorage) { fs.StorageSize = "20Gi" },
+		},
+		{
+			name:    "decrease the storage size",
+			modify:  func(fs *FileStorage) { fs.StorageSize = "5Gi" },
+			wantErr: true,
+		},
+		{
+			name:   "change the volume attributes class",
+			modify: func(fs *FileStorage) { fs.VolumeAttributesClassName = ptr.To("gold") },
+		},
+		{
+			name:   "unset the volume attributes class",
+			modify: func(fs *FileStorage) { fs.VolumeAttributesClassName = nil },
+		},
+		{
+			name:    "change the access modes",
+			modify:  func(fs *FileStorage) { fs.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOncePod} },
+			wantErr: true,
+		},
+		{
+			name:    "change the volume mode",
+			modify:  func(fs *FileStorage) { fs.VolumeMode = ptr.To(corev1.PersistentVolumeFilesystem) },
+			wantErr: true,
+		},
+		{
+			name: "set the data source",
+			modify: func(fs *FileStorage) {
+				fs.DataSource = &corev1.TypedLocalObjectReference{Kind: "PersistentVolumeClaim", Name: "pvc"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "set the data source ref",
+			modify: func(fs *FileStorage) {
+				fs.DataSourceRef = &corev1.TypedObjectReference{Kind: "PersistentVolumeClaim", Name: "pvc"}
+			},
+			wantErr: true,
+		},
+		{
+			name:    "switch to the empty dir",
+			modify:  func(fs *FileStorage) { fs.UseEmptyDir = ptr.To(true) },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateFileStorageUpdate(newFileStorage(nil), newFileStorage(tt.modify)); (err != nil) != tt.wantErr {
+				t.Errorf("wantErr %v, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
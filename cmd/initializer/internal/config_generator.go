@@ -41,8 +41,14 @@ type Options struct {
 	// DatanodeGroupID is the id of the datanode group when use `DatanodeGroups` in GreptimeDBCluster.
 	DatanodeGroupID int32
 
+	// FlownodeGroupID is the id of the flownode group when use `FlownodeGroups` in GreptimeDBCluster.
+	FlownodeGroupID int32
+
 	// StartNodeID is the start node id of the datanode and flownode.
 	StartNodeID int32
+
+	// Zone is the zone of the datanode, which is set to the labels of the datanode for the region placement.
+	Zone string
 }
 
 type ConfigGenerator struct {
@@ -127,6 +133,10 @@ func (c *ConfigGenerator) generateDatanodeConfig(initConfig []byte) ([]byte, err
 
 	datanodeCfg.RPCServerAddr = ptr.To(common.GetServerAddress(enableIPv6, podIP, c.RPCPort))
 
+	if c.Zone != "" {
+		datanodeCfg.Zone = ptr.To(c.Zone)
+	}
+
 	return dbconfig.Marshal(cfg, v1alpha1.ConfigMergeStrategyOperatorFirst)
 }
 
@@ -201,8 +211,13 @@ func (c *ConfigGenerator) allocateNodeID() (uint64, error) {
 		return 0, fmt.Errorf("invalid hostname format '%s'", name)
 	}
 
-	if c.DatanodeGroupID >= 0 {
-		nodeID = uint64(c.DatanodeGroupID)<<32 | nodeID
+	// The group id is the high 32 bits of the node id, so the node ids of the groups don't collide.
+	groupID := c.DatanodeGroupID
+	if c.RoleKind == string(v1alpha1.FlownodeRoleKind) {
+		groupID = c.FlownodeGroupID
+	}
+	if groupID >= 0 {
+		nodeID = uint64(groupID)<<32 | nodeID
 	}
 
 	return nodeID + uint64(c.StartNodeID), nil
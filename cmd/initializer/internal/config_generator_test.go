@@ -207,6 +207,102 @@ func TestDatanodeConfigGeneratorWithDatanodeGroupID(t *testing.T) {
 	}
 }
 
+func TestDatanodeConfigGeneratorWithZone(t *testing.T) {
+	var testZone = "us-east-1a"
+
+	tmpConfigFile, err := os.CreateTemp("", "config-*.toml")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer tmpConfigFile.Close()
+
+	opts := &Options{
+		ConfigPath:      tmpConfigFile.Name(),
+		InitConfigPath:  "testdata/datanode-config.toml",
+		Namespace:       testClusterNamespace,
+		RoleKind:        string(v1alpha1.DatanodeRoleKind),
+		RPCPort:         testRPCPort,
+		DatanodeGroupID: 1,
+		Zone:            testZone,
+	}
+
+	t.Setenv(deployer.EnvPodIP, testPodIP)
+	t.Setenv(deployer.EnvPodName, testDatanodePodName)
+
+	cg := NewConfigGenerator(opts, datanodeHostname)
+	if err = cg.Generate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tomlData, err := os.ReadFile(opts.ConfigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tree, err := toml.Load(string(tomlData))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zone, ok := tree.Get("labels.zone").(string)
+	if !ok {
+		t.Fatalf("labels.zone is not string")
+	}
+
+	if zone != testZone {
+		t.Fatalf("zone is not equal, want: '%s', got: '%s'", testZone, zone)
+	}
+}
+
+func TestFlownodeConfigGeneratorWithFlownodeGroupID(t *testing.T) {
+	var testFlownodeGroupID int32 = 3
+
+	tmpConfigFile, err := os.CreateTemp("", "config-*.toml")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer tmpConfigFile.Close()
+
+	opts := &Options{
+		ConfigPath:      tmpConfigFile.Name(),
+		InitConfigPath:  "testdata/flownode-config.toml",
+		Namespace:       testClusterNamespace,
+		RoleKind:        string(v1alpha1.FlownodeRoleKind),
+		RPCPort:         testRPCPort,
+		DatanodeGroupID: -1,
+		FlownodeGroupID: testFlownodeGroupID,
+	}
+
+	t.Setenv(deployer.EnvPodIP, testPodIP)
+	t.Setenv(deployer.EnvPodName, testFlownodePodName)
+
+	cg := NewConfigGenerator(opts, flownodeHostname)
+	if err = cg.Generate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tomlData, err := os.ReadFile(opts.ConfigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tree, err := toml.Load(string(tomlData))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodeID, ok := tree.Get("node_id").(int64)
+	if !ok {
+		t.Fatalf("node_id is not int64")
+	}
+
+	expectedNodeID := uint64(testFlownodeGroupID)<<32 | uint64(testPodIndex)
+
+	if !reflect.DeepEqual(expectedNodeID, uint64(nodeID)) {
+		t.Fatalf("nodeID is not equal, want: '%d', got: '%d'", expectedNodeID, nodeID)
+	}
+}
+
 func datanodeHostname() (name string, err error) {
 	return testDatanodePodName, nil
 }
@@ -33,7 +33,9 @@ func main() {
 
 	pflag.Int32Var(&opts.RPCPort, "rpc-port", 4001, "the RPC port")
 	pflag.Int32Var(&opts.DatanodeGroupID, "datanode-group-id", -1, "the id of the datanode group")
+	pflag.Int32Var(&opts.FlownodeGroupID, "flownode-group-id", -1, "the id of the flownode group")
 	pflag.Int32Var(&opts.StartNodeID, "start-node-id", 0, "the id of the start node id of the datanode and flownode")
+	pflag.StringVar(&opts.Zone, "zone", "", "the zone of the datanode")
 	klog.InitFlags(nil)
 	pflag.CommandLine.AddGoFlagSet(goflag.CommandLine)
 
@@ -38,8 +38,12 @@ import (
 	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
 	"github.com/GreptimeTeam/greptimedb-operator/cmd/operator/app/options"
 	"github.com/GreptimeTeam/greptimedb-operator/cmd/operator/app/version"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/greptimedbbackup"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/greptimedbbackupschedule"
 	"github.com/GreptimeTeam/greptimedb-operator/controllers/greptimedbcluster"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/greptimedbrestore"
 	"github.com/GreptimeTeam/greptimedb-operator/controllers/greptimedbstandalone"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/podrecovery"
 )
 
 const (
@@ -126,11 +130,31 @@ func NewOperatorCommand() *cobra.Command {
 				os.Exit(1)
 			}
 
+			if err := greptimedbbackup.Setup(mgr, o); err != nil {
+				setupLog.Error(err, "unable to setup controller", "controller", "greptimedbbackup")
+				os.Exit(1)
+			}
+
+			if err := greptimedbbackupschedule.Setup(mgr, o); err != nil {
+				setupLog.Error(err, "unable to setup controller", "controller", "greptimedbbackupschedule")
+				os.Exit(1)
+			}
+
+			if err := greptimedbrestore.Setup(mgr, o); err != nil {
+				setupLog.Error(err, "unable to setup controller", "controller", "greptimedbrestore")
+				os.Exit(1)
+			}
+
 			if err := greptimedbstandalone.Setup(mgr, o); err != nil {
 				setupLog.Error(err, "unable to setup controller", "controller", "greptimedbstandalone")
 				os.Exit(1)
 			}
 
+			if err := podrecovery.Setup(mgr, o); err != nil {
+				setupLog.Error(err, "unable to setup controller", "controller", "podrecovery")
+				os.Exit(1)
+			}
+
 			if o.EnableAdmissionWebhook {
 				if err := (&v1alpha1.GreptimeDBCluster{}).SetupWebhookWithManager(mgr); err != nil {
 					setupLog.Error(err, "unable to setup admission webhook", "controller", "greptimedbcluster")
@@ -26,6 +26,8 @@ const (
 	defaultAdmissionWebhookPort    = 8082
 	defaultAdmissionWebhookCertDir = "/etc/webhook-tls"
 	defaultProfilingAddress        = "0.0.0.0:8083"
+	defaultRcloneImage             = "rclone/rclone:1.69"
+	defaultFailedNodeTimeout       = 5 * time.Minute
 )
 
 type Options struct {
@@ -43,6 +45,15 @@ type Options struct {
 	AdmissionWebhookCertDir string
 	EnableProfiling         bool
 	ProfilingAddress        string
+	BackupCleanupImage      string
+	CloneCopyImage          string
+
+	// EnableFailedNodePodRecovery enables force deleting the pods on the nodes that are not ready for longer than the FailedNodeTimeout.
+	// The volume attachments of the pods are only deleted if the node is fenced, that is, it's tainted with `node.kubernetes.io/out-of-service`
+	// after it's shut down, or it has the `node.kubernetes.io/unreachable` NoExecute taint for longer than the FailedNodeTimeout.
+	// Without the fencing, a partitioned node may still write to the volumes after they are attached to the new node.
+	EnableFailedNodePodRecovery bool
+	FailedNodeTimeout           time.Duration
 }
 
 func NewDefaultOptions() *Options {
@@ -56,6 +67,9 @@ func NewDefaultOptions() *Options {
 		AdmissionWebhookCertDir: defaultAdmissionWebhookCertDir,
 		EnableProfiling:         false,
 		ProfilingAddress:        defaultProfilingAddress,
+		BackupCleanupImage:      defaultRcloneImage,
+		CloneCopyImage:          defaultRcloneImage,
+		FailedNodeTimeout:       defaultFailedNodeTimeout,
 	}
 }
 
@@ -72,4 +86,8 @@ func (o *Options) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.AdmissionWebhookCertDir, "admission-webhook-cert-dir", o.AdmissionWebhookCertDir, "The directory that contains the server key and certificate.")
 	fs.BoolVar(&o.EnableProfiling, "enable-profiling", o.EnableProfiling, "Enable pprof performance profiling (exposes /debug/pprof endpoints).")
 	fs.StringVar(&o.ProfilingAddress, "profiling-address", o.ProfilingAddress, "The address that pprof profiling HTTP server binds to (e.g., for accessing /debug/pprof).")
+	fs.StringVar(&o.BackupCleanupImage, "backup-cleanup-image", o.BackupCleanupImage, "The rclone image of the job that deletes the backup data from the object storage.")
+	fs.StringVar(&o.CloneCopyImage, "clone-copy-image", o.CloneCopyImage, "The rclone image of the job that copies the data in the object storage of the source cluster to the clone.")
+	fs.BoolVar(&o.EnableFailedNodePodRecovery, "enable-failed-node-pod-recovery", o.EnableFailedNodePodRecovery, "Enable force deleting the GreptimeDB pods and their volume attachments on the nodes that are not ready, so the pods can be rescheduled. The volume attachments are only deleted if the node is tainted with 'node.kubernetes.io/out-of-service', or it has been unreachable for longer than the failed node timeout.")
+	fs.DurationVar(&o.FailedNodeTimeout, "failed-node-timeout", o.FailedNodeTimeout, "The duration that the node is not ready before its GreptimeDB pods are force deleted.")
 }
@@ -17,17 +17,21 @@ package common
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/avast/retry-go"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
@@ -141,6 +145,32 @@ func GeneratePodMonitor(namespace, resourceName string, promSpec *v1alpha1.Prome
 	return pm, nil
 }
 
+// GeneratePodDisruptionBudget generates the pod disruption budget for the pods of the component.
+func GeneratePodDisruptionBudget(namespace, resourceName string, pdbSpec *v1alpha1.PodDisruptionBudgetSpec) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: policyv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				constant.GreptimeDBComponentName: resourceName,
+			},
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable:   pdbSpec.GetMinAvailable(),
+			MaxUnavailable: pdbSpec.GetMaxUnavailable(),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					constant.GreptimeDBComponentName: resourceName,
+				},
+			},
+		},
+	}
+}
+
 func GeneratePodTemplateSpec(kind v1alpha1.RoleKind, template *v1alpha1.PodTemplateSpec) *corev1.PodTemplateSpec {
 	if template == nil || template.MainContainer == nil {
 		return nil
@@ -171,6 +201,14 @@ func GeneratePodTemplateSpec(kind v1alpha1.RoleKind, template *v1alpha1.PodTempl
 					ImagePullPolicy: template.MainContainer.ImagePullPolicy,
 					VolumeMounts:    template.MainContainer.VolumeMounts,
 					SecurityContext: template.MainContainer.SecurityContext,
+
+					ResizePolicy:             template.MainContainer.ResizePolicy,
+					VolumeDevices:            template.MainContainer.VolumeDevices,
+					TerminationMessagePath:   template.MainContainer.TerminationMessagePath,
+					TerminationMessagePolicy: template.MainContainer.TerminationMessagePolicy,
+					Stdin:                    template.MainContainer.Stdin,
+					StdinOnce:                template.MainContainer.StdinOnce,
+					TTY:                      template.MainContainer.TTY,
 				},
 			},
 			NodeSelector:                  template.NodeSelector,
@@ -186,7 +224,27 @@ func GeneratePodTemplateSpec(kind v1alpha1.RoleKind, template *v1alpha1.PodTempl
 			SchedulerName:                 template.SchedulerName,
 			Volumes:                       template.Volumes,
 			Tolerations:                   template.Tolerations,
+			TopologySpreadConstraints:     template.TopologySpreadConstraints,
 			SecurityContext:               template.SecurityContext,
+			AutomountServiceAccountToken:  template.AutomountServiceAccountToken,
+			NodeName:                      template.NodeName,
+			HostPID:                       template.HostPID,
+			HostIPC:                       template.HostIPC,
+			ShareProcessNamespace:         template.ShareProcessNamespace,
+			HostAliases:                   template.HostAliases,
+			PriorityClassName:             template.PriorityClassName,
+			Priority:                      template.Priority,
+			DNSConfig:                     template.DNSConfig,
+			ReadinessGates:                template.ReadinessGates,
+			RuntimeClassName:              template.RuntimeClassName,
+			EnableServiceLinks:            template.EnableServiceLinks,
+			PreemptionPolicy:              template.PreemptionPolicy,
+			Overhead:                      template.Overhead,
+			OS:                            template.OS,
+			HostUsers:                     template.HostUsers,
+			SchedulingGates:               template.SchedulingGates,
+			ResourceClaims:                template.ResourceClaims,
+			Resources:                     template.Resources,
 		},
 	}
 
@@ -229,6 +287,11 @@ func FileStorageToPVC(clusterName string, datanodeGroupName string, fs v1alpha1.
 		annotations = util.MergeStringMap(annotations, fs.GetAnnotations())
 	}
 
+	accessModes := fs.GetAccessModes()
+	if len(accessModes) == 0 {
+		accessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+
 	return &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        fs.GetName(),
@@ -237,18 +300,83 @@ func FileStorageToPVC(clusterName string, datanodeGroupName string, fs v1alpha1.
 		},
 		Spec: corev1.PersistentVolumeClaimSpec{
 			StorageClassName: fs.GetStorageClassName(),
-			AccessModes: []corev1.PersistentVolumeAccessMode{
-				corev1.ReadWriteOnce,
-			},
+			AccessModes:      accessModes,
 			Resources: corev1.VolumeResourceRequirements{
 				Requests: corev1.ResourceList{
 					corev1.ResourceStorage: resource.MustParse(fs.GetSize()),
 				},
 			},
+			VolumeMode:                fs.GetVolumeMode(),
+			DataSource:                fs.GetDataSource(),
+			DataSourceRef:             fs.GetDataSourceRef(),
+			VolumeAttributesClassName: fs.GetVolumeAttributesClassName(),
 		},
 	}
 }
 
+// FileStorageToVolume returns the pod volume of the file storage that is not allocated by the volume claim template of the StatefulSet,
+// that is, the empty dir or the generic ephemeral volume. It returns nil if the file storage uses the PVC.
+func FileStorageToVolume(clusterName string, datanodeGroupName string, fs *v1alpha1.FileStorage, fsType FileStorageType, kind v1alpha1.RoleKind) *corev1.Volume {
+	switch {
+	case fs.IsUseEmptyDir():
+		emptyDir := &corev1.EmptyDirVolumeSource{Medium: fs.GetEmptyDirMedium()}
+		if fs.GetSize() != "" {
+			emptyDir.SizeLimit = ptr.To(resource.MustParse(fs.GetSize()))
+		}
+		return &corev1.Volume{
+			Name:         fs.GetName(),
+			VolumeSource: corev1.VolumeSource{EmptyDir: emptyDir},
+		}
+	case fs.IsUseEphemeralVolume():
+		claim := FileStorageToPVC(clusterName, datanodeGroupName, fs, fsType, kind)
+		return &corev1.Volume{
+			Name: fs.GetName(),
+			VolumeSource: corev1.VolumeSource{
+				Ephemeral: &corev1.EphemeralVolumeSource{
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+						// The name of the PVC is generated as `${pod}-${volume}` by Kubernetes.
+						ObjectMeta: metav1.ObjectMeta{
+							Labels:      claim.Labels,
+							Annotations: claim.Annotations,
+						},
+						Spec: claim.Spec,
+					},
+				},
+			},
+		}
+	default:
+		return nil
+	}
+}
+
+// PVCRetentionPolicy returns the PVC retention policy of the StatefulSet from the retain policies of the file storage.
+// It returns nil if both policies are `Retain`, which is the default policy of the StatefulSet.
+func PVCRetentionPolicy(fs *v1alpha1.FileStorage) *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy {
+	if !fs.IsUsePVC() {
+		return nil
+	}
+
+	policy := &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+		WhenDeleted: appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+		WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+	}
+
+	if fs.GetPolicy() == v1alpha1.StorageRetainPolicyTypeDelete {
+		policy.WhenDeleted = appsv1.DeletePersistentVolumeClaimRetentionPolicyType
+	}
+
+	if fs.GetPolicyWhenScaled() == v1alpha1.StorageRetainPolicyTypeDelete {
+		policy.WhenScaled = appsv1.DeletePersistentVolumeClaimRetentionPolicyType
+	}
+
+	if policy.WhenDeleted == appsv1.RetainPersistentVolumeClaimRetentionPolicyType &&
+		policy.WhenScaled == appsv1.RetainPersistentVolumeClaimRetentionPolicyType {
+		return nil
+	}
+
+	return policy
+}
+
 func GetPVCs(ctx context.Context, k8sClient client.Client, namespace, resourceName string, fsType FileStorageType) ([]corev1.PersistentVolumeClaim, error) {
 	var labelSelector *metav1.LabelSelector
 	switch fsType {
@@ -355,6 +483,28 @@ func SetMaintenanceMode(metaHTTPServiceURL string, enabled bool) error {
 	return nil
 }
 
+// GetMetaLeader requests the metasrv for the address of the current leader, and returns an empty address if no leader is elected.
+func GetMetaLeader(metaHTTPServiceURL string) (string, error) {
+	requestURL := metaHTTPServiceURL + "/admin/leader"
+
+	rsp, err := http.Get(requestURL)
+	if err != nil {
+		return "", err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to request metasrv '%s' for leader, status code: %d", requestURL, rsp.StatusCode)
+	}
+
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
+
 // GetBindAddress returns the wildcard bind address for listening.
 // If enableIPv6 is true, returns "[::]:port", otherwise returns "0.0.0.0:port".
 func GetBindAddress(enableIPv6 bool, port int32) string {
@@ -0,0 +1,153 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/constant"
+)
+
+func TestFileStorageToVolume(t *testing.T) {
+	// The PVC is allocated by the volume claim template of the StatefulSet.
+	pvc := &v1alpha1.FileStorage{Name: "wal", StorageSize: "5Gi", MountPath: "/wal"}
+	if volume := FileStorageToVolume("test", "", pvc, FileStorageTypeWAL, v1alpha1.DatanodeRoleKind); volume != nil {
+		t.Fatalf("expected no volume for the PVC, got: %v", volume)
+	}
+
+	emptyDir := &v1alpha1.FileStorage{
+		Name:           "cache",
+		StorageSize:    "2Gi",
+		MountPath:      "/cache",
+		UseEmptyDir:    ptr.To(true),
+		EmptyDirMedium: corev1.StorageMediumMemory,
+	}
+	volume := FileStorageToVolume("test", "", emptyDir, FileStorageTypeCache, v1alpha1.DatanodeRoleKind)
+	if volume == nil || volume.EmptyDir == nil {
+		t.Fatalf("expected an empty dir volume, got: %v", volume)
+	}
+	if volume.Name != "cache" || volume.EmptyDir.Medium != corev1.StorageMediumMemory || volume.EmptyDir.SizeLimit.String() != "2Gi" {
+		t.Errorf("unexpected empty dir volume: %v", volume)
+	}
+
+	ephemeral := &v1alpha1.FileStorage{
+		Name:               "wal",
+		StorageSize:        "5Gi",
+		MountPath:          "/wal",
+		StorageClassName:   ptr.To("local-nvme"),
+		UseEphemeralVolume: ptr.To(true),
+	}
+	volume = FileStorageToVolume("test", "", ephemeral, FileStorageTypeWAL, v1alpha1.DatanodeRoleKind)
+	if volume == nil || volume.Ephemeral == nil {
+		t.Fatalf("expected an ephemeral volume, got: %v", volume)
+	}
+
+	template := volume.Ephemeral.VolumeClaimTemplate
+	if ptr.Deref(template.Spec.StorageClassName, "") != "local-nvme" {
+		t.Errorf("expected the storage class 'local-nvme', got: %v", template.Spec.StorageClassName)
+	}
+	if template.Labels[FileStorageTypeLabelKey] != string(FileStorageTypeWAL) || template.Labels[constant.GreptimeDBComponentName] != "test-datanode" {
+		t.Errorf("unexpected labels of the ephemeral volume: %v", template.Labels)
+	}
+}
+
+func TestFileStorageToPVC(t *testing.T) {
+	fs := &v1alpha1.FileStorage{
+		Name:             "datanode",
+		StorageClassName: ptr.To("ssd"),
+		StorageSize:      "20Gi",
+		MountPath:        "/data",
+		Labels:           map[string]string{"team": "db"},
+		Annotations:      map[string]string{"backup": "daily"},
+		AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOncePod},
+		VolumeMode:       ptr.To(corev1.PersistentVolumeFilesystem),
+		DataSource: &corev1.TypedLocalObjectReference{
+			APIGroup: ptr.To("snapshot.storage.k8s.io"),
+			Kind:     "VolumeSnapshot",
+			Name:     "datanode-snapshot",
+		},
+		DataSourceRef: &corev1.TypedObjectReference{
+			APIGroup: ptr.To("snapshot.storage.k8s.io"),
+			Kind:     "VolumeSnapshot",
+			Name:     "datanode-snapshot",
+		},
+		VolumeAttributesClassName: ptr.To("gold"),
+	}
+
+	pvc := FileStorageToPVC("test", "", fs, FileStorageTypeDatanode, v1alpha1.DatanodeRoleKind)
+
+	if pvc.Name != "datanode" {
+		t.Errorf("unexpected name: %s", pvc.Name)
+	}
+	if want := map[string]string{constant.GreptimeDBComponentName: "test-datanode", "team": "db"}; !reflect.DeepEqual(pvc.Labels, want) {
+		t.Errorf("unexpected labels, want: %v, got: %v", want, pvc.Labels)
+	}
+	if want := map[string]string{"backup": "daily"}; !reflect.DeepEqual(pvc.Annotations, want) {
+		t.Errorf("unexpected annotations, want: %v, got: %v", want, pvc.Annotations)
+	}
+
+	want := corev1.PersistentVolumeClaimSpec{
+		StorageClassName: fs.StorageClassName,
+		AccessModes:      fs.AccessModes,
+		Resources: corev1.VolumeResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")},
+		},
+		VolumeMode:                fs.VolumeMode,
+		DataSource:                fs.DataSource,
+		DataSourceRef:             fs.DataSourceRef,
+		VolumeAttributesClassName: fs.VolumeAttributesClassName,
+	}
+	if !reflect.DeepEqual(pvc.Spec, want) {
+		t.Errorf("unexpected spec, want: %+v, got: %+v", want, pvc.Spec)
+	}
+
+	// The access mode is ReadWriteOnce by default, and the WAL PVC has the file storage type label.
+	wal := FileStorageToPVC("test", "", &v1alpha1.FileStorage{Name: "wal", StorageSize: "5Gi"}, FileStorageTypeWAL, v1alpha1.DatanodeRoleKind)
+	if !reflect.DeepEqual(wal.Spec.AccessModes, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}) {
+		t.Errorf("expected the default access mode ReadWriteOnce, got: %v", wal.Spec.AccessModes)
+	}
+	if wal.Labels[FileStorageTypeLabelKey] != string(FileStorageTypeWAL) {
+		t.Errorf("expected the file storage type label, got: %v", wal.Labels)
+	}
+}
+
+func TestGetMetaLeader(t *testing.T) {
+	leader := "10.0.0.1:3002"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/admin/leader" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, leader)
+	}))
+	defer server.Close()
+
+	got, err := GetMetaLeader(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != leader {
+		t.Errorf("expected leader '%s', got '%s'", leader, got)
+	}
+}
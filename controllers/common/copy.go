@@ -0,0 +1,309 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+)
+
+// CopyLocation is the object storage location that used by the `COPY DATABASE` statements.
+type CopyLocation struct {
+	// URL is the object storage URL of the location, for example, `s3://bucket/root/backups/`.
+	// It always ends with `/`.
+	URL string
+
+	// Connection is the connection options of the object storage, for example, the region and the credentials.
+	Connection map[string]string
+}
+
+// NewCopyLocation creates the CopyLocation from the object storage provider and the prefix under the root of the object storage.
+// The credentials will be read from the secrets in the given namespace.
+func NewCopyLocation(ctx context.Context, c client.Client, namespace string, osp *v1alpha1.ObjectStorageProviderSpec, prefix string) (*CopyLocation, error) {
+	var (
+		scheme, bucket, root string
+		connection           = make(map[string]string)
+	)
+
+	if s3 := osp.GetS3Storage(); s3 != nil {
+		scheme, bucket, root = "s3", s3.Bucket, s3.Root
+		setIfNotEmpty(connection, "REGION", s3.Region)
+		setIfNotEmpty(connection, "ENDPOINT", s3.Endpoint)
+		if s3.EnableVirtualHostStyle {
+			connection["ENABLE_VIRTUAL_HOST_STYLE"] = "true"
+		}
+		if s3.SecretName != "" {
+			data, err := getSecretData(ctx, c, namespace, s3.SecretName, []string{v1alpha1.AccessKeyIDSecretKey, v1alpha1.SecretAccessKeySecretKey})
+			if err != nil {
+				return nil, err
+			}
+			connection["ACCESS_KEY_ID"] = string(data[0])
+			connection["SECRET_ACCESS_KEY"] = string(data[1])
+		}
+	} else if oss := osp.GetOSSStorage(); oss != nil {
+		scheme, bucket, root = "oss", oss.Bucket, oss.Root
+		setIfNotEmpty(connection, "ENDPOINT", oss.Endpoint)
+		if oss.SecretName != "" {
+			data, err := getSecretData(ctx, c, namespace, oss.SecretName, []string{v1alpha1.AccessKeyIDSecretKey, v1alpha1.AccessKeySecretSecretKey})
+			if err != nil {
+				return nil, err
+			}
+			connection["ACCESS_KEY_ID"] = string(data[0])
+			connection["ACCESS_KEY_SECRET"] = string(data[1])
+		}
+	} else if gcs := osp.GetGCSStorage(); gcs != nil {
+		scheme, bucket, root = "gcs", gcs.Bucket, gcs.Root
+		setIfNotEmpty(connection, "SCOPE", gcs.Scope)
+		setIfNotEmpty(connection, "ENDPOINT", gcs.Endpoint)
+		if gcs.SecretName != "" {
+			data, err := getSecretData(ctx, c, namespace, gcs.SecretName, []string{v1alpha1.ServiceAccountKey})
+			if err != nil {
+				return nil, err
+			}
+			if len(data[0]) != 0 {
+				connection["CREDENTIAL"] = base64.StdEncoding.EncodeToString(data[0])
+			}
+		}
+	} else if azblob := osp.GetAZBlobStorage(); azblob != nil {
+		scheme, bucket, root = "azblob", azblob.Container, azblob.Root
+		setIfNotEmpty(connection, "ENDPOINT", azblob.Endpoint)
+		if azblob.SecretName != "" {
+			data, err := getSecretData(ctx, c, namespace, azblob.SecretName, []string{v1alpha1.AccountName, v1alpha1.AccountKey})
+			if err != nil {
+				return nil, err
+			}
+			connection["ACCOUNT_NAME"] = string(data[0])
+			connection["ACCOUNT_KEY"] = string(data[1])
+		}
+	} else {
+		return nil, fmt.Errorf("no object storage provider is configured")
+	}
+
+	return &CopyLocation{
+		URL:        fmt.Sprintf("%s://%s/", scheme, JoinObjectStoragePath(bucket, root, prefix)),
+		Connection: connection,
+	}, nil
+}
+
+// DatabaseURL returns the URL of the directory that stores the data of the database.
+func (l *CopyLocation) DatabaseURL(database string) string {
+	return l.URL + database + "/"
+}
+
+// SchemaURL returns the URL of the file that stores the table schemas of the database.
+// The file is next to the directory of the database, so `COPY DATABASE ... FROM` will not read it as the data of a table.
+func (l *CopyLocation) SchemaURL(database string) string {
+	return l.URL + database + ".schemas.parquet"
+}
+
+// CopyDatabaseToSQL returns the `COPY DATABASE ... TO ...` statement that exports the database to the location.
+func CopyDatabaseToSQL(database string, location *CopyLocation, format v1alpha1.BackupFormat) string {
+	return fmt.Sprintf("COPY DATABASE %s TO %s%s", QuoteIdentifier(database), quoteString(location.DatabaseURL(database)), copyOptions(location, format))
+}
+
+// CopyDatabaseFromSQL returns the `COPY DATABASE ... FROM ...` statement that imports the database from the location.
+func CopyDatabaseFromSQL(database string, location *CopyLocation, format v1alpha1.BackupFormat) string {
+	return fmt.Sprintf("COPY DATABASE %s FROM %s%s", QuoteIdentifier(database), quoteString(location.DatabaseURL(database)), copyOptions(location, format))
+}
+
+// CopySchemasToSQL returns the `COPY (...) TO ...` statement that saves the `CREATE TABLE` statements of the database to the location.
+// Every statement is a row of the file, and the `idx` column keeps the order of the statements.
+func CopySchemasToSQL(database string, schemas []string, location *CopyLocation) string {
+	// The file is still written when the database has no tables, so the restore can always read it.
+	query := "SELECT CAST(0 AS INT) AS idx, '' AS ddl WHERE false"
+	if len(schemas) > 0 {
+		values := make([]string, 0, len(schemas))
+		for i, schema := range schemas {
+			values = append(values, fmt.Sprintf("(%d, %s)", i, quoteString(schema)))
+		}
+		query = fmt.Sprintf("SELECT column1 AS idx, column2 AS ddl FROM (VALUES %s)", strings.Join(values, ", "))
+	}
+
+	return fmt.Sprintf("COPY (%s) TO %s%s", query, quoteString(location.SchemaURL(database)), copyOptions(location, v1alpha1.BackupFormatParquet))
+}
+
+// GetFrontendMySQLAddress returns the address of the frontend MySQL service of the cluster.
+// If the cluster only has frontend groups, the first frontend group will be used.
+func GetFrontendMySQLAddress(cluster *v1alpha1.GreptimeDBCluster) (string, error) {
+	frontend := cluster.GetFrontend()
+	if frontend == nil && len(cluster.GetFrontendGroups()) > 0 {
+		frontend = cluster.GetFrontendGroups()[0]
+	}
+
+	if frontend == nil {
+		return "", fmt.Errorf("the cluster '%s/%s' has no frontend", cluster.Namespace, cluster.Name)
+	}
+
+	port := frontend.MySQLPort
+	if port == 0 {
+		port = cluster.Spec.MySQLPort
+	}
+
+	return fmt.Sprintf("%s.%s.svc.cluster.local:%d", ResourceName(cluster.Name, v1alpha1.FrontendRoleKind, frontend.GetName()), cluster.Namespace, port), nil
+}
+
+// MySQLConnection is the connection information of the frontend MySQL service.
+type MySQLConnection struct {
+	Addr     string
+	Username string
+	Password string
+}
+
+// NewMySQLConnection creates the MySQLConnection of the cluster frontend. The credentials will be read from the secret if it's not empty.
+// The secret should contain keys named `username` and `password`.
+func NewMySQLConnection(ctx context.Context, c client.Client, cluster *v1alpha1.GreptimeDBCluster, secretName string) (*MySQLConnection, error) {
+	addr, err := GetFrontendMySQLAddress(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &MySQLConnection{Addr: addr}
+	if secretName != "" {
+		data, err := getSecretData(ctx, c, cluster.Namespace, secretName, []string{v1alpha1.UsernameSecretKey, v1alpha1.PasswordSecretKey})
+		if err != nil {
+			return nil, err
+		}
+		conn.Username = string(data[0])
+		conn.Password = string(data[1])
+	}
+
+	return conn, nil
+}
+
+// Open opens the database handle of the MySQL connection. The database can be empty.
+func (c *MySQLConnection) Open(database string) (*sql.DB, error) {
+	cfg := mysql.Config{
+		Net:                  "tcp",
+		Addr:                 c.Addr,
+		User:                 c.Username,
+		Passwd:               c.Password,
+		DBName:               database,
+		AllowNativePasswords: true,
+		Timeout:              5 * time.Second,
+	}
+
+	return sql.Open("mysql", cfg.FormatDSN())
+}
+
+// BackupSchemaTableName is the name of the temporary external table that reads the table schemas saved by the backup.
+const BackupSchemaTableName = "__greptime_backup_schemas"
+
+// CreateSchemaTableSQL returns the `CREATE EXTERNAL TABLE` statement that reads the table schemas saved by CopySchemasToSQL.
+func CreateSchemaTableSQL(database string, location *CopyLocation) string {
+	options := []string{
+		fmt.Sprintf("LOCATION = %s", quoteString(location.SchemaURL(database))),
+		fmt.Sprintf("FORMAT = %s", quoteString(string(v1alpha1.BackupFormatParquet))),
+	}
+	for _, k := range connectionKeys(location) {
+		options = append(options, fmt.Sprintf("%s = %s", k, quoteString(location.Connection[k])))
+	}
+
+	return fmt.Sprintf("CREATE EXTERNAL TABLE IF NOT EXISTS %s WITH (%s)", QuoteIdentifier(BackupSchemaTableName), strings.Join(options, ", "))
+}
+
+func copyOptions(location *CopyLocation, format v1alpha1.BackupFormat) string {
+	var sb strings.Builder
+
+	if format != "" {
+		sb.WriteString(fmt.Sprintf(" WITH (FORMAT = %s)", quoteString(string(format))))
+	}
+
+	if len(location.Connection) > 0 {
+		keys := connectionKeys(location)
+		options := make([]string, 0, len(keys))
+		for _, k := range keys {
+			options = append(options, fmt.Sprintf("%s = %s", k, quoteString(location.Connection[k])))
+		}
+		sb.WriteString(fmt.Sprintf(" CONNECTION (%s)", strings.Join(options, ", ")))
+	}
+
+	return sb.String()
+}
+
+// connectionKeys returns the sorted keys of the connection options, so the generated statements are stable.
+func connectionKeys(location *CopyLocation) []string {
+	keys := make([]string, 0, len(location.Connection))
+	for k := range location.Connection {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GetCredentials returns the username and the password in the secret.
+// The secret should contain keys named `username` and `password`.
+func GetCredentials(ctx context.Context, c client.Client, namespace, secretName string) (string, string, error) {
+	data, err := getSecretData(ctx, c, namespace, secretName, []string{v1alpha1.UsernameSecretKey, v1alpha1.PasswordSecretKey})
+	if err != nil {
+		return "", "", err
+	}
+	return string(data[0]), string(data[1]), nil
+}
+
+func getSecretData(ctx context.Context, c client.Client, namespace, name string, keys []string) ([][]byte, error) {
+	var secret corev1.Secret
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &secret); err != nil {
+		return nil, err
+	}
+
+	var values [][]byte
+	for _, key := range keys {
+		value, ok := secret.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("secret '%s/%s' does not have key '%s'", namespace, name, key)
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// JoinObjectStoragePath joins the path elements of the object storage and ignores the leading and trailing slashes of every element.
+func JoinObjectStoragePath(elem ...string) string {
+	var parts []string
+	for _, e := range elem {
+		if e = strings.Trim(e, "/"); e != "" {
+			parts = append(parts, e)
+		}
+	}
+	return path.Join(parts...)
+}
+
+func setIfNotEmpty(m map[string]string, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
+
+// QuoteIdentifier quotes the identifier with backticks, for example, the database name and the table name.
+func QuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
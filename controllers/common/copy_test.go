@@ -0,0 +1,153 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+)
+
+func TestCopyDatabaseSQL(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "s3-credentials",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			v1alpha1.AccessKeyIDSecretKey:     []byte("ak"),
+			v1alpha1.SecretAccessKeySecretKey: []byte("s'k"),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		osp      *v1alpha1.ObjectStorageProviderSpec
+		prefix   string
+		database string
+		wantURL  string
+		wantTo   string
+		wantFrom string
+	}{
+		{
+			name: "s3 with credentials",
+			osp: &v1alpha1.ObjectStorageProviderSpec{
+				S3: &v1alpha1.S3Storage{
+					Bucket:     "bucket",
+					Root:       "/cluster/",
+					Region:     "us-west-2",
+					SecretName: "s3-credentials",
+				},
+			},
+			prefix:   "backups/my-backup",
+			database: "public",
+			wantURL:  "s3://bucket/cluster/backups/my-backup/",
+			wantTo:   "COPY DATABASE `public` TO 's3://bucket/cluster/backups/my-backup/public/' WITH (FORMAT = 'parquet') CONNECTION (ACCESS_KEY_ID = 'ak', REGION = 'us-west-2', SECRET_ACCESS_KEY = 's''k')",
+			wantFrom: "COPY DATABASE `public` FROM 's3://bucket/cluster/backups/my-backup/public/' WITH (FORMAT = 'parquet') CONNECTION (ACCESS_KEY_ID = 'ak', REGION = 'us-west-2', SECRET_ACCESS_KEY = 's''k')",
+		},
+		{
+			name: "azblob without credentials",
+			osp: &v1alpha1.ObjectStorageProviderSpec{
+				AZBlob: &v1alpha1.AZBlobStorage{
+					Container: "container",
+					Root:      "",
+				},
+			},
+			prefix:   "backups",
+			database: "my`db",
+			wantURL:  "azblob://container/backups/",
+			wantTo:   "COPY DATABASE `my``db` TO 'azblob://container/backups/my`db/' WITH (FORMAT = 'parquet')",
+			wantFrom: "COPY DATABASE `my``db` FROM 'azblob://container/backups/my`db/' WITH (FORMAT = 'parquet')",
+		},
+	}
+
+	c := fake.NewClientBuilder().WithObjects(secret).Build()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := NewCopyLocation(context.Background(), c, "default", tt.osp, tt.prefix)
+			if err != nil {
+				t.Fatalf("failed to create copy location: %v", err)
+			}
+
+			if location.URL != tt.wantURL {
+				t.Errorf("unexpected url, want: '%s', got: '%s'", tt.wantURL, location.URL)
+			}
+
+			if got := CopyDatabaseToSQL(tt.database, location, v1alpha1.BackupFormatParquet); got != tt.wantTo {
+				t.Errorf("unexpected copy to sql, want: '%s', got: '%s'", tt.wantTo, got)
+			}
+
+			if got := CopyDatabaseFromSQL(tt.database, location, v1alpha1.BackupFormatParquet); got != tt.wantFrom {
+				t.Errorf("unexpected copy from sql, want: '%s', got: '%s'", tt.wantFrom, got)
+			}
+		})
+	}
+}
+
+func TestNewCopyLocationWithoutProvider(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+	if _, err := NewCopyLocation(context.Background(), c, "default", nil, "backups"); err == nil {
+		t.Errorf("expect error when no object storage provider is configured")
+	}
+}
+
+func TestCopySchemasToSQL(t *testing.T) {
+	location := &CopyLocation{
+		URL:        "s3://bucket/backups/",
+		Connection: map[string]string{"REGION": "us-west-2", "ENDPOINT": "http://minio:9000"},
+	}
+
+	tests := []struct {
+		name    string
+		schemas []string
+		want    string
+	}{
+		{
+			name:    "with tables",
+			schemas: []string{"CREATE TABLE a (ts TIMESTAMP TIME INDEX)", "CREATE TABLE b (s STRING DEFAULT 'x', ts TIMESTAMP TIME INDEX)"},
+			want:    "COPY (SELECT column1 AS idx, column2 AS ddl FROM (VALUES (0, 'CREATE TABLE a (ts TIMESTAMP TIME INDEX)'), (1, 'CREATE TABLE b (s STRING DEFAULT ''x'', ts TIMESTAMP TIME INDEX)'))) TO 's3://bucket/backups/public.schemas.parquet' WITH (FORMAT = 'parquet') CONNECTION (ENDPOINT = 'http://minio:9000', REGION = 'us-west-2')",
+		},
+		{
+			name: "without tables",
+			want: "COPY (SELECT CAST(0 AS INT) AS idx, '' AS ddl WHERE false) TO 's3://bucket/backups/public.schemas.parquet' WITH (FORMAT = 'parquet') CONNECTION (ENDPOINT = 'http://minio:9000', REGION = 'us-west-2')",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CopySchemasToSQL("public", tt.schemas, location); got != tt.want {
+				t.Errorf("unexpected copy schemas sql, want: '%s', got: '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCreateSchemaTableSQL(t *testing.T) {
+	location := &CopyLocation{
+		URL:        "s3://bucket/backups/",
+		Connection: map[string]string{"REGION": "us-west-2", "ENDPOINT": "http://minio:9000"},
+	}
+
+	want := "CREATE EXTERNAL TABLE IF NOT EXISTS `__greptime_backup_schemas` WITH (LOCATION = 's3://bucket/backups/public.schemas.parquet', FORMAT = 'parquet', ENDPOINT = 'http://minio:9000', REGION = 'us-west-2')"
+	if got := CreateSchemaTableSQL("public", location); got != want {
+		t.Errorf("unexpected create schema table sql, want: '%s', got: '%s'", want, got)
+	}
+}
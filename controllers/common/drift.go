@@ -0,0 +1,183 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+	k8sutils "github.com/GreptimeTeam/greptimedb-operator/pkg/util/k8s"
+)
+
+// Drift is the difference between the live object and the object generated by the operator.
+type Drift struct {
+	// Object is the live object.
+	Object *unstructured.Unstructured
+
+	// Fields are the drifted fields in the format of `<path>: <live value> -> <generated value>`.
+	Fields []string
+}
+
+// String returns the description of the drift.
+func (d *Drift) String() string {
+	return fmt.Sprintf("%s '%s': %v", d.Object.GetKind(), d.Object.GetName(), d.Fields)
+}
+
+// Paths returns the paths of the drifted fields.
+func (d *Drift) Paths() []string {
+	paths := make([]string, 0, len(d.Fields))
+	for _, field := range d.Fields {
+		path, _, _ := strings.Cut(field, ": ")
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// DetectDrift compares the generated objects with the live objects and returns the drifts.
+// Only the fields that are set in the generated objects are compared, so the fields that are defaulted by the API server are ignored.
+func DetectDrift(ctx context.Context, c client.Client, objects []client.Object) ([]Drift, error) {
+	var drifts []Drift
+
+	for _, object := range objects {
+		live := k8sutils.SourceObject(object).(*unstructured.Unstructured)
+		if err := c.Get(ctx, client.ObjectKeyFromObject(object), live); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		desired, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+		if err != nil {
+			return nil, err
+		}
+
+		var fields []string
+		for _, key := range sortedKeys(desired) {
+			switch key {
+			case "apiVersion", "kind", "metadata", "status":
+				continue
+			}
+			diffFields(key, live.Object[key], desired[key], &fields)
+		}
+
+		if len(fields) > 0 {
+			drifts = append(drifts, Drift{Object: live, Fields: fields})
+		}
+	}
+
+	return drifts, nil
+}
+
+// ResetLastAppliedResourceSpec sets the last applied resource spec of the drifted object to its live spec,
+// so the deployers will find the difference and overwrite the drift with the generated object in the next sync.
+func ResetLastAppliedResourceSpec(ctx context.Context, c client.Client, drift *Drift) error {
+	live := drift.Object
+	spec, ok := live.Object["spec"]
+	if !ok {
+		spec = live.Object["data"]
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	patched := live.DeepCopy()
+	annotations := patched.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[deployer.LastAppliedResourceSpec] = string(data)
+	patched.SetAnnotations(annotations)
+
+	return c.Patch(ctx, patched, client.MergeFrom(live))
+}
+
+func diffFields(path string, live, desired interface{}, fields *[]string) {
+	// The field is not set in the generated object.
+	if isEmptyValue(desired) {
+		return
+	}
+
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		if l, ok := live.(map[string]interface{}); ok {
+			for _, key := range sortedKeys(d) {
+				diffFields(path+"."+key, l[key], d[key], fields)
+			}
+			return
+		}
+	case []interface{}:
+		if l, ok := live.([]interface{}); ok && len(l) == len(d) {
+			for i := range d {
+				diffFields(fmt.Sprintf("%s[%d]", path, i), l[i], d[i], fields)
+			}
+			return
+		}
+	default:
+		if reflect.DeepEqual(live, desired) {
+			return
+		}
+	}
+
+	*fields = append(*fields, fmt.Sprintf("%s: %s -> %s", path, toJSON(live), toJSON(desired)))
+}
+
+func isEmptyValue(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	case string:
+		return value == ""
+	case bool:
+		return !value
+	}
+	return false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func toJSON(v interface{}) string {
+	if v == nil {
+		return "<none>"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
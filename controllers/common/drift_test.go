@@ -0,0 +1,97 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+)
+
+func TestDetectDrift(t *testing.T) {
+	newStatefulSet := func(replicas int32, image string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			TypeMeta:   metav1.TypeMeta{Kind: "StatefulSet", APIVersion: "apps/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "test-datanode", Namespace: "default"},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To(replicas),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "datanode", Image: image}}},
+				},
+			},
+		}
+	}
+
+	// The live object is modified by hand and defaulted by the API server.
+	live := newStatefulSet(1, "greptime/greptimedb:debug")
+	live.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullIfNotPresent
+	live.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithObjects(live).Build()
+
+	drifts, err := DetectDrift(ctx, c, []client.Object{newStatefulSet(3, "greptime/greptimedb:latest")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(drifts) != 1 {
+		t.Fatalf("expected 1 drift, got: %v", drifts)
+	}
+
+	want := []string{
+		`spec.replicas: 1 -> 3`,
+		`spec.template.spec.containers[0].image: "greptime/greptimedb:debug" -> "greptime/greptimedb:latest"`,
+	}
+	if !reflect.DeepEqual(drifts[0].Fields, want) {
+		t.Errorf("unexpected drift fields, want: %v, got: %v", want, drifts[0].Fields)
+	}
+
+	if paths := drifts[0].Paths(); !reflect.DeepEqual(paths, []string{"spec.replicas", "spec.template.spec.containers[0].image"}) {
+		t.Errorf("unexpected drift paths: %v", paths)
+	}
+
+	if err := ResetLastAppliedResourceSpec(ctx, c, &drifts[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	got := new(appsv1.StatefulSet)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(live), got); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := got.Annotations[deployer.LastAppliedResourceSpec]; !ok {
+		t.Errorf("expected the last applied resource spec to be reset")
+	}
+
+	// No drift for the object that is the same as the generated one.
+	drifts, err = DetectDrift(ctx, c, []client.Object{newStatefulSet(1, "greptime/greptimedb:debug")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(drifts) != 0 {
+		t.Errorf("expected no drift, got: %v", drifts)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"time"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/util/cron"
+)
+
+// InMaintenanceWindow returns true if the time is in one of the maintenance windows, and the start time of the next window if it's not.
+// It always returns true if there is no maintenance window. The next time is zero if none of the windows will open in the future.
+func InMaintenanceWindow(windows []v1alpha1.MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	if len(windows) == 0 {
+		return true, time.Time{}, nil
+	}
+
+	var next time.Time
+	for _, window := range windows {
+		sched, err := cron.Parse(window.Schedule)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+
+		location := time.UTC
+		if window.TimeZone != "" {
+			if location, err = time.LoadLocation(window.TimeZone); err != nil {
+				return false, time.Time{}, err
+			}
+		}
+
+		// The window that contains the time must start within the duration before the time.
+		start := sched.Next(now.In(location).Add(-window.Duration.Duration))
+		if start.IsZero() {
+			continue
+		}
+
+		if !start.After(now) {
+			return true, time.Time{}, nil
+		}
+
+		if next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+
+	return false, next, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+)
+
+func TestInMaintenanceWindow(t *testing.T) {
+	windows := []v1alpha1.MaintenanceWindow{
+		{
+			// Every Saturday from 02:00 to 06:00 in Shanghai.
+			Schedule: "0 2 * * 6",
+			Duration: metav1.Duration{Duration: 4 * time.Hour},
+			TimeZone: "Asia/Shanghai",
+		},
+		{
+			// Every day from 12:00 to 12:30 in UTC.
+			Schedule: "0 12 * * *",
+			Duration: metav1.Duration{Duration: 30 * time.Minute},
+		},
+	}
+
+	tests := []struct {
+		now      string
+		inWindow bool
+		next     string
+	}{
+		// 2026-10-17 is Saturday, and it's 03:00 in Shanghai.
+		{now: "2026-10-16T19:00:00Z", inWindow: true},
+		{now: "2026-10-16T12:10:00Z", inWindow: true},
+		// The window is closed at 12:30.
+		{now: "2026-10-16T12:30:00Z", inWindow: false, next: "2026-10-16T18:00:00Z"},
+		{now: "2026-10-16T08:00:00Z", inWindow: false, next: "2026-10-16T12:00:00Z"},
+		{now: "2026-10-16T22:00:00Z", inWindow: false, next: "2026-10-17T12:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		now, err := time.Parse(time.RFC3339, tt.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		inWindow, next, err := InMaintenanceWindow(windows, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if inWindow != tt.inWindow {
+			t.Errorf("%s: expected in window %v, got %v", tt.now, tt.inWindow, inWindow)
+		}
+
+		if tt.next != "" {
+			want, _ := time.Parse(time.RFC3339, tt.next)
+			if !next.Equal(want) {
+				t.Errorf("%s: expected the next window at %s, got %s", tt.now, want, next.UTC())
+			}
+		}
+	}
+
+	if inWindow, _, _ := InMaintenanceWindow(nil, time.Now()); !inWindow {
+		t.Errorf("expected in window when there is no maintenance window")
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+)
+
+// PausableStatus is the status of the object whose reconciliation can be paused.
+type PausableStatus interface {
+	GetCondition(conditionType v1alpha1.ConditionType) *v1alpha1.Condition
+	SetCondition(condition v1alpha1.Condition)
+}
+
+// Pause sets the Paused condition if the object is not marked as paused yet.
+// It returns true if the status is changed and needs to be updated.
+func Pause(recorder record.EventRecorder, object client.Object, status PausableStatus) bool {
+	if isPaused(status) {
+		return false
+	}
+
+	klog.Infof("The reconciliation of '%s/%s' is paused", object.GetNamespace(), object.GetName())
+	recorder.Event(object, corev1.EventTypeNormal, "Paused", "Reconciliation is paused")
+	status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypePaused, corev1.ConditionTrue, "Paused", "the reconciliation is paused"))
+
+	return true
+}
+
+// Resume reports the drift of the resources that are modified during the pause and clears the Paused condition.
+// The last applied resource specs of the drifted resources are reset, so the drift will be overwritten by the next sync.
+// The resources are only generated if the object is marked as paused. It returns true if the status needs to be updated.
+func Resume(ctx context.Context, c client.Client, recorder record.EventRecorder, object client.Object, status PausableStatus,
+	generate func() ([]client.Object, error)) (bool, error) {
+	if !isPaused(status) {
+		return false, nil
+	}
+
+	objects, err := generate()
+	if err != nil {
+		return false, err
+	}
+
+	drifts, err := DetectDrift(ctx, c, objects)
+	if err != nil {
+		return false, err
+	}
+
+	var drifted []string
+	for i := range drifts {
+		klog.Infof("Overwrite the drift of '%s/%s': %s", object.GetNamespace(), object.GetName(), drifts[i].String())
+		if err := ResetLastAppliedResourceSpec(ctx, c, &drifts[i]); err != nil {
+			return false, err
+		}
+		drifted = append(drifted, fmt.Sprintf("%s '%s' (%s)", drifts[i].Object.GetKind(), drifts[i].Object.GetName(), strings.Join(drifts[i].Paths(), ", ")))
+	}
+
+	message := "the reconciliation is resumed"
+	if len(drifted) > 0 {
+		message = fmt.Sprintf("%s, overwriting the drift of %s", message, strings.Join(drifted, ", "))
+	}
+
+	klog.Infof("The reconciliation of '%s/%s' is resumed", object.GetNamespace(), object.GetName())
+	recorder.Event(object, corev1.EventTypeNormal, "Resumed", message)
+	status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypePaused, corev1.ConditionFalse, "Resumed", message))
+
+	return true, nil
+}
+
+func isPaused(status PausableStatus) bool {
+	condition := status.GetCondition(v1alpha1.ConditionTypePaused)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+)
+
+// prometheusQueryResponse is the response of the instant query of the Prometheus HTTP API.
+type prometheusQueryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// prometheusSample is the sample of the vector result, and the value is `[timestamp, "value"]`.
+type prometheusSample struct {
+	Value []interface{} `json:"value"`
+}
+
+// GetMonitoringPrometheusURL returns the URL of the Prometheus HTTP API of the monitoring standalone of the cluster.
+func GetMonitoringPrometheusURL(cluster *v1alpha1.GreptimeDBCluster) string {
+	port := v1alpha1.DefaultHTTPPort
+	if standalone := cluster.GetMonitoring().GetStandalone(); standalone != nil && standalone.HTTPPort != 0 {
+		port = standalone.HTTPPort
+	}
+
+	return fmt.Sprintf("http://%s.%s:%d/v1/prometheus", ResourceName(MonitoringServiceName(cluster.Name), v1alpha1.StandaloneRoleKind), cluster.Namespace, port)
+}
+
+// QueryPrometheus evaluates the instant PromQL query by the Prometheus-compatible HTTP API,
+// and returns the sum of the values of all the series in the result.
+func QueryPrometheus(ctx context.Context, endpoint, query string) (float64, error) {
+	requestURL := strings.TrimSuffix(endpoint, "/") + "/api/v1/query?" + url.Values{"query": []string{query}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer rsp.Body.Close()
+
+	var result prometheusQueryResponse
+	if err := json.NewDecoder(rsp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode the response of query '%s', status code: %d: %v", query, rsp.StatusCode, err)
+	}
+
+	if rsp.StatusCode != http.StatusOK || result.Status != "success" {
+		return 0, fmt.Errorf("failed to evaluate query '%s', status code: %d: %s", query, rsp.StatusCode, result.Error)
+	}
+
+	switch result.Data.ResultType {
+	case "scalar":
+		var sample []interface{}
+		if err := json.Unmarshal(result.Data.Result, &sample); err != nil {
+			return 0, err
+		}
+		return parseSampleValue(sample)
+	case "vector":
+		var samples []prometheusSample
+		if err := json.Unmarshal(result.Data.Result, &samples); err != nil {
+			return 0, err
+		}
+
+		if len(samples) == 0 {
+			return 0, fmt.Errorf("query '%s' returns no data", query)
+		}
+
+		var sum float64
+		for _, sample := range samples {
+			value, err := parseSampleValue(sample.Value)
+			if err != nil {
+				return 0, err
+			}
+			sum += value
+		}
+		return sum, nil
+	default:
+		return 0, fmt.Errorf("unsupported result type '%s' of query '%s'", result.Data.ResultType, query)
+	}
+}
+
+func parseSampleValue(sample []interface{}) (float64, error) {
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("invalid sample '%v'", sample)
+	}
+
+	value, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid sample value '%v'", sample[1])
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
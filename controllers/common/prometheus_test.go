@@ -0,0 +1,75 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestQueryPrometheus(t *testing.T) {
+	results := map[string]string{
+		"vector": `{"status":"success","data":{"resultType":"vector","result":[` +
+			`{"metric":{"pod":"test-datanode-0"},"value":[1700000000,"10"]},` +
+			`{"metric":{"pod":"test-datanode-1"},"value":[1700000000,"2.5"]}]}}`,
+		"scalar": `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"0.75"]}}`,
+		"empty":  `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		"error":  `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/prometheus/api/v1/query" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		result, ok := results[r.URL.Query().Get("query")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Query().Get("query") == "error" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		fmt.Fprint(w, result)
+	}))
+	defer server.Close()
+
+	endpoint := server.URL + "/v1/prometheus"
+	tests := []struct {
+		query   string
+		want    float64
+		wantErr bool
+	}{
+		{query: "vector", want: 12.5},
+		{query: "scalar", want: 0.75},
+		{query: "empty", wantErr: true},
+		{query: "error", wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := QueryPrometheus(context.Background(), endpoint, test.query)
+		if (err != nil) != test.wantErr {
+			t.Errorf("query '%s': want error %v, got: %v", test.query, test.wantErr, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("query '%s': want %v, got %v", test.query, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+)
+
+// KeepStorageSizes reverts the decreased storage sizes of the volume claim templates of the new StatefulSet, since the PVCs can't be shrunk.
+// The sizes are checked against the live StatefulSet, or the live PVCs if the StatefulSet doesn't exist, for example, it's deleted to be recreated.
+// The other changes of the StatefulSet are still applied. It returns the messages of the skipped decreases.
+func KeepStorageSizes(ctx context.Context, k8sClient client.Client, newSts *appsv1.StatefulSet) ([]string, error) {
+	oldSts := new(appsv1.StatefulSet)
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(newSts), oldSts); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		oldSts = nil
+	}
+
+	var skipped []string
+	for i := range newSts.Spec.VolumeClaimTemplates {
+		template := &newSts.Spec.VolumeClaimTemplates[i]
+		current, err := currentStorageSize(ctx, k8sClient, oldSts, newSts, template)
+		if err != nil {
+			return nil, err
+		}
+
+		size := template.Spec.Resources.Requests[corev1.ResourceStorage]
+		if current.IsZero() || size.Cmp(current) >= 0 {
+			continue
+		}
+
+		skipped = append(skipped, fmt.Sprintf("the storage size of '%s' in statefulset '%s' can't be decreased from '%s' to '%s'",
+			template.Name, newSts.Name, current.String(), size.String()))
+		if template.Spec.Resources.Requests == nil {
+			template.Spec.Resources.Requests = corev1.ResourceList{}
+		}
+		template.Spec.Resources.Requests[corev1.ResourceStorage] = current
+	}
+
+	return skipped, nil
+}
+
+// currentStorageSize returns the storage size of the volume claim template in the live StatefulSet,
+// or the largest requested size of its PVCs if the StatefulSet doesn't exist.
+func currentStorageSize(ctx context.Context, k8sClient client.Client, oldSts, newSts *appsv1.StatefulSet, template *corev1.PersistentVolumeClaim) (resource.Quantity, error) {
+	if oldSts != nil {
+		for _, oldTemplate := range oldSts.Spec.VolumeClaimTemplates {
+			if oldTemplate.Name == template.Name {
+				return oldTemplate.Spec.Resources.Requests[corev1.ResourceStorage], nil
+			}
+		}
+		return resource.Quantity{}, nil
+	}
+
+	fsType := FileStorageType(template.Labels[FileStorageTypeLabelKey])
+	if fsType == "" {
+		fsType = FileStorageTypeDatanode
+	}
+
+	claims, err := GetPVCs(ctx, k8sClient, newSts.Namespace, newSts.Name, fsType)
+	if err != nil {
+		return resource.Quantity{}, err
+	}
+
+	var current resource.Quantity
+	for _, claim := range claims {
+		if !strings.HasPrefix(claim.Name, fmt.Sprintf("%s-%s-", template.Name, newSts.Name)) {
+			continue
+		}
+		if size := claim.Spec.Resources.Requests[corev1.ResourceStorage]; size.Cmp(current) > 0 {
+			current = size
+		}
+	}
+
+	return current, nil
+}
+
+// ExpandStatefulSetPVCs expands the PVCs of the StatefulSet if the storage sizes of the volume claim templates are increased,
+// and modifies the VolumeAttributesClass of the PVCs if it's changed.
+// Since the volume claim templates of the StatefulSet are immutable, the StatefulSet is deleted with the orphan cascading
+// after the PVCs are patched, and it will be recreated with the new volume claim templates without restarting the pods.
+// It returns the resize status of the patched PVCs and true if the StatefulSet is deleted.
+func ExpandStatefulSetPVCs(ctx context.Context, k8sClient client.Client, oldSts, newSts *appsv1.StatefulSet) ([]v1alpha1.PVCResizeStatus, bool, error) {
+	var expanded []corev1.PersistentVolumeClaim
+
+	for _, newTemplate := range newSts.Spec.VolumeClaimTemplates {
+		for _, oldTemplate := range oldSts.Spec.VolumeClaimTemplates {
+			if newTemplate.Name != oldTemplate.Name {
+				continue
+			}
+
+			newSize := newTemplate.Spec.Resources.Requests[corev1.ResourceStorage]
+			oldSize := oldTemplate.Spec.Resources.Requests[corev1.ResourceStorage]
+			switch newSize.Cmp(oldSize) {
+			case -1:
+				// The decreases should have been reverted by KeepStorageSizes.
+				return nil, false, fmt.Errorf("the storage size of '%s' in statefulset '%s' can't be decreased from '%s' to '%s'",
+					newTemplate.Name, newSts.Name, oldSize.String(), newSize.String())
+			case 1:
+				expanded = append(expanded, newTemplate)
+			default:
+				if !ptr.Equal(newTemplate.Spec.VolumeAttributesClassName, oldTemplate.Spec.VolumeAttributesClassName) {
+					expanded = append(expanded, newTemplate)
+				}
+			}
+		}
+	}
+
+	if len(expanded) == 0 {
+		return nil, false, nil
+	}
+
+	var statuses []v1alpha1.PVCResizeStatus
+	for _, template := range expanded {
+		fsType := FileStorageType(template.Labels[FileStorageTypeLabelKey])
+		if fsType == "" {
+			fsType = FileStorageTypeDatanode
+		}
+
+		claims, err := GetPVCs(ctx, k8sClient, newSts.Namespace, newSts.Name, fsType)
+		if err != nil {
+			return nil, false, err
+		}
+
+		size := template.Spec.Resources.Requests[corev1.ResourceStorage]
+		for _, claim := range claims {
+			// The PVCs of the StatefulSet are named as `${template}-${statefulset}-${ordinal}`.
+			if !strings.HasPrefix(claim.Name, fmt.Sprintf("%s-%s-", template.Name, newSts.Name)) {
+				continue
+			}
+
+			if err := patchPVC(ctx, k8sClient, &claim, size, template.Spec.VolumeAttributesClassName); err != nil {
+				return nil, false, err
+			}
+
+			statuses = append(statuses, pvcResizeStatus(&claim, size))
+		}
+	}
+
+	klog.Infof("Recreate the statefulset '%s/%s' with the expanded volume claim templates", newSts.Namespace, newSts.Name)
+	if err := k8sClient.Delete(ctx, oldSts, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil && !k8serrors.IsNotFound(err) {
+		return nil, false, err
+	}
+
+	return statuses, true, nil
+}
+
+// UpdatePVCResizeStatus refreshes the resize status of the PVCs, and returns true if all the PVCs are resized.
+func UpdatePVCResizeStatus(ctx context.Context, k8sClient client.Client, namespace string, statuses []v1alpha1.PVCResizeStatus) (bool, error) {
+	completed := true
+	for i := range statuses {
+		if statuses[i].Phase == v1alpha1.PVCResizePhaseCompleted {
+			continue
+		}
+
+		claim := new(corev1.PersistentVolumeClaim)
+		if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: statuses[i].Name}, claim); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return false, err
+		}
+
+		size, err := resource.ParseQuantity(statuses[i].RequestedSize)
+		if err != nil {
+			return false, err
+		}
+
+		statuses[i] = pvcResizeStatus(claim, size)
+		if statuses[i].Phase != v1alpha1.PVCResizePhaseCompleted {
+			completed = false
+		}
+	}
+
+	return completed, nil
+}
+
+// MergePVCResizeStatus replaces the resize status of the same PVCs with the new ones.
+func MergePVCResizeStatus(statuses, updates []v1alpha1.PVCResizeStatus) []v1alpha1.PVCResizeStatus {
+	for _, update := range updates {
+		found := false
+		for i := range statuses {
+			if statuses[i].Name == update.Name {
+				statuses[i] = update
+				found = true
+			}
+		}
+		if !found {
+			statuses = append(statuses, update)
+		}
+	}
+	return statuses
+}
+
+// patchPVC expands the PVC to the size and sets the VolumeAttributesClass of the PVC.
+func patchPVC(ctx context.Context, k8sClient client.Client, claim *corev1.PersistentVolumeClaim, size resource.Quantity, volumeAttributesClassName *string) error {
+	patch := client.MergeFrom(claim.DeepCopy())
+
+	if !ptr.Equal(claim.Spec.VolumeAttributesClassName, volumeAttributesClassName) {
+		klog.Infof("Modify the VolumeAttributesClass of PVC '%s/%s' to '%s'", claim.Namespace, claim.Name, ptr.Deref(volumeAttributesClassName, ""))
+		claim.Spec.VolumeAttributesClassName = volumeAttributesClassName
+	}
+
+	if current := claim.Spec.Resources.Requests[corev1.ResourceStorage]; current.Cmp(size) < 0 {
+		if err := checkVolumeExpansion(ctx, k8sClient, claim); err != nil {
+			return err
+		}
+
+		klog.Infof("Expand the PVC '%s/%s' to %s", claim.Namespace, claim.Name, size.String())
+		claim.Spec.Resources.Requests[corev1.ResourceStorage] = size
+	}
+
+	return k8sClient.Patch(ctx, claim, patch)
+}
+
+func checkVolumeExpansion(ctx context.Context, k8sClient client.Client, claim *corev1.PersistentVolumeClaim) error {
+	className := ptr.Deref(claim.Spec.StorageClassName, "")
+	if className == "" {
+		return fmt.Errorf("the PVC '%s' can't be expanded without a StorageClass", claim.Name)
+	}
+
+	storageClass := new(storagev1.StorageClass)
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: className}, storageClass); err != nil {
+		return err
+	}
+
+	if !ptr.Deref(storageClass.AllowVolumeExpansion, false) {
+		return fmt.Errorf("the StorageClass '%s' of PVC '%s' doesn't allow volume expansion", className, claim.Name)
+	}
+
+	return nil
+}
+
+func pvcResizeStatus(claim *corev1.PersistentVolumeClaim, size resource.Quantity) v1alpha1.PVCResizeStatus {
+	status := v1alpha1.PVCResizeStatus{
+		Name:          claim.Name,
+		RequestedSize: size.String(),
+		Phase:         v1alpha1.PVCResizePhaseResizing,
+	}
+
+	capacity, ok := claim.Status.Capacity[corev1.ResourceStorage]
+	if ok {
+		status.CapacitySize = capacity.String()
+	}
+
+	if ok && capacity.Cmp(size) >= 0 {
+		status.Phase = v1alpha1.PVCResizePhaseCompleted
+		return status
+	}
+
+	for _, condition := range claim.Status.Conditions {
+		if condition.Type == corev1.PersistentVolumeClaimFileSystemResizePending && condition.Status == corev1.ConditionTrue {
+			status.Phase = v1alpha1.PVCResizePhaseFileSystemResizePending
+		}
+	}
+
+	return status
+}
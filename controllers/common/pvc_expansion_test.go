@@ -0,0 +1,249 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/constant"
+)
+
+func TestExpandStatefulSetPVCs(t *testing.T) {
+	newSts := func(size string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-datanode", Namespace: "default"},
+			Spec: appsv1.StatefulSetSpec{
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "datanode"},
+						Spec: corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.VolumeResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	newPVC := func(name, className string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{constant.GreptimeDBComponentName: "test-datanode"},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: ptr.To(className),
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+				},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		}
+	}
+
+	ctx := context.Background()
+	oldSts := newSts("10Gi")
+
+	k8sClient := fake.NewClientBuilder().
+		WithObjects(
+			oldSts,
+			newPVC("datanode-test-datanode-0", "expandable"),
+			newPVC("datanode-test-datanode-1", "expandable"),
+			&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "expandable"}, AllowVolumeExpansion: ptr.To(true)},
+		).
+		Build()
+
+	// The storage size is not changed.
+	if _, recreated, err := ExpandStatefulSetPVCs(ctx, k8sClient, oldSts, newSts("10Gi")); err != nil || recreated {
+		t.Fatalf("expected nothing to do, got recreated: %v, err: %v", recreated, err)
+	}
+
+	// The storage size can't be decreased.
+	if _, _, err := ExpandStatefulSetPVCs(ctx, k8sClient, oldSts, newSts("5Gi")); err == nil {
+		t.Fatalf("expected an error when the storage size is decreased")
+	}
+
+	// The decreased storage size is reverted to the one of the live statefulset.
+	shrunk := newSts("5Gi")
+	skipped, err := KeepStorageSizes(ctx, k8sClient, shrunk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skipped) != 1 {
+		t.Fatalf("expected the decrease to be skipped, got: %v", skipped)
+	}
+	if _, recreated, err := ExpandStatefulSetPVCs(ctx, k8sClient, oldSts, shrunk); err != nil || recreated {
+		t.Fatalf("expected nothing to do after the decrease is skipped, got recreated: %v, err: %v", recreated, err)
+	}
+
+	statuses, recreated, err := ExpandStatefulSetPVCs(ctx, k8sClient, oldSts, newSts("20Gi"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !recreated {
+		t.Fatalf("expected the statefulset to be recreated")
+	}
+
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(oldSts), new(appsv1.StatefulSet)); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the statefulset to be deleted, got: %v", err)
+	}
+
+	if len(statuses) != 2 {
+		t.Fatalf("expected the resize status of 2 PVCs, got: %d", len(statuses))
+	}
+
+	for _, status := range statuses {
+		claim := new(corev1.PersistentVolumeClaim)
+		if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: status.Name}, claim); err != nil {
+			t.Fatal(err)
+		}
+
+		if size := claim.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "20Gi" {
+			t.Errorf("expected PVC '%s' to be expanded to 20Gi, got: %s", claim.Name, size.String())
+		}
+
+		if status.Phase != v1alpha1.PVCResizePhaseResizing || status.CapacitySize != "10Gi" {
+			t.Errorf("unexpected resize status: %+v", status)
+		}
+	}
+
+	// The PVC is resized by the storage provider.
+	claim := newPVC("datanode-test-datanode-0", "expandable")
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(claim), claim); err != nil {
+		t.Fatal(err)
+	}
+	claim.Status.Capacity[corev1.ResourceStorage] = resource.MustParse("20Gi")
+	if err := k8sClient.Status().Update(ctx, claim); err != nil {
+		t.Fatal(err)
+	}
+
+	completed, err := UpdatePVCResizeStatus(ctx, k8sClient, "default", statuses)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if completed || statuses[0].Phase != v1alpha1.PVCResizePhaseCompleted || statuses[1].Phase != v1alpha1.PVCResizePhaseResizing {
+		t.Errorf("unexpected resize status: %+v", statuses)
+	}
+
+	// The statefulset is not recreated yet, so the decrease is checked against the expanded PVCs.
+	shrunk = newSts("15Gi")
+	skipped, err = KeepStorageSizes(ctx, k8sClient, shrunk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := shrunk.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage]
+	if len(skipped) != 1 || size.String() != "20Gi" {
+		t.Errorf("expected the storage size to be kept as 20Gi, got: %s, skipped: %v", size.String(), skipped)
+	}
+}
+
+func TestExpandPVCWithoutVolumeExpansion(t *testing.T) {
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "datanode-test-datanode-0", Namespace: "default"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: ptr.To("standard"),
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().
+		WithObjects(claim, &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "standard"}}).
+		Build()
+
+	if err := patchPVC(context.Background(), k8sClient, claim, resource.MustParse("20Gi"), nil); err == nil {
+		t.Errorf("expected an error when the StorageClass doesn't allow volume expansion")
+	}
+}
+
+func TestModifyVolumeAttributesClass(t *testing.T) {
+	newSts := func(className *string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-datanode", Namespace: "default"},
+			Spec: appsv1.StatefulSetSpec{
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "datanode"},
+						Spec: corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.VolumeResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+							},
+							VolumeAttributesClassName: className,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	// The StorageClass doesn't allow volume expansion, but the size is not changed.
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "datanode-test-datanode-0",
+			Namespace: "default",
+			Labels:    map[string]string{constant.GreptimeDBComponentName: "test-datanode"},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: ptr.To("standard"),
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+			VolumeAttributesClassName: ptr.To("silver"),
+		},
+	}
+
+	ctx := context.Background()
+	oldSts := newSts(ptr.To("silver"))
+	k8sClient := fake.NewClientBuilder().
+		WithObjects(oldSts, claim, &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "standard"}}).
+		Build()
+
+	_, recreated, err := ExpandStatefulSetPVCs(ctx, k8sClient, oldSts, newSts(ptr.To("gold")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !recreated {
+		t.Fatalf("expected the statefulset to be recreated with the new volume claim templates")
+	}
+
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(claim), claim); err != nil {
+		t.Fatal(err)
+	}
+
+	if className := ptr.Deref(claim.Spec.VolumeAttributesClassName, ""); className != "gold" {
+		t.Errorf("expected the VolumeAttributesClass of the PVC to be 'gold', got: '%s'", className)
+	}
+}
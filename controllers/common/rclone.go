@@ -0,0 +1,123 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+)
+
+// RcloneRemote returns the rclone path of `${root}/${prefix}` in the object storage and the environment variables that configure the remote.
+// The remote is configured as `name` by the `RCLONE_CONFIG_${NAME}_*` environment variables, so the remotes of different object storages
+// can be used in the same rclone command. The credentials are read from the secrets of the object storage.
+func RcloneRemote(name string, osp *v1alpha1.ObjectStorageProviderSpec, prefix string) (string, []corev1.EnvVar, error) {
+	var (
+		env    []corev1.EnvVar
+		config = "RCLONE_CONFIG_" + strings.ToUpper(name) + "_"
+	)
+
+	add := func(key, value string) {
+		env = append(env, corev1.EnvVar{Name: config + key, Value: value})
+	}
+
+	if s3 := osp.GetS3Storage(); s3 != nil {
+		add("TYPE", "s3")
+		add("PROVIDER", "Other")
+		add("REGION", s3.Region)
+		add("ENDPOINT", s3.Endpoint)
+		add("FORCE_PATH_STYLE", strconv.FormatBool(!s3.EnableVirtualHostStyle))
+		if s3.SecretName != "" {
+			env = append(env,
+				secretEnv(config+"ACCESS_KEY_ID", s3.SecretName, v1alpha1.AccessKeyIDSecretKey),
+				secretEnv(config+"SECRET_ACCESS_KEY", s3.SecretName, v1alpha1.SecretAccessKeySecretKey),
+			)
+		} else {
+			add("ENV_AUTH", "true")
+		}
+		return name + ":" + JoinObjectStoragePath(s3.Bucket, s3.Root, prefix), env, nil
+	}
+
+	if oss := osp.GetOSSStorage(); oss != nil {
+		add("TYPE", "s3")
+		add("PROVIDER", "Alibaba")
+		add("ENDPOINT", oss.Endpoint)
+		if oss.SecretName != "" {
+			env = append(env,
+				secretEnv(config+"ACCESS_KEY_ID", oss.SecretName, v1alpha1.AccessKeyIDSecretKey),
+				secretEnv(config+"SECRET_ACCESS_KEY", oss.SecretName, v1alpha1.AccessKeySecretSecretKey),
+			)
+		} else {
+			add("ENV_AUTH", "true")
+		}
+		return name + ":" + JoinObjectStoragePath(oss.Bucket, oss.Root, prefix), env, nil
+	}
+
+	if gcs := osp.GetGCSStorage(); gcs != nil {
+		add("TYPE", "google cloud storage")
+		add("BUCKET_POLICY_ONLY", "true")
+		if gcs.SecretName != "" {
+			env = append(env, secretEnv(config+"SERVICE_ACCOUNT_CREDENTIALS", gcs.SecretName, v1alpha1.ServiceAccountKey))
+		} else {
+			add("ENV_AUTH", "true")
+		}
+		return name + ":" + JoinObjectStoragePath(gcs.Bucket, gcs.Root, prefix), env, nil
+	}
+
+	if azblob := osp.GetAZBlobStorage(); azblob != nil {
+		add("TYPE", "azureblob")
+		if azblob.Endpoint != "" {
+			add("ENDPOINT", azblob.Endpoint)
+		}
+		if azblob.SecretName != "" {
+			env = append(env,
+				secretEnv(config+"ACCOUNT", azblob.SecretName, v1alpha1.AccountName),
+				secretEnv(config+"KEY", azblob.SecretName, v1alpha1.AccountKey),
+			)
+		} else {
+			add("ENV_AUTH", "true")
+		}
+		return name + ":" + JoinObjectStoragePath(azblob.Container, azblob.Root, prefix), env, nil
+	}
+
+	return "", nil, fmt.Errorf("no object storage provider is configured")
+}
+
+// IsJobFinished returns true and the condition type if the job is completed or failed.
+func IsJobFinished(job *batchv1.Job) (bool, batchv1.JobConditionType) {
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return true, c.Type
+		}
+	}
+	return false, ""
+}
+
+func secretEnv(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+)
+
+func TestRcloneRemote(t *testing.T) {
+	source := &v1alpha1.ObjectStorageProviderSpec{S3: &v1alpha1.S3Storage{Bucket: "bucket", Root: "/source/", SecretName: "s3-credentials"}}
+	clone := &v1alpha1.ObjectStorageProviderSpec{GCS: &v1alpha1.GCSStorage{Bucket: "bucket", Root: "clone"}}
+
+	sourceRemote, sourceEnv, err := RcloneRemote("source", source, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cloneRemote, cloneEnv, err := RcloneRemote("clone", clone, "data")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sourceRemote != "source:bucket/source" || cloneRemote != "clone:bucket/clone/data" {
+		t.Errorf("unexpected remotes: %s, %s", sourceRemote, cloneRemote)
+	}
+
+	// The remotes are configured by their own environment variables, so they can be used in the same command.
+	for name, env := range map[string][]corev1.EnvVar{"RCLONE_CONFIG_SOURCE_": sourceEnv, "RCLONE_CONFIG_CLONE_": cloneEnv} {
+		for _, e := range env {
+			if !strings.HasPrefix(e.Name, name) {
+				t.Errorf("expected the environment variable '%s' to have the prefix '%s'", e.Name, name)
+			}
+		}
+	}
+
+	if _, _, err := RcloneRemote("empty", &v1alpha1.ObjectStorageProviderSpec{}, ""); err == nil {
+		t.Errorf("expected an error when no object storage provider is configured")
+	}
+}
+
+func TestIsJobFinished(t *testing.T) {
+	tests := []struct {
+		name          string
+		conditions    []batchv1.JobCondition
+		finished      bool
+		conditionType batchv1.JobConditionType
+	}{
+		{
+			name: "running",
+		},
+		{
+			name:          "completed",
+			conditions:    []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+			finished:      true,
+			conditionType: batchv1.JobComplete,
+		},
+		{
+			name:          "failed",
+			conditions:    []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}},
+			finished:      true,
+			conditionType: batchv1.JobFailed,
+		},
+		{
+			name:       "suspended",
+			conditions: []batchv1.JobCondition{{Type: batchv1.JobSuspended, Status: corev1.ConditionTrue}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finished, conditionType := IsJobFinished(&batchv1.Job{Status: batchv1.JobStatus{Conditions: tt.conditions}})
+			if finished != tt.finished || conditionType != tt.conditionType {
+				t.Errorf("want: %v %s, got: %v %s", tt.finished, tt.conditionType, finished, conditionType)
+			}
+		})
+	}
+}
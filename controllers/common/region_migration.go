@@ -0,0 +1,230 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// RegionRoleLeader is the role of the leader region in the heartbeats of the datanodes.
+	RegionRoleLeader = "Leader"
+
+	// RegionMigrationTimeout is the timeout of the region migration, after which the migration will be resubmitted.
+	RegionMigrationTimeout = 10 * time.Minute
+)
+
+// RegionMigration is the migration of the region from one datanode to another.
+type RegionMigration struct {
+	RegionID   uint64
+	FromPeerID uint64
+	ToPeerID   uint64
+}
+
+// heartbeatStatValue is the heartbeat stats of the datanode that returned by the metasrv.
+type heartbeatStatValue struct {
+	Stats []struct {
+		ID          uint64 `json:"id"`
+		Addr        string `json:"addr"`
+		RegionStats []struct {
+			ID   uint64 `json:"id"`
+			Role string `json:"role"`
+		} `json:"region_stats"`
+	} `json:"stats"`
+}
+
+// submitRegionMigrationResult is the result of submitting the region migration that returned by the metasrv.
+type submitRegionMigrationResult struct {
+	ProcedureID string `json:"procedure_id"`
+}
+
+// GetDatanodeRegions requests the metasrv for the latest heartbeats of the datanodes,
+// and returns the node ids of the datanodes and the ids of their leader regions.
+func GetDatanodeRegions(metaHTTPServiceURL string) (map[uint64][]uint64, error) {
+	requestURL := metaHTTPServiceURL + "/admin/heartbeat"
+
+	rsp, err := http.Get(requestURL)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to request metasrv '%s' for heartbeats, status code: %d", requestURL, rsp.StatusCode)
+	}
+
+	var values []heartbeatStatValue
+	if err := json.NewDecoder(rsp.Body).Decode(&values); err != nil {
+		return nil, err
+	}
+
+	regions := make(map[uint64][]uint64)
+	for _, value := range values {
+		if len(value.Stats) == 0 {
+			continue
+		}
+
+		// The stats are ordered by time, and the last one is the latest.
+		stat := value.Stats[len(value.Stats)-1]
+		regions[stat.ID] = []uint64{}
+		for _, region := range stat.RegionStats {
+			if region.Role == RegionRoleLeader {
+				regions[stat.ID] = append(regions[stat.ID], region.ID)
+			}
+		}
+	}
+
+	return regions, nil
+}
+
+// SubmitRegionMigration requests the metasrv to migrate the region from one datanode to another, and returns the id of the procedure.
+func SubmitRegionMigration(metaHTTPServiceURL string, migration RegionMigration) (string, error) {
+	params := url.Values{}
+	params.Set("region_id", strconv.FormatUint(migration.RegionID, 10))
+	params.Set("from_peer_id", strconv.FormatUint(migration.FromPeerID, 10))
+	params.Set("to_peer_id", strconv.FormatUint(migration.ToPeerID, 10))
+	requestURL := metaHTTPServiceURL + "/admin/region-migration?" + params.Encode()
+
+	rsp, err := http.Post(requestURL, "application/json", nil)
+	if err != nil {
+		return "", err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to request metasrv '%s' for region migration, status code: %d", requestURL, rsp.StatusCode)
+	}
+
+	var result submitRegionMigrationResult
+	if err := json.NewDecoder(rsp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+
+	klog.Infof("Submit the migration of region '%d' from datanode '%d' to datanode '%d', procedure id: '%s'",
+		migration.RegionID, migration.FromPeerID, migration.ToPeerID, result.ProcedureID)
+
+	return result.ProcedureID, nil
+}
+
+// PlanRegionMigrations plans to migrate the leader regions off the removed datanodes to the target datanodes.
+// Every region is migrated to the target datanode that has the fewest regions, and the targets that have no heartbeat are ignored.
+// It returns an error if there are regions to migrate but no target datanode is available.
+func PlanRegionMigrations(regions map[uint64][]uint64, removedNodeIDs, targetNodeIDs []uint64) ([]RegionMigration, error) {
+	removed := make(map[uint64]bool, len(removedNodeIDs))
+	for _, id := range removedNodeIDs {
+		removed[id] = true
+	}
+
+	var (
+		targets     []uint64
+		regionCount = make(map[uint64]int)
+	)
+	for _, id := range targetNodeIDs {
+		if regionIDs, ok := regions[id]; ok && !removed[id] {
+			targets = append(targets, id)
+			regionCount[id] = len(regionIDs)
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i] < targets[j] })
+
+	var migrations []RegionMigration
+	for _, from := range removedNodeIDs {
+		for _, regionID := range regions[from] {
+			if len(targets) == 0 {
+				return nil, fmt.Errorf("no target datanode is available to migrate the region '%d' to", regionID)
+			}
+
+			to := targets[0]
+			for _, id := range targets[1:] {
+				if regionCount[id] < regionCount[to] {
+					to = id
+				}
+			}
+			regionCount[to]++
+
+			migrations = append(migrations, RegionMigration{RegionID: regionID, FromPeerID: from, ToPeerID: to})
+		}
+	}
+
+	return migrations, nil
+}
+
+// PlanRegionRebalance plans to migrate the leader regions between the datanodes to balance the number of their regions.
+// The datanodes that have no heartbeat are ignored.
+func PlanRegionRebalance(regions map[uint64][]uint64, nodeIDs []uint64) []RegionMigration {
+	var (
+		nodes []uint64
+		total int
+	)
+	for _, id := range nodeIDs {
+		if regionIDs, ok := regions[id]; ok {
+			nodes = append(nodes, id)
+			total += len(regionIDs)
+		}
+	}
+
+	if len(nodes) < 2 {
+		return nil
+	}
+
+	// The datanodes with more regions keep one more region if the regions can't be evenly distributed.
+	sort.Slice(nodes, func(i, j int) bool {
+		if len(regions[nodes[i]]) != len(regions[nodes[j]]) {
+			return len(regions[nodes[i]]) > len(regions[nodes[j]])
+		}
+		return nodes[i] < nodes[j]
+	})
+
+	var (
+		base  = total / len(nodes)
+		extra = total % len(nodes)
+	)
+
+	quota := func(i int) int {
+		if i < extra {
+			return base + 1
+		}
+		return base
+	}
+
+	var surplus []RegionMigration
+	for i, id := range nodes {
+		regionIDs := append([]uint64(nil), regions[id]...)
+		sort.Slice(regionIDs, func(i, j int) bool { return regionIDs[i] < regionIDs[j] })
+		for _, regionID := range regionIDs[min(quota(i), len(regionIDs)):] {
+			surplus = append(surplus, RegionMigration{RegionID: regionID, FromPeerID: id})
+		}
+	}
+
+	var migrations []RegionMigration
+	for i, id := range nodes {
+		for count := len(regions[id]); count < quota(i) && len(surplus) > 0; count++ {
+			migration := surplus[0]
+			surplus = surplus[1:]
+			migration.ToPeerID = id
+			migrations = append(migrations, migration)
+		}
+	}
+
+	return migrations
+}
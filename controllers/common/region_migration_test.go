@@ -0,0 +1,151 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestRegionMigration(t *testing.T) {
+	var submitted []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin/heartbeat", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+  {"stats": [{"id": 0, "addr": "datanode-0", "region_stats": [{"id": 1, "role": "Leader"}, {"id": 2, "role": "Leader"}]}]},
+  {"stats": [{"id": 1, "addr": "datanode-1", "region_stats": [{"id": 3, "role": "Leader"}]}]},
+  {"stats": [
+    {"id": 2, "addr": "datanode-2", "region_stats": [{"id": 4, "role": "Leader"}, {"id": 5, "role": "Leader"}, {"id": 6, "role": "Leader"}]},
+    {"id": 2, "addr": "datanode-2", "region_stats": [{"id": 4, "role": "Leader"}, {"id": 5, "role": "Leader"}, {"id": 6, "role": "Follower"}]}
+  ]}
+]`)
+	})
+	mux.HandleFunc("/admin/region-migration", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		query := r.URL.Query()
+		submitted = append(submitted, fmt.Sprintf("%s:%s->%s", query.Get("region_id"), query.Get("from_peer_id"), query.Get("to_peer_id")))
+		fmt.Fprintf(w, `{"procedure_id": "procedure-%d"}`, len(submitted))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	regions, err := GetDatanodeRegions(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantRegions := map[uint64][]uint64{0: {1, 2}, 1: {3}, 2: {4, 5}}
+	if !reflect.DeepEqual(regions, wantRegions) {
+		t.Fatalf("unexpected regions, want: %v, got: %v", wantRegions, regions)
+	}
+
+	// Scale in from 3 to 1 replicas.
+	migrations, err := PlanRegionMigrations(regions, []uint64{1, 2}, []uint64{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, migration := range migrations {
+		if _, err := SubmitRegionMigration(server.URL, migration); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wantSubmitted := []string{"3:1->0", "4:2->0", "5:2->0"}
+	if !reflect.DeepEqual(submitted, wantSubmitted) {
+		t.Errorf("unexpected region migrations, want: %v, got: %v", wantSubmitted, submitted)
+	}
+
+	// Scale in from 3 to 2 replicas, the regions are migrated to the datanode with the fewest regions.
+	migrations, err = PlanRegionMigrations(regions, []uint64{2}, []uint64{0, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantMigrations := []RegionMigration{
+		{RegionID: 4, FromPeerID: 2, ToPeerID: 1},
+		{RegionID: 5, FromPeerID: 2, ToPeerID: 0},
+	}
+	if !reflect.DeepEqual(migrations, wantMigrations) {
+		t.Errorf("unexpected region migrations, want: %v, got: %v", wantMigrations, migrations)
+	}
+
+	// The regions are only migrated to the target datanodes even if the others have fewer regions.
+	migrations, err = PlanRegionMigrations(regions, []uint64{2}, []uint64{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantMigrations = []RegionMigration{
+		{RegionID: 4, FromPeerID: 2, ToPeerID: 0},
+		{RegionID: 5, FromPeerID: 2, ToPeerID: 0},
+	}
+	if !reflect.DeepEqual(migrations, wantMigrations) {
+		t.Errorf("unexpected region migrations, want: %v, got: %v", wantMigrations, migrations)
+	}
+
+	// No datanode is left to migrate the regions to.
+	if _, err := PlanRegionMigrations(regions, []uint64{0, 1, 2}, nil); err == nil {
+		t.Errorf("expected an error when all the datanodes are removed")
+	}
+
+	// The target datanode has no heartbeat.
+	if _, err := PlanRegionMigrations(regions, []uint64{1, 2}, []uint64{3}); err == nil {
+		t.Errorf("expected an error when the target datanodes are not available")
+	}
+}
+
+func TestPlanRegionRebalance(t *testing.T) {
+	tests := []struct {
+		name    string
+		regions map[uint64][]uint64
+		nodeIDs []uint64
+		want    []RegionMigration
+	}{
+		{
+			name:    "scale out from 2 to 3 datanodes",
+			regions: map[uint64][]uint64{0: {1, 2, 3}, 1: {4, 5, 6}, 2: {}},
+			nodeIDs: []uint64{0, 1, 2},
+			want:    []RegionMigration{{RegionID: 3, FromPeerID: 0, ToPeerID: 2}, {RegionID: 6, FromPeerID: 1, ToPeerID: 2}},
+		},
+		{
+			name:    "the regions can't be evenly distributed",
+			regions: map[uint64][]uint64{0: {1, 2, 3, 4, 5}, 1: {}},
+			nodeIDs: []uint64{0, 1},
+			want:    []RegionMigration{{RegionID: 4, FromPeerID: 0, ToPeerID: 1}, {RegionID: 5, FromPeerID: 0, ToPeerID: 1}},
+		},
+		{
+			name:    "the datanode without heartbeat is ignored",
+			regions: map[uint64][]uint64{0: {1, 2}, 1: {3}},
+			nodeIDs: []uint64{0, 1, 2},
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		got := PlanRegionRebalance(tt.regions, tt.nodeIDs)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: want %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
@@ -16,8 +16,11 @@ package constant
 
 const (
 	GreptimeDBComponentName = "app.greptime.io/component"
-	GreptimeDBConfigDir     = "/etc/greptimedb"
-	GreptimeDBTLSDir        = "/etc/greptimedb/tls"
+
+	// GreptimeDBBackupScheduleLabelKey is the label key of the backups that created by the GreptimeDBBackupSchedule.
+	GreptimeDBBackupScheduleLabelKey = "app.greptime.io/backup-schedule"
+	GreptimeDBConfigDir              = "/etc/greptimedb"
+	GreptimeDBTLSDir                 = "/etc/greptimedb/tls"
 
 	// GreptimeDBInitConfigDir used for greptimedb-initializer.
 	GreptimeDBInitConfigDir = "/etc/greptimedb-init"
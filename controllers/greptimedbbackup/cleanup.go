@@ -0,0 +1,65 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbbackup
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+)
+
+// CleanupJobName returns the name of the job that deletes the backup data from the object storage.
+func CleanupJobName(backupName string) string {
+	return backupName + "-cleanup"
+}
+
+// buildCleanupJob builds the job that runs `rclone purge` to delete the `${prefix}` directory of the backup.
+// The rclone remote is configured by the environment variables, and the credentials are read from the secrets of the object storage.
+func buildCleanupJob(backup *v1alpha1.GreptimeDBBackup, image string) (*batchv1.Job, error) {
+	remote, env, err := common.RcloneRemote("backup", backup.Status.ObjectStorage, backup.Spec.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CleanupJobName(backup.Name),
+			Namespace: backup.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(3)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:    "cleanup",
+							Image:   image,
+							Command: []string{"rclone"},
+							Args:    []string{"purge", remote, "--verbose"},
+							Env:     env,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return job, nil
+}
@@ -0,0 +1,414 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbbackup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/cmd/operator/app/options"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+)
+
+const (
+	greptimedbBackupFinalizer = "greptimedbbackup.greptime.io/finalizer"
+)
+
+var (
+	defaultRequeueAfter = 10 * time.Second
+)
+
+// Reconciler reconciles a GreptimeDBBackup object.
+type Reconciler struct {
+	client.Client
+
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// Exporter exports the databases of the cluster to the object storage.
+	Exporter Exporter
+
+	// CleanupImage is the image of the job that deletes the backup data from the object storage.
+	CleanupImage string
+
+	Clock clock.PassiveClock
+}
+
+func Setup(mgr ctrl.Manager, o *options.Options) error {
+	reconciler := &Reconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Recorder:     mgr.GetEventRecorderFor("greptimedbbackup-controller"),
+		Exporter:     &mysqlExporter{},
+		CleanupImage: o.BackupCleanupImage,
+		Clock:        clock.RealClock{},
+	}
+	return reconciler.SetupWithManager(mgr)
+}
+
+// +kubebuilder:rbac:groups=greptime.io,resources=greptimedbbackups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=greptime.io,resources=greptimedbbackups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=greptime.io,resources=greptimedbclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch;
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete;
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	klog.V(2).Infof("Reconciling GreptimeDBBackup: %s", req.NamespacedName)
+
+	var err error
+	backup := new(v1alpha1.GreptimeDBBackup)
+	if err := r.Get(ctx, req.NamespacedName, backup); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			r.Recorder.Event(backup, corev1.EventTypeWarning, "ReconcileError", fmt.Sprintf("Reconcile error: %v", err))
+		}
+	}()
+
+	if !backup.DeletionTimestamp.IsZero() {
+		klog.V(2).Infof("Backup '%s/%s' is being deleted", backup.Namespace, backup.Name)
+		return r.delete(ctx, backup)
+	}
+
+	if err = r.addFinalizer(ctx, backup); err != nil {
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "AddFinalizerFailed", fmt.Sprintf("Add finalizer failed: %v", err))
+		return ctrl.Result{}, err
+	}
+
+	// The backup is a one-shot job and will not be executed again once it's finished.
+	if backup.IsFinished() {
+		return ctrl.Result{}, nil
+	}
+
+	if err = backup.Validate(); err != nil {
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "InvalidBackup", fmt.Sprintf("Invalid backup: %v", err))
+		return ctrl.Result{}, r.fail(ctx, backup, err)
+	}
+
+	if err = backup.Check(ctx, r.Client); err != nil {
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "InvalidBackup", fmt.Sprintf("Invalid backup: %v", err))
+		return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+	}
+
+	originalObject := backup.DeepCopy()
+	if err = backup.SetDefaults(); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !cmp.Equal(originalObject.Spec, backup.Spec) {
+		// Update the default values to the backup spec if it is not set.
+		if err = r.Update(ctx, backup); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	cluster := new(v1alpha1.GreptimeDBCluster)
+	if err = r.Get(ctx, client.ObjectKey{Namespace: backup.Namespace, Name: backup.Spec.ClusterName}, cluster); err != nil {
+		if k8serrors.IsNotFound(err) {
+			err = nil
+			return r.pending(ctx, backup, fmt.Sprintf("cluster '%s' is not found", backup.Spec.ClusterName))
+		}
+		return ctrl.Result{}, err
+	}
+
+	if cluster.Status.ClusterPhase != v1alpha1.PhaseRunning {
+		return r.pending(ctx, backup, fmt.Sprintf("cluster '%s' is not running", cluster.Name))
+	}
+
+	if backup.Status.BackupPhase != v1alpha1.BackupPhaseRunning {
+		return r.start(ctx, backup, cluster)
+	}
+
+	return r.export(ctx, backup, cluster)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.GreptimeDBBackup{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
+
+// start resolves the location and the databases of the backup and moves the backup to the running phase.
+func (r *Reconciler) start(ctx context.Context, backup *v1alpha1.GreptimeDBBackup, cluster *v1alpha1.GreptimeDBCluster) (ctrl.Result, error) {
+	location, osp, err := r.location(ctx, backup, cluster)
+	if err != nil {
+		return ctrl.Result{}, r.fail(ctx, backup, err)
+	}
+
+	databases := backup.Spec.Databases
+	if len(databases) == 0 {
+		conn, err := r.connection(ctx, backup, cluster)
+		if err != nil {
+			return ctrl.Result{}, r.fail(ctx, backup, err)
+		}
+
+		databases, err = r.Exporter.ListDatabases(ctx, conn)
+		if err != nil {
+			klog.Errorf("Failed to list databases of cluster '%s/%s': %v", cluster.Namespace, cluster.Name, err)
+			return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+		}
+	}
+
+	backup.Status.BackupPhase = v1alpha1.BackupPhaseRunning
+	backup.Status.StartTime = ptrNow()
+	backup.Status.Location = location.URL
+	backup.Status.ObjectStorage = osp.DeepCopy()
+	backup.Status.Databases = databases
+	backup.Status.Message = ""
+	backup.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeProgressing, corev1.ConditionTrue, "BackupStarted", "the backup is exporting databases"))
+
+	r.Recorder.Event(backup, corev1.EventTypeNormal, "BackupStarted", fmt.Sprintf("Start to export %d databases to '%s'", len(databases), location.URL))
+
+	if err := r.updateStatus(ctx, backup); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// export exports the next database that has not been exported. It only exports one database in one reconciliation,
+// so the progress is persisted in the status and the backup can continue after the operator restarts.
+func (r *Reconciler) export(ctx context.Context, backup *v1alpha1.GreptimeDBBackup, cluster *v1alpha1.GreptimeDBCluster) (ctrl.Result, error) {
+	var next string
+	for _, database := range backup.Status.Databases {
+		if !slices.Contains(backup.Status.ExportedDatabases, database) {
+			next = database
+			break
+		}
+	}
+
+	if next == "" {
+		return ctrl.Result{}, r.complete(ctx, backup)
+	}
+
+	location, _, err := r.location(ctx, backup, cluster)
+	if err != nil {
+		return ctrl.Result{}, r.fail(ctx, backup, err)
+	}
+
+	conn, err := r.connection(ctx, backup, cluster)
+	if err != nil {
+		return ctrl.Result{}, r.fail(ctx, backup, err)
+	}
+
+	klog.Infof("Export database '%s' of cluster '%s/%s' to '%s'", next, cluster.Namespace, cluster.Name, location.DatabaseURL(next))
+
+	result, err := r.Exporter.ExportDatabase(ctx, conn, next, location, backup.GetFormat())
+	if err != nil {
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "ExportDatabaseFailed", fmt.Sprintf("Export database '%s' failed: %v", next, err))
+		return ctrl.Result{}, r.fail(ctx, backup, fmt.Errorf("export database '%s' failed: %v", next, err))
+	}
+
+	backup.Status.ExportedDatabases = append(backup.Status.ExportedDatabases, next)
+	backup.Status.ExportedBytes += result.Bytes
+
+	r.Recorder.Event(backup, corev1.EventTypeNormal, "DatabaseExported", fmt.Sprintf("Database '%s' is exported, %d tables", next, result.Tables))
+
+	if err := r.updateStatus(ctx, backup); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{Requeue: true}, nil
+}
+
+func (r *Reconciler) location(ctx context.Context, backup *v1alpha1.GreptimeDBBackup, cluster *v1alpha1.GreptimeDBCluster) (*common.CopyLocation, *v1alpha1.ObjectStorageProviderSpec, error) {
+	// The credentials of the cluster object storage are stored in the namespace of the cluster,
+	// which is the same as the backup.
+	osp := backup.GetObjectStorage()
+	if osp == nil {
+		osp = cluster.GetObjectStorageProvider()
+	}
+
+	location, err := common.NewCopyLocation(ctx, r.Client, backup.Namespace, osp, backup.Spec.Prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return location, osp, nil
+}
+
+func (r *Reconciler) connection(ctx context.Context, backup *v1alpha1.GreptimeDBBackup, cluster *v1alpha1.GreptimeDBCluster) (*common.MySQLConnection, error) {
+	return common.NewMySQLConnection(ctx, r.Client, cluster, backup.Spec.CredentialsSecretName)
+}
+
+// addFinalizer adds the finalizer to the backup whose data should be deleted with it.
+func (r *Reconciler) addFinalizer(ctx context.Context, backup *v1alpha1.GreptimeDBBackup) error {
+	if backup.Spec.DeletionPolicy == v1alpha1.BackupDeletionPolicyDelete {
+		if !controllerutil.ContainsFinalizer(backup, greptimedbBackupFinalizer) {
+			controllerutil.AddFinalizer(backup, greptimedbBackupFinalizer)
+			if err := r.Update(ctx, backup); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// delete runs the cleanup job to delete the backup data from the object storage and removes the finalizer when the job is finished.
+// The finalizer will be removed even if the job is failed, so the backup can always be deleted.
+func (r *Reconciler) delete(ctx context.Context, backup *v1alpha1.GreptimeDBBackup) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(backup, greptimedbBackupFinalizer) {
+		klog.V(2).Info("Skipping as it does not have a finalizer")
+		return ctrl.Result{}, nil
+	}
+
+	// The backup data only exists after the backup started.
+	if backup.Spec.DeletionPolicy == v1alpha1.BackupDeletionPolicyDelete && backup.Status.Location != "" {
+		job := new(batchv1.Job)
+		err := r.Get(ctx, client.ObjectKey{Namespace: backup.Namespace, Name: CleanupJobName(backup.Name)}, job)
+		if k8serrors.IsNotFound(err) {
+			job, err = buildCleanupJob(backup, r.CleanupImage)
+			if err != nil {
+				r.Recorder.Event(backup, corev1.EventTypeWarning, "CleanupFailed", fmt.Sprintf("Build cleanup job failed: %v", err))
+				return r.removeFinalizer(ctx, backup)
+			}
+
+			if err := controllerutil.SetControllerReference(backup, job, r.Scheme); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			if err := r.Create(ctx, job); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			r.Recorder.Event(backup, corev1.EventTypeNormal, "CleanupStarted", fmt.Sprintf("Start to delete the backup data in '%s'", backup.Status.Location))
+			return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+		}
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		finished, conditionType := common.IsJobFinished(job)
+		if !finished {
+			return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+		}
+
+		if conditionType == batchv1.JobComplete {
+			r.Recorder.Event(backup, corev1.EventTypeNormal, "CleanupCompleted", fmt.Sprintf("The backup data in '%s' is deleted", backup.Status.Location))
+		} else {
+			r.Recorder.Event(backup, corev1.EventTypeWarning, "CleanupFailed", fmt.Sprintf("Cleanup job '%s' failed, the backup data in '%s' should be deleted manually", job.Name, backup.Status.Location))
+		}
+	}
+
+	return r.removeFinalizer(ctx, backup)
+}
+
+func (r *Reconciler) removeFinalizer(ctx context.Context, backup *v1alpha1.GreptimeDBBackup) (ctrl.Result, error) {
+	controllerutil.RemoveFinalizer(backup, greptimedbBackupFinalizer)
+	if err := r.Update(ctx, backup); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	klog.Infof("Delete GreptimeDB backup '%s/%s'", backup.Namespace, backup.Name)
+
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) pending(ctx context.Context, backup *v1alpha1.GreptimeDBBackup, reason string) (ctrl.Result, error) {
+	klog.V(2).Infof("Backup '%s/%s' is pending: %s", backup.Namespace, backup.Name, reason)
+
+	// The backup will be continued when the cluster is running again.
+	if backup.Status.BackupPhase == v1alpha1.BackupPhaseRunning {
+		return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+	}
+
+	// The backup that can't start in time is failed, so it doesn't block the next backups of the schedule.
+	if timeout := backup.GetPendingTimeout(); timeout != nil && r.Clock.Since(backup.CreationTimestamp.Time) > timeout.Duration {
+		return ctrl.Result{}, r.fail(ctx, backup, fmt.Errorf("the backup is pending for longer than %s: %s", timeout.Duration, reason))
+	}
+
+	if backup.Status.BackupPhase != v1alpha1.BackupPhasePending || backup.Status.Message != reason {
+		backup.Status.BackupPhase = v1alpha1.BackupPhasePending
+		backup.Status.Message = reason
+		if err := r.updateStatus(ctx, backup); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+}
+
+func (r *Reconciler) complete(ctx context.Context, backup *v1alpha1.GreptimeDBBackup) error {
+	backup.Status.BackupPhase = v1alpha1.BackupPhaseCompleted
+	backup.Status.CompletionTime = ptrNow()
+	backup.Status.Message = ""
+	backup.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeProgressing, corev1.ConditionFalse, "BackupCompleted", "the backup is completed"))
+
+	r.Recorder.Event(backup, corev1.EventTypeNormal, "BackupCompleted", fmt.Sprintf("Backup is completed, %d databases are exported to '%s'", len(backup.Status.ExportedDatabases), backup.Status.Location))
+
+	return r.updateStatus(ctx, backup)
+}
+
+func (r *Reconciler) fail(ctx context.Context, backup *v1alpha1.GreptimeDBBackup, cause error) error {
+	klog.Errorf("Backup '%s/%s' failed: %v", backup.Namespace, backup.Name, cause)
+
+	backup.Status.BackupPhase = v1alpha1.BackupPhaseFailed
+	backup.Status.CompletionTime = ptrNow()
+	backup.Status.Message = cause.Error()
+	backup.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeProgressing, corev1.ConditionFalse, "BackupFailed", cause.Error()))
+
+	r.Recorder.Event(backup, corev1.EventTypeWarning, "BackupFailed", fmt.Sprintf("Backup failed: %v", cause))
+
+	return r.updateStatus(ctx, backup)
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, backup *v1alpha1.GreptimeDBBackup) error {
+	backup.Status.ObservedGeneration = backup.Generation
+	return UpdateStatus(ctx, backup, r.Client)
+}
+
+func UpdateStatus(ctx context.Context, input *v1alpha1.GreptimeDBBackup, kc client.Client, opts ...client.SubResourceUpdateOption) error {
+	backup := input.DeepCopy()
+	status := backup.Status
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
+		objectKey := client.ObjectKey{Namespace: backup.Namespace, Name: backup.Name}
+		if err = kc.Get(ctx, objectKey, backup); err != nil {
+			return
+		}
+		backup.Status = status
+		return kc.Status().Update(ctx, backup, opts...)
+	})
+}
+
+func ptrNow() *metav1.Time {
+	now := metav1.Now()
+	return &now
+}
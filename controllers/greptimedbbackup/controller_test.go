@@ -0,0 +1,563 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbbackup
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+)
+
+func TestBackupProgress(t *testing.T) {
+	backup := newBackup(v1alpha1.BackupDeletionPolicyRetain)
+	r := newTestReconciler(newCluster(v1alpha1.PhaseRunning), backup)
+	exporter := &fakeExporter{
+		databases: []string{"db1", "db2", "db3"},
+		results: map[string]*ExportResult{
+			"db1": {Bytes: 1024, Tables: 2},
+			"db2": {Bytes: 2048, Tables: 3},
+			// The size of the empty database is NULL.
+			"db3": {Bytes: 0, Tables: 0},
+		},
+	}
+	r.Exporter = exporter
+
+	// The databases are listed when the backup starts.
+	reconcile(t, r, backup)
+	got := getBackup(t, r, backup)
+	if got.Status.BackupPhase != v1alpha1.BackupPhaseRunning || got.Status.StartTime == nil {
+		t.Fatalf("expected the backup to be running, got: %+v", got.Status)
+	}
+	if !reflect.DeepEqual(got.Status.Databases, exporter.databases) {
+		t.Errorf("unexpected databases: %v", got.Status.Databases)
+	}
+	if got.Status.Location != "s3://bucket/backups/backup/" {
+		t.Errorf("unexpected location: %s", got.Status.Location)
+	}
+	if s3 := got.Status.ObjectStorage.GetS3Storage(); s3 == nil || s3.Bucket != "bucket" {
+		t.Errorf("expected the object storage of the cluster to be recorded, got: %+v", got.Status.ObjectStorage)
+	}
+	if condition := got.Status.GetCondition(v1alpha1.ConditionTypeProgressing); condition == nil || condition.Status != corev1.ConditionTrue {
+		t.Errorf("expected the backup to be progressing, got: %+v", condition)
+	}
+
+	// Only one database is exported in one reconciliation.
+	for i, want := range [][]string{{"db1"}, {"db1", "db2"}, {"db1", "db2", "db3"}} {
+		reconcile(t, r, backup)
+		got = getBackup(t, r, backup)
+		if got.Status.BackupPhase != v1alpha1.BackupPhaseRunning {
+			t.Fatalf("step %d: expected the backup to be running, got: %s", i, got.Status.BackupPhase)
+		}
+		if !reflect.DeepEqual(got.Status.ExportedDatabases, want) {
+			t.Fatalf("step %d: unexpected exported databases, want: %v, got: %v", i, want, got.Status.ExportedDatabases)
+		}
+	}
+
+	reconcile(t, r, backup)
+	got = getBackup(t, r, backup)
+	if got.Status.BackupPhase != v1alpha1.BackupPhaseCompleted || got.Status.CompletionTime == nil {
+		t.Fatalf("expected the backup to be completed, got: %+v", got.Status)
+	}
+	if condition := got.Status.GetCondition(v1alpha1.ConditionTypeProgressing); condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != "BackupCompleted" {
+		t.Errorf("expected the backup not to be progressing, got: %+v", condition)
+	}
+
+	// The sizes of the exported databases are summed up.
+	if got.Status.ExportedBytes != 3072 {
+		t.Errorf("expected 3072 exported bytes, got: %d", got.Status.ExportedBytes)
+	}
+
+	if want := []string{"db1", "db2", "db3"}; !reflect.DeepEqual(exporter.exported, want) {
+		t.Errorf("expected to export %v, got: %v", want, exporter.exported)
+	}
+
+	// The finished backup is not executed again.
+	reconcile(t, r, backup)
+	if len(exporter.exported) != 3 {
+		t.Errorf("expected the completed backup not to be executed again, got: %v", exporter.exported)
+	}
+
+	// The backup data is retained, so there is no finalizer.
+	if len(got.Finalizers) != 0 {
+		t.Errorf("unexpected finalizers: %v", got.Finalizers)
+	}
+}
+
+func TestBackupPending(t *testing.T) {
+	backup := newBackup(v1alpha1.BackupDeletionPolicyRetain)
+	backup.Spec.Databases = []string{"db1"}
+	r := newTestReconciler(backup)
+	exporter := &fakeExporter{}
+	r.Exporter = exporter
+
+	// The backup waits for the cluster to be created.
+	reconcile(t, r, backup)
+	got := getBackup(t, r, backup)
+	if got.Status.BackupPhase != v1alpha1.BackupPhasePending || got.Status.Message != "cluster 'test' is not found" {
+		t.Fatalf("expected the backup to be pending, got: %+v", got.Status)
+	}
+
+	// The backup waits for the cluster to be running.
+	cluster := newCluster(v1alpha1.PhaseStarting)
+	if err := r.Create(context.Background(), cluster); err != nil {
+		t.Fatal(err)
+	}
+	reconcile(t, r, backup)
+	got = getBackup(t, r, backup)
+	if got.Status.BackupPhase != v1alpha1.BackupPhasePending || got.Status.Message != "cluster 'test' is not running" {
+		t.Fatalf("expected the backup to be pending, got: %+v", got.Status)
+	}
+
+	cluster.Status.ClusterPhase = v1alpha1.PhaseRunning
+	if err := r.Status().Update(context.Background(), cluster); err != nil {
+		t.Fatal(err)
+	}
+
+	// The databases in the spec are not listed from the cluster.
+	reconcile(t, r, backup)
+	got = getBackup(t, r, backup)
+	if got.Status.BackupPhase != v1alpha1.BackupPhaseRunning || got.Status.Message != "" {
+		t.Fatalf("expected the backup to be running, got: %+v", got.Status)
+	}
+	if !reflect.DeepEqual(got.Status.Databases, []string{"db1"}) || exporter.listed != 0 {
+		t.Errorf("expected to export the databases in the spec, got: %v", got.Status.Databases)
+	}
+
+	// The running backup stays running while the cluster is not running, and continues after that.
+	cluster.Status.ClusterPhase = v1alpha1.PhaseUpdating
+	if err := r.Status().Update(context.Background(), cluster); err != nil {
+		t.Fatal(err)
+	}
+	reconcile(t, r, backup)
+	if got = getBackup(t, r, backup); got.Status.BackupPhase != v1alpha1.BackupPhaseRunning || len(exporter.exported) != 0 {
+		t.Fatalf("expected the backup to be running without exporting, got: %+v", got.Status)
+	}
+
+	cluster.Status.ClusterPhase = v1alpha1.PhaseRunning
+	if err := r.Status().Update(context.Background(), cluster); err != nil {
+		t.Fatal(err)
+	}
+	reconcileUntilFinished(t, r, backup)
+	if got = getBackup(t, r, backup); got.Status.BackupPhase != v1alpha1.BackupPhaseCompleted {
+		t.Fatalf("expected the backup to be completed, got: %s", got.Status.BackupPhase)
+	}
+}
+
+func TestBackupPendingTimeout(t *testing.T) {
+	backup := newBackup(v1alpha1.BackupDeletionPolicyRetain)
+	backup.Spec.PendingTimeout = &metav1.Duration{Duration: 30 * time.Minute}
+	r := newTestReconciler(newCluster(v1alpha1.PhaseUpdating), backup)
+	exporter := &fakeExporter{databases: []string{"db1"}}
+	r.Exporter = exporter
+
+	reconcile(t, r, backup)
+	if got := getBackup(t, r, backup); got.Status.BackupPhase != v1alpha1.BackupPhasePending {
+		t.Fatalf("expected the backup to be pending, got: %s", got.Status.BackupPhase)
+	}
+
+	// The backup is failed if the cluster is not running within the pending timeout.
+	r.Clock.(*clocktesting.FakePassiveClock).SetTime(testCreationTime.Add(31 * time.Minute))
+	reconcile(t, r, backup)
+
+	got := getBackup(t, r, backup)
+	if got.Status.BackupPhase != v1alpha1.BackupPhaseFailed || got.Status.CompletionTime == nil {
+		t.Fatalf("expected the backup to be failed, got: %+v", got.Status)
+	}
+	if want := "the backup is pending for longer than 30m0s: cluster 'test' is not running"; got.Status.Message != want {
+		t.Errorf("unexpected message, want: %q, got: %q", want, got.Status.Message)
+	}
+	if exporter.listed != 0 || len(exporter.exported) != 0 {
+		t.Errorf("expected the timed out backup not to be started")
+	}
+}
+
+func TestBackupFailed(t *testing.T) {
+	tests := []struct {
+		name     string
+		backup   func(*v1alpha1.GreptimeDBBackup)
+		exporter *fakeExporter
+		exported []string
+	}{
+		{
+			name: "invalid backup",
+			backup: func(backup *v1alpha1.GreptimeDBBackup) {
+				backup.Spec.Databases = []string{""}
+			},
+			exporter: &fakeExporter{},
+		},
+		{
+			name: "export failed",
+			exporter: &fakeExporter{
+				databases: []string{"db1", "db2"},
+				failures:  map[string]error{"db2": fmt.Errorf("connection reset")},
+			},
+			exported: []string{"db1", "db2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup := newBackup(v1alpha1.BackupDeletionPolicyRetain)
+			if tt.backup != nil {
+				tt.backup(backup)
+			}
+			r := newTestReconciler(newCluster(v1alpha1.PhaseRunning), backup)
+			r.Exporter = tt.exporter
+
+			reconcileUntilFinished(t, r, backup)
+
+			got := getBackup(t, r, backup)
+			if got.Status.BackupPhase != v1alpha1.BackupPhaseFailed || got.Status.CompletionTime == nil || got.Status.Message == "" {
+				t.Fatalf("expected the backup to be failed, got: %+v", got.Status)
+			}
+			if condition := got.Status.GetCondition(v1alpha1.ConditionTypeProgressing); condition == nil || condition.Reason != "BackupFailed" {
+				t.Errorf("unexpected condition of the failed backup: %+v", condition)
+			}
+			if !reflect.DeepEqual(tt.exporter.exported, tt.exported) {
+				t.Errorf("expected to export %v, got: %v", tt.exported, tt.exporter.exported)
+			}
+
+			// The failed backup is not retried.
+			reconcile(t, r, backup)
+			if !reflect.DeepEqual(tt.exporter.exported, tt.exported) {
+				t.Errorf("expected the failed backup not to be retried, got: %v", tt.exporter.exported)
+			}
+		})
+	}
+}
+
+func TestBackupDeletion(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition batchv1.JobConditionType
+		reason    string
+	}{
+		{
+			name:      "cleanup completed",
+			condition: batchv1.JobComplete,
+			reason:    "CleanupCompleted",
+		},
+		{
+			name:      "cleanup failed",
+			condition: batchv1.JobFailed,
+			reason:    "CleanupFailed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			backup := newBackup(v1alpha1.BackupDeletionPolicyDelete)
+			r := newTestReconciler(newCluster(v1alpha1.PhaseRunning), backup)
+			r.Exporter = &fakeExporter{databases: []string{"db1"}}
+			r.CleanupImage = "rclone/rclone:1.69"
+			recorder := r.Recorder.(*record.FakeRecorder)
+
+			reconcileUntilFinished(t, r, backup)
+			got := getBackup(t, r, backup)
+			if !reflect.DeepEqual(got.Finalizers, []string{greptimedbBackupFinalizer}) {
+				t.Fatalf("expected the finalizer to be added, got: %v", got.Finalizers)
+			}
+
+			if err := r.Delete(ctx, got); err != nil {
+				t.Fatal(err)
+			}
+
+			// The finalizer is kept until the cleanup job is finished.
+			reconcile(t, r, backup)
+			reconcile(t, r, backup)
+			got = getBackup(t, r, backup)
+			if len(got.Finalizers) == 0 {
+				t.Fatalf("expected the finalizer to be kept")
+			}
+
+			job := &batchv1.Job{}
+			if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: CleanupJobName("backup")}, job); err != nil {
+				t.Fatal(err)
+			}
+			if owner := metav1.GetControllerOf(job); owner == nil || owner.Name != "backup" {
+				t.Errorf("expected the cleanup job to be owned by the backup, got: %v", owner)
+			}
+			if args := job.Spec.Template.Spec.Containers[0].Args; !reflect.DeepEqual(args, []string{"purge", "backup:bucket/backups/backup", "--verbose"}) {
+				t.Errorf("unexpected args of the cleanup job: %v", args)
+			}
+
+			job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{Type: tt.condition, Status: corev1.ConditionTrue})
+			if err := r.Status().Update(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+
+			// The finalizer is removed even if the cleanup job is failed.
+			reconcile(t, r, backup)
+			if err := r.Get(ctx, client.ObjectKeyFromObject(backup), new(v1alpha1.GreptimeDBBackup)); !k8serrors.IsNotFound(err) {
+				t.Fatalf("expected the backup to be deleted, got: %v", err)
+			}
+
+			if !hasEvent(recorder, tt.reason) {
+				t.Errorf("expected the %s event", tt.reason)
+			}
+		})
+	}
+}
+
+func TestBuildCleanupJob(t *testing.T) {
+	tests := []struct {
+		name   string
+		osp    *v1alpha1.ObjectStorageProviderSpec
+		remote string
+		env    map[string]string
+		secret map[string]string
+	}{
+		{
+			name: "s3",
+			osp: &v1alpha1.ObjectStorageProviderSpec{
+				S3: &v1alpha1.S3Storage{Bucket: "bucket", Root: "/greptimedb/", Region: "us-west-2", Endpoint: "https://s3.amazonaws.com", SecretName: "s3-credentials"},
+			},
+			remote: "backup:bucket/greptimedb/backups/backup",
+			env: map[string]string{
+				"RCLONE_CONFIG_BACKUP_TYPE":             "s3",
+				"RCLONE_CONFIG_BACKUP_PROVIDER":         "Other",
+				"RCLONE_CONFIG_BACKUP_REGION":           "us-west-2",
+				"RCLONE_CONFIG_BACKUP_ENDPOINT":         "https://s3.amazonaws.com",
+				"RCLONE_CONFIG_BACKUP_FORCE_PATH_STYLE": "true",
+			},
+			secret: map[string]string{
+				"RCLONE_CONFIG_BACKUP_ACCESS_KEY_ID":     v1alpha1.AccessKeyIDSecretKey,
+				"RCLONE_CONFIG_BACKUP_SECRET_ACCESS_KEY": v1alpha1.SecretAccessKeySecretKey,
+			},
+		},
+		{
+			name: "oss",
+			osp: &v1alpha1.ObjectStorageProviderSpec{
+				OSS: &v1alpha1.OSSStorage{Bucket: "bucket", Endpoint: "oss-cn-hangzhou.aliyuncs.com", SecretName: "oss-credentials"},
+			},
+			remote: "backup:bucket/backups/backup",
+			env: map[string]string{
+				"RCLONE_CONFIG_BACKUP_TYPE":     "s3",
+				"RCLONE_CONFIG_BACKUP_PROVIDER": "Alibaba",
+				"RCLONE_CONFIG_BACKUP_ENDPOINT": "oss-cn-hangzhou.aliyuncs.com",
+			},
+			secret: map[string]string{
+				"RCLONE_CONFIG_BACKUP_ACCESS_KEY_ID":     v1alpha1.AccessKeyIDSecretKey,
+				"RCLONE_CONFIG_BACKUP_SECRET_ACCESS_KEY": v1alpha1.AccessKeySecretSecretKey,
+			},
+		},
+		{
+			name: "gcs without secret",
+			osp: &v1alpha1.ObjectStorageProviderSpec{
+				GCS: &v1alpha1.GCSStorage{Bucket: "bucket", Root: "greptimedb"},
+			},
+			remote: "backup:bucket/greptimedb/backups/backup",
+			env: map[string]string{
+				"RCLONE_CONFIG_BACKUP_TYPE":               "google cloud storage",
+				"RCLONE_CONFIG_BACKUP_BUCKET_POLICY_ONLY": "true",
+				"RCLONE_CONFIG_BACKUP_ENV_AUTH":           "true",
+			},
+		},
+		{
+			name: "azblob",
+			osp: &v1alpha1.ObjectStorageProviderSpec{
+				AZBlob: &v1alpha1.AZBlobStorage{Container: "container", SecretName: "azblob-credentials"},
+			},
+			remote: "backup:container/backups/backup",
+			env: map[string]string{
+				"RCLONE_CONFIG_BACKUP_TYPE": "azureblob",
+			},
+			secret: map[string]string{
+				"RCLONE_CONFIG_BACKUP_ACCOUNT": v1alpha1.AccountName,
+				"RCLONE_CONFIG_BACKUP_KEY":     v1alpha1.AccountKey,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup := newBackup(v1alpha1.BackupDeletionPolicyDelete)
+			backup.Spec.Prefix = "backups/backup"
+			backup.Status.ObjectStorage = tt.osp
+
+			job, err := buildCleanupJob(backup, "rclone/rclone:1.69")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if job.Name != "backup-cleanup" || job.Namespace != "default" {
+				t.Errorf("unexpected cleanup job: %s/%s", job.Namespace, job.Name)
+			}
+
+			container := job.Spec.Template.Spec.Containers[0]
+			if container.Image != "rclone/rclone:1.69" {
+				t.Errorf("unexpected image: %s", container.Image)
+			}
+			if want := []string{"purge", tt.remote, "--verbose"}; !reflect.DeepEqual(container.Args, want) {
+				t.Errorf("unexpected args, want: %v, got: %v", want, container.Args)
+			}
+
+			env := make(map[string]string)
+			secret := make(map[string]string)
+			for _, e := range container.Env {
+				if e.ValueFrom != nil {
+					secret[e.Name] = e.ValueFrom.SecretKeyRef.Key
+				} else {
+					env[e.Name] = e.Value
+				}
+			}
+			if !reflect.DeepEqual(env, tt.env) {
+				t.Errorf("unexpected env, want: %v, got: %v", tt.env, env)
+			}
+			if len(tt.secret) != 0 && !reflect.DeepEqual(secret, tt.secret) {
+				t.Errorf("unexpected secret env, want: %v, got: %v", tt.secret, secret)
+			}
+		})
+	}
+
+	// The backup without the object storage can't be cleaned up.
+	backup := newBackup(v1alpha1.BackupDeletionPolicyDelete)
+	backup.Status.ObjectStorage = nil
+	if _, err := buildCleanupJob(backup, "rclone/rclone:1.69"); err == nil {
+		t.Errorf("expected an error when the backup has no object storage")
+	}
+}
+
+type fakeExporter struct {
+	databases []string
+	results   map[string]*ExportResult
+	failures  map[string]error
+
+	listed   int
+	exported []string
+}
+
+var _ Exporter = &fakeExporter{}
+
+func (e *fakeExporter) ListDatabases(_ context.Context, _ *common.MySQLConnection) ([]string, error) {
+	e.listed++
+	return e.databases, nil
+}
+
+func (e *fakeExporter) ExportDatabase(_ context.Context, _ *common.MySQLConnection, database string, _ *common.CopyLocation, _ v1alpha1.BackupFormat) (*ExportResult, error) {
+	e.exported = append(e.exported, database)
+	if err := e.failures[database]; err != nil {
+		return nil, err
+	}
+	if result := e.results[database]; result != nil {
+		return result, nil
+	}
+	return &ExportResult{}, nil
+}
+
+// testCreationTime is the creation time of the backup in the tests.
+var testCreationTime = time.Date(2026, time.March, 10, 2, 0, 0, 0, time.UTC)
+
+// newTestReconciler returns a Reconciler backed by a fake client that is seeded with objs.
+func newTestReconciler(objs ...client.Object) *Reconciler {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+
+	return &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&v1alpha1.GreptimeDBBackup{}, &v1alpha1.GreptimeDBCluster{}, &batchv1.Job{}).
+			Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(100),
+		Clock:    clocktesting.NewFakePassiveClock(testCreationTime.Add(time.Minute)),
+	}
+}
+
+func newCluster(phase v1alpha1.Phase) *v1alpha1.GreptimeDBCluster {
+	return &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			Frontend:              &v1alpha1.FrontendSpec{},
+			MySQLPort:             4002,
+			ObjectStorageProvider: &v1alpha1.ObjectStorageProviderSpec{S3: &v1alpha1.S3Storage{Bucket: "bucket"}},
+		},
+		Status: v1alpha1.GreptimeDBClusterStatus{ClusterPhase: phase},
+	}
+}
+
+func newBackup(deletionPolicy v1alpha1.BackupDeletionPolicy) *v1alpha1.GreptimeDBBackup {
+	return &v1alpha1.GreptimeDBBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default", UID: "uid-backup", CreationTimestamp: metav1.NewTime(testCreationTime)},
+		Spec: v1alpha1.GreptimeDBBackupSpec{
+			ClusterName:    "test",
+			DeletionPolicy: deletionPolicy,
+		},
+	}
+}
+
+func reconcile(t *testing.T, r *Reconciler, backup *v1alpha1.GreptimeDBBackup) {
+	t.Helper()
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(backup)}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func reconcileUntilFinished(t *testing.T, r *Reconciler, backup *v1alpha1.GreptimeDBBackup) {
+	t.Helper()
+	for i := 0; i < 20; i++ {
+		reconcile(t, r, backup)
+		if getBackup(t, r, backup).IsFinished() {
+			return
+		}
+	}
+	t.Fatalf("the backup is not finished")
+}
+
+func getBackup(t *testing.T, r *Reconciler, backup *v1alpha1.GreptimeDBBackup) *v1alpha1.GreptimeDBBackup {
+	t.Helper()
+	got := new(v1alpha1.GreptimeDBBackup)
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(backup), got); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func hasEvent(recorder *record.FakeRecorder, reason string) bool {
+	for {
+		select {
+		case event := <-recorder.Events:
+			if strings.Contains(event, " "+reason+" ") {
+				return true
+			}
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbbackup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+)
+
+// systemDatabases are the databases that will not be exported by default.
+var systemDatabases = map[string]bool{
+	"information_schema": true,
+	"greptime_private":   true,
+	"pg_catalog":         true,
+}
+
+// ExportResult is the result of exporting a database.
+type ExportResult struct {
+	// Bytes is the total size of the exported tables.
+	Bytes int64
+
+	// Tables is the number of the exported tables.
+	Tables int
+}
+
+// Exporter exports the databases of the cluster to the object storage.
+type Exporter interface {
+	// ListDatabases returns all the databases except the system databases.
+	ListDatabases(ctx context.Context, conn *common.MySQLConnection) ([]string, error)
+
+	// ExportDatabase exports the data and the table schemas of the database to the location.
+	ExportDatabase(ctx context.Context, conn *common.MySQLConnection, database string, location *common.CopyLocation, format v1alpha1.BackupFormat) (*ExportResult, error)
+}
+
+type mysqlExporter struct{}
+
+var _ Exporter = &mysqlExporter{}
+
+func (e *mysqlExporter) ListDatabases(ctx context.Context, conn *common.MySQLConnection) ([]string, error) {
+	db, err := conn.Open("")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, "SHOW DATABASES")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var databases []string
+	for rows.Next() {
+		var database string
+		if err := rows.Scan(&database); err != nil {
+			return nil, err
+		}
+		if !systemDatabases[database] {
+			databases = append(databases, database)
+		}
+	}
+
+	return databases, rows.Err()
+}
+
+func (e *mysqlExporter) ExportDatabase(ctx context.Context, conn *common.MySQLConnection, database string, location *common.CopyLocation, format v1alpha1.BackupFormat) (*ExportResult, error) {
+	db, err := conn.Open(database)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var size sql.NullInt64
+	if err := db.QueryRowContext(ctx, "SELECT SUM(data_length) FROM information_schema.tables WHERE table_schema = ?", database).Scan(&size); err != nil {
+		return nil, err
+	}
+
+	schemas, err := e.tableSchemas(ctx, db, database)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.ExecContext(ctx, common.CopyDatabaseToSQL(database, location, format)); err != nil {
+		return nil, err
+	}
+
+	// `COPY DATABASE ... FROM` only imports the data into the existing tables, so the schemas are saved next to the data for the restore.
+	if _, err := db.ExecContext(ctx, common.CopySchemasToSQL(database, schemas, location)); err != nil {
+		return nil, fmt.Errorf("save table schemas failed: %v", err)
+	}
+
+	return &ExportResult{Bytes: size.Int64, Tables: len(schemas)}, nil
+}
+
+// tableSchemas returns the `CREATE TABLE` statements of all the tables in the database.
+func (e *mysqlExporter) tableSchemas(ctx context.Context, db *sql.DB, database string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT table_name FROM information_schema.tables WHERE table_schema = ? AND table_type = 'BASE TABLE'", database)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var schemas []string
+	for _, table := range tables {
+		var name, schema string
+		query := fmt.Sprintf("SHOW CREATE TABLE %s", common.QuoteIdentifier(table))
+		if err := db.QueryRowContext(ctx, query).Scan(&name, &schema); err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+
+	return schemas, nil
+}
@@ -0,0 +1,315 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbbackupschedule
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/cmd/operator/app/options"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/constant"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/util/cron"
+)
+
+// maxMissedSchedules is the maximum number of the schedule times to iterate in one reconciliation,
+// for example, the operator is down for a long time and the schedule has missed many times.
+const maxMissedSchedules = 10000
+
+var (
+	defaultRequeueAfter = 10 * time.Second
+)
+
+// Reconciler reconciles a GreptimeDBBackupSchedule object.
+type Reconciler struct {
+	client.Client
+
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Clock    clock.PassiveClock
+}
+
+func Setup(mgr ctrl.Manager, _ *options.Options) error {
+	reconciler := &Reconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("greptimedbbackupschedule-controller"),
+		Clock:    clock.RealClock{},
+	}
+	return reconciler.SetupWithManager(mgr)
+}
+
+// +kubebuilder:rbac:groups=greptime.io,resources=greptimedbbackupschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=greptime.io,resources=greptimedbbackupschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=greptime.io,resources=greptimedbbackups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch;
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	klog.V(2).Infof("Reconciling GreptimeDBBackupSchedule: %s", req.NamespacedName)
+
+	var err error
+	schedule := new(v1alpha1.GreptimeDBBackupSchedule)
+	if err := r.Get(ctx, req.NamespacedName, schedule); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// The backups of the schedule will be deleted by the garbage collector.
+	if !schedule.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	defer func() {
+		if err != nil {
+			r.Recorder.Event(schedule, corev1.EventTypeWarning, "ReconcileError", fmt.Sprintf("Reconcile error: %v", err))
+		}
+	}()
+
+	if err = schedule.Validate(); err != nil {
+		r.Recorder.Event(schedule, corev1.EventTypeWarning, "InvalidBackupSchedule", fmt.Sprintf("Invalid backup schedule: %v", err))
+		return ctrl.Result{}, nil
+	}
+
+	if err = schedule.Check(ctx, r.Client); err != nil {
+		r.Recorder.Event(schedule, corev1.EventTypeWarning, "InvalidBackupSchedule", fmt.Sprintf("Invalid backup schedule: %v", err))
+		return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+	}
+
+	originalObject := schedule.DeepCopy()
+	if err = schedule.SetDefaults(); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !cmp.Equal(originalObject.Spec, schedule.Spec) {
+		// Update the default values to the schedule spec if it is not set.
+		if err = r.Update(ctx, schedule); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	var backups v1alpha1.GreptimeDBBackupList
+	if err = r.List(ctx, &backups, client.InNamespace(schedule.Namespace), client.MatchingLabels{constant.GreptimeDBBackupScheduleLabelKey: schedule.Name}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.syncHistory(schedule, backups.Items)
+
+	if err = r.cleanExpiredBackups(ctx, schedule, backups.Items); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	result, err := r.schedule(ctx, schedule, backups.Items)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err = r.updateStatus(ctx, schedule); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return result, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.GreptimeDBBackupSchedule{}).
+		Owns(&v1alpha1.GreptimeDBBackup{}).
+		Complete(r)
+}
+
+// syncHistory records the completion time of the last successful and failed backups in the status.
+func (r *Reconciler) syncHistory(schedule *v1alpha1.GreptimeDBBackupSchedule, backups []v1alpha1.GreptimeDBBackup) {
+	for i := range backups {
+		backup := &backups[i]
+		completionTime := backup.Status.CompletionTime
+		if completionTime == nil {
+			continue
+		}
+
+		switch backup.Status.BackupPhase {
+		case v1alpha1.BackupPhaseCompleted:
+			if last := schedule.Status.LastSuccessfulTime; last == nil || last.Before(completionTime) {
+				schedule.Status.LastSuccessfulTime = completionTime.DeepCopy()
+				r.Recorder.Event(schedule, corev1.EventTypeNormal, "BackupSucceeded", fmt.Sprintf("Backup '%s' is completed", backup.Name))
+			}
+		case v1alpha1.BackupPhaseFailed:
+			if last := schedule.Status.LastFailureTime; last == nil || last.Before(completionTime) {
+				schedule.Status.LastFailureTime = completionTime.DeepCopy()
+				r.Recorder.Event(schedule, corev1.EventTypeWarning, "BackupFailed", fmt.Sprintf("Backup '%s' is failed: %s", backup.Name, backup.Status.Message))
+			}
+		}
+	}
+}
+
+// cleanExpiredBackups deletes the backups that exceed the retention policy.
+// The backup data will be deleted from the object storage by the backup controller according to the deletion policy of the backup.
+func (r *Reconciler) cleanExpiredBackups(ctx context.Context, schedule *v1alpha1.GreptimeDBBackupSchedule, backups []v1alpha1.GreptimeDBBackup) error {
+	for _, backup := range expiredBackups(backups, schedule.GetRetention(), r.Clock.Now()) {
+		if !backup.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if err := r.Delete(ctx, &backup, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+
+		klog.Infof("Delete expired backup '%s/%s' of schedule '%s'", backup.Namespace, backup.Name, schedule.Name)
+		r.Recorder.Event(schedule, corev1.EventTypeNormal, "BackupExpired", fmt.Sprintf("Backup '%s' is expired and deleted", backup.Name))
+	}
+
+	return nil
+}
+
+// schedule creates the backup for the most recent missed schedule time and returns when to reconcile the schedule again.
+// If the last backup is still pending or running, the schedule time will be skipped.
+// The pending backup is failed after its pending timeout, so it doesn't block the schedule forever.
+func (r *Reconciler) schedule(ctx context.Context, schedule *v1alpha1.GreptimeDBBackupSchedule, backups []v1alpha1.GreptimeDBBackup) (ctrl.Result, error) {
+	sched, err := cron.Parse(schedule.Spec.Schedule)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := r.Clock.Now()
+	if schedule.Spec.TimeZone != "" {
+		location, err := time.LoadLocation(schedule.Spec.TimeZone)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		now = now.In(location)
+	}
+
+	earliest := schedule.CreationTimestamp.Time
+	if last := schedule.Status.LastScheduleTime; last != nil {
+		earliest = last.Time
+	}
+
+	next := sched.Next(now)
+	if next.IsZero() {
+		schedule.Status.NextScheduleTime = nil
+	} else {
+		schedule.Status.NextScheduleTime = &metav1.Time{Time: next}
+	}
+
+	scheduledTime := mostRecentScheduleTime(sched, earliest.In(now.Location()), now)
+	if scheduledTime.IsZero() || schedule.Spec.Suspend {
+		return requeueUntil(now, next), nil
+	}
+
+	schedule.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+
+	for _, backup := range backups {
+		if !backup.IsFinished() && backup.DeletionTimestamp.IsZero() {
+			r.Recorder.Event(schedule, corev1.EventTypeWarning, "BackupSkipped", fmt.Sprintf("Skip the backup at %s because backup '%s' is still running", scheduledTime.Format(time.RFC3339), backup.Name))
+			return requeueUntil(now, next), nil
+		}
+	}
+
+	backup, err := r.buildBackup(schedule, scheduledTime)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.Create(ctx, backup); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return ctrl.Result{}, err
+	}
+
+	schedule.Status.LastBackupName = backup.Name
+	r.Recorder.Event(schedule, corev1.EventTypeNormal, "BackupCreated", fmt.Sprintf("Create backup '%s' for the schedule at %s", backup.Name, scheduledTime.Format(time.RFC3339)))
+
+	return requeueUntil(now, next), nil
+}
+
+func (r *Reconciler) buildBackup(schedule *v1alpha1.GreptimeDBBackupSchedule, scheduledTime time.Time) (*v1alpha1.GreptimeDBBackup, error) {
+	// Use the scheduled time in minutes as the suffix, so the same schedule time always has the same backup name.
+	name := fmt.Sprintf("%s-%d", schedule.Name, scheduledTime.Unix()/60)
+
+	backup := &v1alpha1.GreptimeDBBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: schedule.Namespace,
+			Labels: map[string]string{
+				constant.GreptimeDBBackupScheduleLabelKey: schedule.Name,
+			},
+		},
+		Spec: *schedule.Spec.BackupTemplate.DeepCopy(),
+	}
+
+	// Every backup has its own directory, so the expired backup data can be deleted independently.
+	if backup.Spec.Prefix != "" {
+		backup.Spec.Prefix = path.Join(backup.Spec.Prefix, name)
+	}
+
+	if err := controllerutil.SetControllerReference(schedule, backup, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	return backup, nil
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, schedule *v1alpha1.GreptimeDBBackupSchedule) error {
+	schedule.Status.ObservedGeneration = schedule.Generation
+	return UpdateStatus(ctx, schedule, r.Client)
+}
+
+func UpdateStatus(ctx context.Context, input *v1alpha1.GreptimeDBBackupSchedule, kc client.Client, opts ...client.SubResourceUpdateOption) error {
+	schedule := input.DeepCopy()
+	status := schedule.Status
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
+		objectKey := client.ObjectKey{Namespace: schedule.Namespace, Name: schedule.Name}
+		if err = kc.Get(ctx, objectKey, schedule); err != nil {
+			return
+		}
+		schedule.Status = status
+		return kc.Status().Update(ctx, schedule, opts...)
+	})
+}
+
+func requeueUntil(now, next time.Time) ctrl.Result {
+	if next.IsZero() {
+		return ctrl.Result{}
+	}
+	// Add one second to make sure the next schedule time has passed when reconciling.
+	return ctrl.Result{RequeueAfter: next.Sub(now) + time.Second}
+}
+
+// mostRecentScheduleTime returns the latest schedule time that is in (earliest, now].
+// It returns zero time if there is no schedule time in the range.
+// If there are too many missed schedule times, it returns the last iterated one and the rest will be caught up later.
+func mostRecentScheduleTime(sched cron.Schedule, earliest, now time.Time) time.Time {
+	var mostRecent time.Time
+	for t, i := sched.Next(earliest), 0; !t.IsZero() && !t.After(now) && i < maxMissedSchedules; t, i = sched.Next(t), i+1 {
+		mostRecent = t
+	}
+	return mostRecent
+}
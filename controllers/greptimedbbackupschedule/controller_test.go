@@ -0,0 +1,351 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbbackupschedule
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/constant"
+)
+
+// testCreationTime is the creation time of the schedule in the tests.
+var testCreationTime = time.Date(2026, time.March, 7, 12, 0, 0, 0, time.UTC)
+
+func TestSchedule(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, time.March, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		schedule func(*v1alpha1.GreptimeDBBackupSchedule)
+		backups  []*v1alpha1.GreptimeDBBackup
+		now      time.Time
+
+		// created is the schedule time of the created backup, zero means no backup is created.
+		created      time.Time
+		lastSchedule time.Time
+		nextSchedule time.Time
+	}{
+		{
+			name:         "before the first schedule time",
+			now:          at(8, 1),
+			nextSchedule: at(8, 2),
+		},
+		{
+			name:         "at the first schedule time",
+			now:          at(8, 2).Add(30 * time.Second),
+			created:      at(8, 2),
+			lastSchedule: at(8, 2),
+			nextSchedule: at(9, 2),
+		},
+		{
+			name:         "catch up the most recent missed schedule time",
+			now:          at(10, 3),
+			created:      at(10, 2),
+			lastSchedule: at(10, 2),
+			nextSchedule: at(11, 2),
+		},
+		{
+			name: "after the last schedule time",
+			schedule: func(schedule *v1alpha1.GreptimeDBBackupSchedule) {
+				schedule.Status.LastScheduleTime = &metav1.Time{Time: at(10, 2)}
+			},
+			now:          at(10, 3),
+			lastSchedule: at(10, 2),
+			nextSchedule: at(11, 2),
+		},
+		{
+			name: "suspended",
+			schedule: func(schedule *v1alpha1.GreptimeDBBackupSchedule) {
+				schedule.Spec.Suspend = true
+			},
+			now:          at(10, 3),
+			nextSchedule: at(11, 2),
+		},
+		{
+			name: "skip while the last backup is pending",
+			backups: []*v1alpha1.GreptimeDBBackup{
+				newScheduledBackup("pending", v1alpha1.BackupPhasePending),
+			},
+			now:          at(8, 3),
+			lastSchedule: at(8, 2),
+			nextSchedule: at(9, 2),
+		},
+		{
+			name: "skip while the last backup is running",
+			backups: []*v1alpha1.GreptimeDBBackup{
+				newScheduledBackup("running", v1alpha1.BackupPhaseRunning),
+			},
+			now:          at(8, 3),
+			lastSchedule: at(8, 2),
+			nextSchedule: at(9, 2),
+		},
+		{
+			name: "not blocked by the finished backups",
+			backups: []*v1alpha1.GreptimeDBBackup{
+				newScheduledBackup("completed", v1alpha1.BackupPhaseCompleted),
+				newScheduledBackup("failed", v1alpha1.BackupPhaseFailed),
+			},
+			now:          at(8, 3),
+			created:      at(8, 2),
+			lastSchedule: at(8, 2),
+			nextSchedule: at(9, 2),
+		},
+		{
+			// 02:00 in Asia/Shanghai is 18:00 of the previous day in UTC.
+			name: "time zone",
+			schedule: func(schedule *v1alpha1.GreptimeDBBackupSchedule) {
+				schedule.Spec.TimeZone = "Asia/Shanghai"
+			},
+			now:          at(8, 19),
+			created:      at(8, 18),
+			lastSchedule: at(8, 18),
+			nextSchedule: at(9, 18),
+		},
+		{
+			name: "time zone prefix",
+			schedule: func(schedule *v1alpha1.GreptimeDBBackupSchedule) {
+				schedule.Spec.Schedule = "CRON_TZ=Asia/Shanghai 0 2 * * *"
+			},
+			now:          at(8, 17),
+			created:      at(7, 18),
+			lastSchedule: at(7, 18),
+			nextSchedule: at(8, 18),
+		},
+		{
+			name: "descriptor",
+			schedule: func(schedule *v1alpha1.GreptimeDBBackupSchedule) {
+				schedule.Spec.Schedule = "@hourly"
+			},
+			now:          at(7, 13).Add(30 * time.Minute),
+			created:      at(7, 13),
+			lastSchedule: at(7, 13),
+			nextSchedule: at(7, 14),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := newSchedule()
+			if tt.schedule != nil {
+				tt.schedule(schedule)
+			}
+
+			objs := []client.Object{schedule}
+			for _, backup := range tt.backups {
+				objs = append(objs, backup)
+			}
+			r := newTestReconciler(tt.now, objs...)
+
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(schedule)})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := getSchedule(t, r, schedule)
+			if !timeEqual(got.Status.LastScheduleTime, tt.lastSchedule) {
+				t.Errorf("unexpected last schedule time, want: %v, got: %v", tt.lastSchedule, got.Status.LastScheduleTime)
+			}
+			if !timeEqual(got.Status.NextScheduleTime, tt.nextSchedule) {
+				t.Errorf("unexpected next schedule time, want: %v, got: %v", tt.nextSchedule, got.Status.NextScheduleTime)
+			}
+
+			// The schedule is reconciled again right after the next schedule time.
+			if want := tt.nextSchedule.Sub(tt.now) + time.Second; result.RequeueAfter != want {
+				t.Errorf("unexpected requeue after, want: %v, got: %v", want, result.RequeueAfter)
+			}
+
+			created := createdBackups(t, r, tt.backups)
+			if tt.created.IsZero() {
+				if len(created) != 0 {
+					t.Fatalf("expected no backup to be created, got: %v", created)
+				}
+				return
+			}
+
+			name := fmt.Sprintf("schedule-%d", tt.created.Unix()/60)
+			if len(created) != 1 || created[0] != name {
+				t.Fatalf("expected backup '%s' to be created, got: %v", name, created)
+			}
+			if got.Status.LastBackupName != name {
+				t.Errorf("unexpected last backup name: %s", got.Status.LastBackupName)
+			}
+
+			backup := new(v1alpha1.GreptimeDBBackup)
+			if err := r.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, backup); err != nil {
+				t.Fatal(err)
+			}
+			if owner := metav1.GetControllerOf(backup); owner == nil || owner.Name != "schedule" {
+				t.Errorf("expected the backup to be owned by the schedule, got: %v", owner)
+			}
+			if backup.Spec.ClusterName != "test" || backup.Spec.DeletionPolicy != v1alpha1.BackupDeletionPolicyDelete {
+				t.Errorf("expected the backup to be created from the defaulted template, got: %+v", backup.Spec)
+			}
+		})
+	}
+}
+
+// TestScheduleRequeue reconciles the schedule at the requeue times and checks that every schedule time creates one backup.
+func TestScheduleRequeue(t *testing.T) {
+	schedule := newSchedule()
+	schedule.Spec.Schedule = "0 */6 * * *"
+	r := newTestReconciler(testCreationTime)
+	if err := r.Create(context.Background(), schedule); err != nil {
+		t.Fatal(err)
+	}
+	clock := r.Clock.(*clocktesting.FakePassiveClock)
+
+	for i := 0; i < 4; i++ {
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(schedule)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		clock.SetTime(clock.Now().Add(result.RequeueAfter))
+
+		// The backups are finished before the next schedule time.
+		for _, name := range createdBackups(t, r, nil) {
+			backup := new(v1alpha1.GreptimeDBBackup)
+			if err := r.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, backup); err != nil {
+				t.Fatal(err)
+			}
+			if backup.Status.BackupPhase != v1alpha1.BackupPhaseCompleted {
+				backup.Status.BackupPhase = v1alpha1.BackupPhaseCompleted
+				backup.Status.CompletionTime = &metav1.Time{Time: clock.Now()}
+				if err := r.Status().Update(context.Background(), backup); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+
+	// The schedule is created at 12:00, so the backups are created at 18:00, 00:00 and 06:00.
+	var want []string
+	for _, scheduledTime := range []time.Time{testCreationTime.Add(6 * time.Hour), testCreationTime.Add(12 * time.Hour), testCreationTime.Add(18 * time.Hour)} {
+		want = append(want, fmt.Sprintf("schedule-%d", scheduledTime.Unix()/60))
+	}
+	sort.Strings(want)
+
+	if got := createdBackups(t, r, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected backups, want: %v, got: %v", want, got)
+	}
+
+	got := getSchedule(t, r, schedule)
+	if got.Status.LastSuccessfulTime == nil {
+		t.Errorf("expected the last successful time to be recorded")
+	}
+}
+
+func newSchedule() *v1alpha1.GreptimeDBBackupSchedule {
+	return &v1alpha1.GreptimeDBBackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "schedule", Namespace: "default", UID: "uid-schedule", CreationTimestamp: metav1.NewTime(testCreationTime)},
+		Spec: v1alpha1.GreptimeDBBackupScheduleSpec{
+			Schedule:       "0 2 * * *",
+			BackupTemplate: v1alpha1.GreptimeDBBackupSpec{ClusterName: "test"},
+		},
+	}
+}
+
+func newScheduledBackup(name string, phase v1alpha1.BackupPhase) *v1alpha1.GreptimeDBBackup {
+	backup := &v1alpha1.GreptimeDBBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{constant.GreptimeDBBackupScheduleLabelKey: "schedule"},
+		},
+		Spec:   v1alpha1.GreptimeDBBackupSpec{ClusterName: "test"},
+		Status: v1alpha1.GreptimeDBBackupStatus{BackupPhase: phase},
+	}
+	if backup.IsFinished() {
+		backup.Status.CompletionTime = &metav1.Time{Time: testCreationTime}
+	}
+	return backup
+}
+
+// newTestReconciler returns a Reconciler backed by a fake client that is seeded with objs, and its clock is at now.
+func newTestReconciler(now time.Time, objs ...client.Object) *Reconciler {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+
+	return &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&v1alpha1.GreptimeDBBackupSchedule{}, &v1alpha1.GreptimeDBBackup{}).
+			Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(100),
+		Clock:    clocktesting.NewFakePassiveClock(now),
+	}
+}
+
+func getSchedule(t *testing.T, r *Reconciler, schedule *v1alpha1.GreptimeDBBackupSchedule) *v1alpha1.GreptimeDBBackupSchedule {
+	t.Helper()
+	got := new(v1alpha1.GreptimeDBBackupSchedule)
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(schedule), got); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+// createdBackups returns the sorted names of the backups that are not in the existing ones.
+func createdBackups(t *testing.T, r *Reconciler, existing []*v1alpha1.GreptimeDBBackup) []string {
+	t.Helper()
+	var backups v1alpha1.GreptimeDBBackupList
+	if err := r.List(context.Background(), &backups, client.InNamespace("default")); err != nil {
+		t.Fatal(err)
+	}
+
+	var created []string
+	for _, backup := range backups.Items {
+		isExisting := false
+		for _, e := range existing {
+			if e.Name == backup.Name {
+				isExisting = true
+			}
+		}
+		if !isExisting {
+			created = append(created, backup.Name)
+		}
+	}
+	sort.Strings(created)
+	return created
+}
+
+func timeEqual(got *metav1.Time, want time.Time) bool {
+	if got == nil {
+		return want.IsZero()
+	}
+	return got.Time.Equal(want)
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbbackupschedule
+
+import (
+	"sort"
+	"time"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+)
+
+// expiredBackups returns the finished backups that exceed the retention policy:
+//
+//   - The completed backups that exceed the count or the max age. The latest completed backup is always kept.
+//   - The failed backups that finished before the latest completed backup.
+func expiredBackups(backups []v1alpha1.GreptimeDBBackup, retention *v1alpha1.BackupRetentionSpec, now time.Time) []v1alpha1.GreptimeDBBackup {
+	if retention == nil {
+		return nil
+	}
+
+	var completed, failed []v1alpha1.GreptimeDBBackup
+	for _, backup := range backups {
+		if backup.Status.CompletionTime == nil {
+			continue
+		}
+		switch backup.Status.BackupPhase {
+		case v1alpha1.BackupPhaseCompleted:
+			completed = append(completed, backup)
+		case v1alpha1.BackupPhaseFailed:
+			failed = append(failed, backup)
+		}
+	}
+
+	if len(completed) == 0 {
+		return nil
+	}
+
+	// Sort the completed backups from the newest to the oldest.
+	sort.Slice(completed, func(i, j int) bool {
+		return completed[j].Status.CompletionTime.Before(completed[i].Status.CompletionTime)
+	})
+
+	var expired []v1alpha1.GreptimeDBBackup
+	for i, backup := range completed {
+		if i == 0 {
+			continue
+		}
+
+		if count := retention.GetCount(); count != nil && i >= int(*count) {
+			expired = append(expired, backup)
+			continue
+		}
+
+		if maxAge := retention.GetMaxAge(); maxAge != nil && now.Sub(backup.Status.CompletionTime.Time) > maxAge.Duration {
+			expired = append(expired, backup)
+		}
+	}
+
+	latest := completed[0].Status.CompletionTime
+	for _, backup := range failed {
+		if backup.Status.CompletionTime.Before(latest) {
+			expired = append(expired, backup)
+		}
+	}
+
+	return expired
+}
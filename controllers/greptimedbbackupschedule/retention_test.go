@@ -0,0 +1,114 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbbackupschedule
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/util/cron"
+)
+
+func TestExpiredBackups(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 3, 0, 0, 0, time.UTC)
+
+	newBackup := func(name string, phase v1alpha1.BackupPhase, daysAgo int) v1alpha1.GreptimeDBBackup {
+		backup := v1alpha1.GreptimeDBBackup{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		backup.Status.BackupPhase = phase
+		if phase == v1alpha1.BackupPhaseCompleted || phase == v1alpha1.BackupPhaseFailed {
+			backup.Status.CompletionTime = &metav1.Time{Time: now.AddDate(0, 0, -daysAgo)}
+		}
+		return backup
+	}
+
+	backups := []v1alpha1.GreptimeDBBackup{
+		newBackup("day-1", v1alpha1.BackupPhaseCompleted, 1),
+		newBackup("day-2", v1alpha1.BackupPhaseFailed, 2),
+		newBackup("day-3", v1alpha1.BackupPhaseCompleted, 3),
+		newBackup("day-5", v1alpha1.BackupPhaseCompleted, 5),
+		newBackup("day-9", v1alpha1.BackupPhaseCompleted, 9),
+		newBackup("running", v1alpha1.BackupPhaseRunning, 0),
+	}
+
+	tests := []struct {
+		name      string
+		backups   []v1alpha1.GreptimeDBBackup
+		retention *v1alpha1.BackupRetentionSpec
+		expired   []string
+	}{
+		{
+			name:      "no retention",
+			backups:   backups,
+			retention: nil,
+			expired:   nil,
+		},
+		{
+			name:      "retention by count",
+			backups:   backups,
+			retention: &v1alpha1.BackupRetentionSpec{Count: ptr.To(int32(2))},
+			expired:   []string{"day-2", "day-5", "day-9"},
+		},
+		{
+			name:      "retention by max age",
+			backups:   backups,
+			retention: &v1alpha1.BackupRetentionSpec{MaxAge: &metav1.Duration{Duration: 4 * 24 * time.Hour}},
+			expired:   []string{"day-2", "day-5", "day-9"},
+		},
+		{
+			name:      "keep the latest completed backup",
+			backups:   backups[3:],
+			retention: &v1alpha1.BackupRetentionSpec{MaxAge: &metav1.Duration{Duration: time.Hour}},
+			expired:   []string{"day-9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var expired []string
+			for _, backup := range expiredBackups(tt.backups, tt.retention, now) {
+				expired = append(expired, backup.Name)
+			}
+			sort.Strings(expired)
+
+			if !reflect.DeepEqual(expired, tt.expired) {
+				t.Errorf("unexpected expired backups, want: %v, got: %v", tt.expired, expired)
+			}
+		})
+	}
+}
+
+func TestMostRecentScheduleTime(t *testing.T) {
+	sched, err := cron.Parse("0 2 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	earliest := time.Date(2026, time.March, 7, 12, 0, 0, 0, time.UTC)
+
+	if got := mostRecentScheduleTime(sched, earliest, time.Date(2026, time.March, 8, 1, 0, 0, 0, time.UTC)); !got.IsZero() {
+		t.Errorf("expected no schedule time, got: %v", got)
+	}
+
+	want := time.Date(2026, time.March, 10, 2, 0, 0, 0, time.UTC)
+	if got := mostRecentScheduleTime(sched, earliest, time.Date(2026, time.March, 10, 3, 0, 0, 0, time.UTC)); !got.Equal(want) {
+		t.Errorf("unexpected schedule time, want: %v, got: %v", want, got)
+	}
+}
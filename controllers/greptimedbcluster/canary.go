@@ -0,0 +1,497 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/constant"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/greptimedbcluster/deployers"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+	k8sutils "github.com/GreptimeTeam/greptimedb-operator/pkg/util/k8s"
+)
+
+const (
+	// canaryCheckInterval is the interval to run the health checks during the soak.
+	canaryCheckInterval = 30 * time.Second
+
+	// canaryCheckTimeout is the timeout of every health check.
+	canaryCheckTimeout = 10 * time.Second
+)
+
+// hasCanaryInProgress returns true if any canary rollout is progressing or soaking.
+// The partitioned StatefulSets are not ready during the canary rollout, but the rollout is still making progress.
+func hasCanaryInProgress(cluster *v1alpha1.GreptimeDBCluster) bool {
+	for i := range cluster.Status.Canaries {
+		if cluster.Status.Canaries[i].IsInProgress() {
+			return true
+		}
+	}
+	return false
+}
+
+// restartProgressDeadline restarts the progress deadline of the cluster when the rest pods start to roll out after the canary steps,
+// so the time spent on the soaks is not counted.
+func restartProgressDeadline(cluster *v1alpha1.GreptimeDBCluster) {
+	for i := range cluster.Status.Conditions {
+		condition := &cluster.Status.Conditions[i]
+		if condition.Type == v1alpha1.ConditionTypeProgressing && condition.Status == corev1.ConditionTrue {
+			condition.LastTransitionTime = metav1.Now()
+		}
+	}
+}
+
+// canaryTarget is the generated StatefulSet with the canary policy.
+type canaryTarget struct {
+	canary *v1alpha1.CanarySpec
+	sts    *appsv1.StatefulSet
+}
+
+// syncCanaries drives the canary rollouts of the StatefulSets before they are applied.
+// It returns the duration after which the canary rollouts should be checked again.
+func (r *Reconciler) syncCanaries(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) (time.Duration, error) {
+	targets, err := r.canaryTargets(cluster)
+	if err != nil {
+		return 0, err
+	}
+
+	var requeueAfter time.Duration
+	for _, target := range targets {
+		after, err := r.syncCanary(ctx, cluster, target)
+		if err != nil {
+			return 0, err
+		}
+
+		// The canary rollout is aborted, and the cluster is rolled back.
+		if cluster.Status.RolledBackGeneration == cluster.Generation {
+			return 0, nil
+		}
+
+		requeueAfter = minRequeueAfter(requeueAfter, after)
+	}
+
+	return requeueAfter, nil
+}
+
+func (r *Reconciler) canaryTargets(cluster *v1alpha1.GreptimeDBCluster) ([]canaryTarget, error) {
+	canaries := make(map[string]*v1alpha1.CanarySpec)
+	if canary := cluster.GetDatanode().GetCanary(); canary != nil {
+		canaries[common.ResourceName(cluster.Name, v1alpha1.DatanodeRoleKind)] = canary
+	}
+	for _, group := range cluster.GetDatanodeGroups() {
+		if canary := group.GetCanary(); canary != nil {
+			canaries[common.ResourceName(cluster.Name, v1alpha1.DatanodeRoleKind, group.GetName())] = canary
+		}
+	}
+	if canary := cluster.GetFlownode().GetCanary(); canary != nil {
+		canaries[common.ResourceName(cluster.Name, v1alpha1.FlownodeRoleKind)] = canary
+	}
+	for _, group := range cluster.GetFlownodeGroups() {
+		if canary := group.GetCanary(); canary != nil {
+			canaries[common.ResourceName(cluster.Name, v1alpha1.FlownodeRoleKind, group.GetName())] = canary
+		}
+	}
+
+	if len(canaries) == 0 {
+		return nil, nil
+	}
+
+	var targets []canaryTarget
+	for _, d := range r.Deployers {
+		if kind, ok := componentKind(d); !ok || (kind != v1alpha1.DatanodeRoleKind && kind != v1alpha1.FlownodeRoleKind) {
+			continue
+		}
+
+		objects, err := d.Generate(cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range objects {
+			if sts, ok := object.(*appsv1.StatefulSet); ok && canaries[sts.Name] != nil {
+				targets = append(targets, canaryTarget{canary: canaries[sts.Name], sts: sts})
+			}
+		}
+	}
+
+	return targets, nil
+}
+
+func (r *Reconciler) syncCanary(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, target canaryTarget) (time.Duration, error) {
+	live := new(appsv1.StatefulSet)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(target.sts), live); err != nil {
+		// The StatefulSet is just created, all the pods will be created with the new pod template.
+		return 0, client.IgnoreNotFound(err)
+	}
+
+	liveRevision, err := appliedCanaryRevision(live)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		revision = deployers.CanaryRevision(&target.sts.Spec.Template)
+		status   = cluster.Status.GetCanary(target.sts.Name)
+	)
+
+	if status == nil || status.Revision != revision {
+		// The pod template is not changed.
+		if liveRevision == revision {
+			return 0, nil
+		}
+		return 0, r.startCanary(ctx, cluster, target, revision)
+	}
+
+	if !status.IsInProgress() {
+		return 0, nil
+	}
+
+	// Wait for the partition of the current step to be applied.
+	if liveRevision != revision || live.Status.ObservedGeneration != live.Generation {
+		return defaultRequeueAfter, nil
+	}
+
+	switch status.Phase {
+	case v1alpha1.CanaryPhaseProgressing:
+		if err := r.checkUpdatedPods(ctx, live, status); err != nil {
+			// The progress deadline of the cluster is not checked during the canary rollout, so it's applied to every step instead.
+			if deadline := cluster.GetRolloutPolicy().GetProgressDeadline(); deadline != nil && time.Since(status.StepStartTime.Time) > deadline.Duration {
+				return 0, r.abortCanary(ctx, cluster, live, status, fmt.Errorf("the pods are not ready in %s: %v", deadline.Duration, err))
+			}
+
+			klog.V(2).Infof("Waiting for the canary step %d of statefulset '%s/%s': %v", status.Step, live.Namespace, live.Name, err)
+			return defaultRequeueAfter, nil
+		}
+
+		status.Phase = v1alpha1.CanaryPhaseSoaking
+		status.StepStartTime = ptrNow()
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "CanaryStepUpdated",
+			fmt.Sprintf("Canary step %d of statefulset '%s' updated %d of %d pods, soaking for %s",
+				status.Step, live.Name, status.UpdatedReplicas, *live.Spec.Replicas, target.canary.GetSoakDuration()))
+
+		return canaryCheckInterval, deployers.UpdateStatus(ctx, cluster, r.Client)
+	case v1alpha1.CanaryPhaseSoaking:
+		if err := r.checkCanaryHealth(ctx, cluster, live, target.canary, status); err != nil {
+			return 0, r.abortCanary(ctx, cluster, live, status, err)
+		}
+
+		remaining := target.canary.GetSoakDuration() - time.Since(status.StepStartTime.Time)
+		if remaining > 0 {
+			return min(remaining, canaryCheckInterval), nil
+		}
+
+		return 0, r.nextCanaryStep(ctx, cluster, target, status)
+	}
+
+	return 0, nil
+}
+
+func (r *Reconciler) startCanary(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, target canaryTarget, revision string) error {
+	replicas := *target.sts.Spec.Replicas
+	status := v1alpha1.CanaryStatus{
+		StatefulSet:     target.sts.Name,
+		Revision:        revision,
+		Phase:           v1alpha1.CanaryPhaseProgressing,
+		Step:            0,
+		UpdatedReplicas: min(target.canary.Steps[0], replicas),
+		StepStartTime:   ptrNow(),
+	}
+	cluster.Status.SetCanary(status)
+
+	klog.Infof("Start the canary rollout of statefulset '%s/%s'", cluster.Namespace, target.sts.Name)
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "CanaryStarted",
+		fmt.Sprintf("Canary rollout of statefulset '%s' started, updating %d of %d pods", target.sts.Name, status.UpdatedReplicas, replicas))
+
+	return deployers.UpdateStatus(ctx, cluster, r.Client)
+}
+
+func (r *Reconciler) nextCanaryStep(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, target canaryTarget, status *v1alpha1.CanaryStatus) error {
+	var (
+		replicas = *target.sts.Spec.Replicas
+		next     = status.Step + 1
+	)
+
+	status.StepStartTime = ptrNow()
+
+	if int(next) < len(target.canary.Steps) && status.UpdatedReplicas < replicas {
+		status.Step = next
+		status.Phase = v1alpha1.CanaryPhaseProgressing
+		status.UpdatedReplicas = min(target.canary.Steps[next], replicas)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "CanaryStepPassed",
+			fmt.Sprintf("Canary step %d of statefulset '%s' passed, updating %d of %d pods", next-1, target.sts.Name, status.UpdatedReplicas, replicas))
+	} else {
+		status.Phase = v1alpha1.CanaryPhaseCompleted
+		status.UpdatedReplicas = replicas
+		restartProgressDeadline(cluster)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "CanaryCompleted",
+			fmt.Sprintf("All the canary steps of statefulset '%s' passed, updating the rest pods", target.sts.Name))
+	}
+
+	return deployers.UpdateStatus(ctx, cluster, r.Client)
+}
+
+// abortCanary rolls back the updated pods to the current revision of the StatefulSet,
+// and the failed spec will not be applied again until the cluster spec is changed.
+func (r *Reconciler) abortCanary(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, sts *appsv1.StatefulSet, status *v1alpha1.CanaryStatus, cause error) error {
+	message := fmt.Sprintf("canary step %d of statefulset '%s' failed: %v", status.Step, sts.Name, cause)
+	klog.Warningf("Abort the canary rollout of the cluster '%s/%s': %s", cluster.Namespace, cluster.Name, message)
+
+	if err := r.rollbackToCurrentRevision(ctx, sts); err != nil {
+		return err
+	}
+
+	status.Phase = v1alpha1.CanaryPhaseAborted
+	status.StepStartTime = ptrNow()
+	status.Message = cause.Error()
+
+	r.Recorder.Event(cluster, corev1.EventTypeWarning, "CanaryAborted", fmt.Sprintf("Canary aborted and rolled back: %s", message))
+
+	cluster.Status.RolledBackGeneration = cluster.Generation
+	cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeRolledBack, corev1.ConditionTrue, "CanaryAborted", message))
+
+	return deployers.UpdateStatus(ctx, cluster, r.Client)
+}
+
+// rollbackToCurrentRevision restores the pod template of the StatefulSet from the controller revision of the pods that are not updated.
+func (r *Reconciler) rollbackToCurrentRevision(ctx context.Context, sts *appsv1.StatefulSet) error {
+	if sts.Status.CurrentRevision == "" || sts.Status.CurrentRevision == sts.Status.UpdateRevision {
+		return nil
+	}
+
+	revision := new(appsv1.ControllerRevision)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: sts.Namespace, Name: sts.Status.CurrentRevision}, revision); err != nil {
+		return err
+	}
+
+	// The data of the controller revision is the patch of the pod template.
+	var patch struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(revision.Data.Raw, &patch); err != nil {
+		return err
+	}
+
+	patched := sts.DeepCopy()
+	patched.Spec.Template = patch.Spec.Template
+	patched.Spec.UpdateStrategy.RollingUpdate = nil
+
+	data, err := json.Marshal(patched.Spec)
+	if err != nil {
+		return err
+	}
+	patched.Annotations[deployer.LastAppliedResourceSpec] = string(data)
+
+	if err := r.Patch(ctx, patched, client.MergeFrom(sts)); err != nil {
+		return err
+	}
+
+	return r.deleteNotReadyPods(ctx, patched)
+}
+
+// checkUpdatedPods checks whether the pods of the current step are updated and ready.
+func (r *Reconciler) checkUpdatedPods(ctx context.Context, sts *appsv1.StatefulSet, status *v1alpha1.CanaryStatus) error {
+	pods, err := r.updatedPods(ctx, sts)
+	if err != nil {
+		return err
+	}
+
+	if int32(len(pods)) < status.UpdatedReplicas {
+		return fmt.Errorf("%d of %d pods are updated", len(pods), status.UpdatedReplicas)
+	}
+
+	for i := range pods {
+		if !k8sutils.IsPodReady(&pods[i]) {
+			return fmt.Errorf("the updated pod '%s' is not ready", pods[i].Name)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) checkCanaryHealth(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, sts *appsv1.StatefulSet, canary *v1alpha1.CanarySpec, status *v1alpha1.CanaryStatus) error {
+	if err := r.checkUpdatedPods(ctx, sts, status); err != nil {
+		return err
+	}
+
+	healthCheck := canary.GetHealthCheck()
+	if healthCheck == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, canaryCheckTimeout)
+	defer cancel()
+
+	if healthCheck.SQL != "" {
+		if err := r.checkCanarySQL(ctx, cluster, healthCheck); err != nil {
+			return fmt.Errorf("the sql check failed: %v", err)
+		}
+	}
+
+	if metrics := healthCheck.GetMetrics(); metrics != nil {
+		maxValue, err := strconv.ParseFloat(metrics.MaxValue, 64)
+		if err != nil {
+			return err
+		}
+
+		pods, err := r.updatedPods(ctx, sts)
+		if err != nil {
+			return err
+		}
+
+		for i := range pods {
+			url, err := podMetricsURL(&pods[i])
+			if err != nil {
+				return err
+			}
+
+			value, err := scrapeMetric(ctx, url, metrics.Name)
+			if err != nil {
+				return fmt.Errorf("failed to scrape the metrics of pod '%s': %v", pods[i].Name, err)
+			}
+
+			if value > maxValue {
+				return fmt.Errorf("the metric '%s' of pod '%s' is %v, which exceeds %v", metrics.Name, pods[i].Name, value, maxValue)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) checkCanarySQL(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, healthCheck *v1alpha1.CanaryHealthCheck) error {
+	conn, err := common.NewMySQLConnection(ctx, r.Client, cluster, healthCheck.CredentialsSecretName)
+	if err != nil {
+		return err
+	}
+
+	db, err := conn.Open("")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, healthCheck.SQL)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return rows.Err()
+}
+
+// updatedPods returns the pods of the update revision of the StatefulSet.
+func (r *Reconciler) updatedPods(ctx context.Context, sts *appsv1.StatefulSet) ([]corev1.Pod, error) {
+	pods := new(corev1.PodList)
+	if err := r.List(ctx, pods, client.InNamespace(sts.Namespace), client.MatchingLabels(sts.Spec.Selector.MatchLabels)); err != nil {
+		return nil, err
+	}
+
+	var updated []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Labels[appsv1.ControllerRevisionHashLabelKey] == sts.Status.UpdateRevision {
+			updated = append(updated, pod)
+		}
+	}
+
+	return updated, nil
+}
+
+// appliedCanaryRevision returns the canary revision of the pod template that is applied to the StatefulSet.
+func appliedCanaryRevision(sts *appsv1.StatefulSet) (string, error) {
+	data, ok := sts.Annotations[deployer.LastAppliedResourceSpec]
+	if !ok {
+		return "", nil
+	}
+
+	var spec appsv1.StatefulSetSpec
+	if err := json.Unmarshal([]byte(data), &spec); err != nil {
+		return "", err
+	}
+
+	return deployers.CanaryRevision(&spec.Template), nil
+}
+
+func podMetricsURL(pod *corev1.Pod) (string, error) {
+	if pod.Status.PodIP == "" {
+		return "", fmt.Errorf("the pod '%s' has no IP", pod.Name)
+	}
+
+	for _, port := range pod.Spec.Containers[constant.MainContainerIndex].Ports {
+		if port.Name == "http" {
+			return fmt.Sprintf("http://%s:%d/metrics", pod.Status.PodIP, port.ContainerPort), nil
+		}
+	}
+
+	return "", fmt.Errorf("the pod '%s' has no http port", pod.Name)
+}
+
+// scrapeMetric scrapes the metrics endpoint and returns the sum of the values of all the series of the metric.
+func scrapeMetric(ctx context.Context, url, name string) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	family, ok := families[name]
+	if !ok {
+		return 0, nil
+	}
+
+	var sum float64
+	for _, metric := range family.GetMetric() {
+		switch {
+		case metric.GetCounter() != nil:
+			sum += metric.GetCounter().GetValue()
+		case metric.GetGauge() != nil:
+			sum += metric.GetGauge().GetValue()
+		case metric.GetUntyped() != nil:
+			sum += metric.GetUntyped().GetValue()
+		}
+	}
+
+	return sum, nil
+}
@@ -0,0 +1,345 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+)
+
+func TestCanaryRollout(t *testing.T) {
+	// The metric of the updated pods breaches the threshold when errors is set.
+	var errors atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "# TYPE greptime_servers_error_count counter\ngreptime_servers_error_count %d\n", errors.Load())
+	}))
+	defer server.Close()
+
+	canary := &v1alpha1.CanarySpec{
+		Steps:        []int32{1, 2},
+		SoakDuration: &metav1.Duration{Duration: time.Hour},
+		HealthCheck: &v1alpha1.CanaryHealthCheck{
+			Metrics: &v1alpha1.CanaryMetricsCheck{Name: "greptime_servers_error_count", MaxValue: "0"},
+		},
+	}
+	f := newCanaryFixture(t, server, canary)
+	ctx := context.Background()
+
+	// Start: the first step updates one pod.
+	f.sync(ctx)
+	status := f.status()
+	if status.Phase != v1alpha1.CanaryPhaseProgressing || status.Step != 0 || status.UpdatedReplicas != 1 {
+		t.Fatalf("expected the canary to start with the first step, got: %+v", status)
+	}
+	if !hasCanaryInProgress(f.cluster) {
+		t.Errorf("expected the canary to be in progress")
+	}
+
+	// The partition of the step is not applied yet.
+	if after := f.sync(ctx); after != defaultRequeueAfter || f.status().Phase != v1alpha1.CanaryPhaseProgressing {
+		t.Fatalf("expected to wait for the partition to be applied, got: %v, %+v", after, f.status())
+	}
+
+	// The updated pod is ready, and the soak starts.
+	f.applyTarget(ctx)
+	f.updatePod(ctx, 2)
+	if after := f.sync(ctx); after != canaryCheckInterval || f.status().Phase != v1alpha1.CanaryPhaseSoaking {
+		t.Fatalf("expected the step to soak, got: %v, %+v", after, f.status())
+	}
+
+	// The health checks pass during the soak.
+	if after := f.sync(ctx); after <= 0 || f.status().Phase != v1alpha1.CanaryPhaseSoaking {
+		t.Fatalf("expected the step to keep soaking, got: %v, %+v", after, f.status())
+	}
+
+	// The soak is finished, and the next step updates one more pod.
+	f.elapse(2 * time.Hour)
+	f.sync(ctx)
+	status = f.status()
+	if status.Phase != v1alpha1.CanaryPhaseProgressing || status.Step != 1 || status.UpdatedReplicas != 2 {
+		t.Fatalf("expected the second step, got: %+v", status)
+	}
+
+	f.updatePod(ctx, 1)
+	f.sync(ctx)
+	if phase := f.status().Phase; phase != v1alpha1.CanaryPhaseSoaking {
+		t.Fatalf("expected the second step to soak, got: %s", phase)
+	}
+
+	// The metric breaches the threshold, so the canary is aborted and the StatefulSet is rolled back.
+	errors.Store(3)
+	f.sync(ctx)
+	status = f.status()
+	if status.Phase != v1alpha1.CanaryPhaseAborted || status.Message == "" {
+		t.Fatalf("expected the canary to be aborted, got: %+v", status)
+	}
+	if hasCanaryInProgress(f.cluster) {
+		t.Errorf("expected no canary in progress after the abort")
+	}
+	if f.cluster.Status.RolledBackGeneration != f.cluster.Generation {
+		t.Errorf("expected the generation %d to be rolled back, got: %d", f.cluster.Generation, f.cluster.Status.RolledBackGeneration)
+	}
+	if condition := f.cluster.Status.GetCondition(v1alpha1.ConditionTypeRolledBack); condition == nil || condition.Status != corev1.ConditionTrue {
+		t.Errorf("expected the rolled back condition, got: %+v", condition)
+	}
+
+	live := f.live(ctx)
+	if image := live.Spec.Template.Spec.Containers[0].Image; image != oldImage {
+		t.Errorf("expected the pod template to be rolled back to '%s', got: '%s'", oldImage, image)
+	}
+	if live.Spec.UpdateStrategy.RollingUpdate != nil {
+		t.Errorf("expected the partition to be removed, got: %+v", live.Spec.UpdateStrategy.RollingUpdate)
+	}
+}
+
+func TestCanaryCompleted(t *testing.T) {
+	f := newCanaryFixture(t, nil, &v1alpha1.CanarySpec{Steps: []int32{1}, SoakDuration: &metav1.Duration{Duration: time.Minute}})
+	ctx := context.Background()
+
+	start := metav1.NewTime(time.Now().Add(-time.Hour))
+	f.cluster.Status.Conditions = []v1alpha1.Condition{{Type: v1alpha1.ConditionTypeProgressing, Status: corev1.ConditionTrue, LastTransitionTime: start}}
+
+	f.sync(ctx)
+	f.applyTarget(ctx)
+	f.updatePod(ctx, 2)
+	f.sync(ctx)
+	f.elapse(2 * time.Minute)
+	f.sync(ctx)
+
+	if status := f.status(); status.Phase != v1alpha1.CanaryPhaseCompleted || status.UpdatedReplicas != 3 {
+		t.Fatalf("expected the canary to be completed, got: %+v", status)
+	}
+
+	// The rest pods start to roll out, so the time of the soaks is not counted in the progress deadline.
+	if condition := f.cluster.Status.GetCondition(v1alpha1.ConditionTypeProgressing); !condition.LastTransitionTime.After(start.Time) {
+		t.Errorf("expected the progress deadline to restart, got: %v", condition.LastTransitionTime)
+	}
+}
+
+func TestCanaryStepDeadline(t *testing.T) {
+	f := newCanaryFixture(t, nil, &v1alpha1.CanarySpec{Steps: []int32{1}})
+	f.cluster.Spec.RolloutPolicy = &v1alpha1.RolloutPolicy{ProgressDeadline: &metav1.Duration{Duration: 10 * time.Minute}}
+	ctx := context.Background()
+
+	f.sync(ctx)
+	f.applyTarget(ctx)
+
+	// The updated pod is never ready.
+	f.sync(ctx)
+	if phase := f.status().Phase; phase != v1alpha1.CanaryPhaseProgressing {
+		t.Fatalf("expected the step to wait for the pods, got: %s", phase)
+	}
+
+	f.elapse(time.Hour)
+	f.sync(ctx)
+	if phase := f.status().Phase; phase != v1alpha1.CanaryPhaseAborted {
+		t.Fatalf("expected the step to be aborted after the progress deadline, got: %s", phase)
+	}
+}
+
+// canaryFixture is a datanode StatefulSet of 3 replicas whose pod template is changed from oldImage to newImage.
+type canaryFixture struct {
+	t       *testing.T
+	r       *Reconciler
+	cluster *v1alpha1.GreptimeDBCluster
+	target  canaryTarget
+}
+
+func newCanaryFixture(t *testing.T, server *httptest.Server, canary *v1alpha1.CanarySpec) *canaryFixture {
+	port := 4000
+	if server != nil {
+		_, p, err := net.SplitHostPort(server.Listener.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if port, err = strconv.Atoi(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	newSts := func(image string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-datanode", Namespace: "default", Generation: 1},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To(int32(3)),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test-datanode"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test-datanode"}},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "datanode", Image: image}}},
+				},
+			},
+		}
+	}
+
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 2},
+	}
+
+	live := newSts(oldImage)
+	live.Annotations = map[string]string{deployer.LastAppliedResourceSpec: marshalSpec(t, live.Spec)}
+	live.Status = appsv1.StatefulSetStatus{ObservedGeneration: 1, CurrentRevision: "rev-old", UpdateRevision: "rev-new"}
+
+	revisionData, err := json.Marshal(map[string]interface{}{"spec": map[string]interface{}{"template": live.Spec.Template}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objects := []client.Object{
+		cluster,
+		live,
+		&appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: "rev-old", Namespace: "default"},
+			Data:       runtime.RawExtension{Raw: revisionData},
+		},
+	}
+	for i := 0; i < 3; i++ {
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-datanode-%d", i),
+				Namespace: "default",
+				Labels:    map[string]string{"app": "test-datanode", appsv1.ControllerRevisionHashLabelKey: "rev-old"},
+			},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "datanode", Image: oldImage, Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: int32(port)}}}}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				PodIP:      "127.0.0.1",
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		})
+	}
+
+	return &canaryFixture{
+		t:       t,
+		r:       newTestReconciler(objects...),
+		cluster: cluster,
+		target:  canaryTarget{canary: canary, sts: newSts(newImage)},
+	}
+}
+
+func (f *canaryFixture) sync(ctx context.Context) time.Duration {
+	f.t.Helper()
+	after, err := f.r.syncCanary(ctx, f.cluster, f.target)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return after
+}
+
+func (f *canaryFixture) status() *v1alpha1.CanaryStatus {
+	f.t.Helper()
+	status := f.cluster.Status.GetCanary(f.target.sts.Name)
+	if status == nil {
+		f.t.Fatalf("the canary status is not found")
+	}
+	return status
+}
+
+// elapse moves the start time of the current step backward.
+func (f *canaryFixture) elapse(d time.Duration) {
+	status := f.status()
+	status.StepStartTime = &metav1.Time{Time: status.StepStartTime.Add(-d)}
+}
+
+func (f *canaryFixture) live(ctx context.Context) *appsv1.StatefulSet {
+	f.t.Helper()
+	live := new(appsv1.StatefulSet)
+	if err := f.r.Get(ctx, client.ObjectKeyFromObject(f.target.sts), live); err != nil {
+		f.t.Fatal(err)
+	}
+	return live
+}
+
+// applyTarget applies the new pod template to the live StatefulSet like the datanode deployer.
+func (f *canaryFixture) applyTarget(ctx context.Context) {
+	f.t.Helper()
+	live := f.live(ctx)
+	live.Spec.Template = f.target.sts.Spec.Template
+	live.Annotations[deployer.LastAppliedResourceSpec] = marshalSpec(f.t, live.Spec)
+	if err := f.r.Update(ctx, live); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+// updatePod makes the pod of the ordinal run the update revision of the StatefulSet.
+func (f *canaryFixture) updatePod(ctx context.Context, ordinal int) {
+	f.t.Helper()
+	pod := new(corev1.Pod)
+	if err := f.r.Get(ctx, client.ObjectKey{Namespace: "default", Name: fmt.Sprintf("test-datanode-%d", ordinal)}, pod); err != nil {
+		f.t.Fatal(err)
+	}
+	pod.Labels[appsv1.ControllerRevisionHashLabelKey] = "rev-new"
+	pod.Spec.Containers[0].Image = newImage
+	if err := f.r.Update(ctx, pod); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+func marshalSpec(t *testing.T, spec appsv1.StatefulSetSpec) string {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestScrapeMetric(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `# HELP greptime_servers_error_count The number of errors.
+# TYPE greptime_servers_error_count counter
+greptime_servers_error_count{protocol="mysql"} 3
+greptime_servers_error_count{protocol="http"} 2
+# HELP greptime_memory_usage_ratio The memory usage ratio.
+# TYPE greptime_memory_usage_ratio gauge
+greptime_memory_usage_ratio 0.5
+`)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		want float64
+	}{
+		{name: "greptime_servers_error_count", want: 5},
+		{name: "greptime_memory_usage_ratio", want: 0.5},
+		{name: "greptime_not_exist", want: 0},
+	}
+
+	for _, tt := range tests {
+		got, err := scrapeMetric(context.Background(), server.URL, tt.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("metric '%s': want %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,590 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/greptimedbcluster/deployers"
+)
+
+const (
+	// defaultMetaTableName is the default table name of the meta backend in MySQL or PostgreSQL.
+	defaultMetaTableName = "greptime_metakv"
+
+	// cloneSourceLabelKey is the label key of the VolumeSnapshots that are taken for cloning the cluster.
+	cloneSourceLabelKey = "app.greptime.io/clone-source"
+)
+
+var volumeSnapshotGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshot",
+}
+
+// cloneTarget is the datanode StatefulSet of the clone and the one of the source cluster.
+type cloneTarget struct {
+	source   string
+	target   string
+	group    string
+	replicas int32
+	fs       *v1alpha1.FileStorage
+}
+
+// aliasCloneStorage sets the meta backend and the object storage of the clone from the source cluster with the aliased key prefix, table or root if they're not set,
+// and makes sure the clone doesn't share the meta backend and the object storage with the source cluster.
+func (r *Reconciler) aliasCloneStorage(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) error {
+	if cluster.Spec.CloneFrom == nil {
+		return nil
+	}
+
+	source := new(v1alpha1.GreptimeDBCluster)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.CloneFrom.Name}, source); err != nil {
+		// The source cluster may be deleted after the clone is finished.
+		if k8serrors.IsNotFound(err) && cluster.Status.Clone.IsFinished() {
+			return nil
+		}
+		return err
+	}
+
+	if cluster.Spec.ObjectStorageProvider == nil && source.GetObjectStorageProvider() != nil {
+		osp := source.GetObjectStorageProvider().DeepCopy()
+		if _, _, root := objectStorageLocation(osp); root != nil {
+			// The root of the clone is next to the one of the source cluster rather than under it, unless the source cluster uses the whole bucket.
+			*root = cloneAlias(*root, cluster.Name, "-")
+		}
+		cluster.Spec.ObjectStorageProvider = osp
+	}
+
+	if sameObjectStorage(cluster.GetObjectStorageProvider(), source.GetObjectStorageProvider()) {
+		return fmt.Errorf("the clone must not use the same object storage root as the source cluster '%s'", source.Name)
+	}
+
+	if cluster.Spec.Meta != nil && cluster.Spec.Meta.BackendStorage == nil && source.GetMeta().GetBackendStorage() != nil {
+		backendStorage := source.GetMeta().GetBackendStorage().DeepCopy()
+		aliasBackendStorage(backendStorage, cluster)
+		cluster.Spec.Meta.BackendStorage = backendStorage
+	}
+
+	if sameBackendStorage(cluster.GetMeta().GetBackendStorage(), source.GetMeta().GetBackendStorage()) {
+		return fmt.Errorf("the clone must not use the same meta backend key prefix as the source cluster '%s'", source.Name)
+	}
+
+	return nil
+}
+
+// prepareClone takes the VolumeSnapshots of the source datanode PVCs, and creates the datanode PVCs of the clone from the snapshots.
+// It returns true if the datanodes can be created.
+func (r *Reconciler) prepareClone(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) (bool, error) {
+	if cluster.Spec.CloneFrom == nil || cluster.Status.Clone.IsFinished() {
+		return true, nil
+	}
+
+	source := new(v1alpha1.GreptimeDBCluster)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.CloneFrom.Name}, source); err != nil {
+		return false, err
+	}
+
+	targets := cloneTargets(cluster, source)
+
+	if cluster.Status.Clone == nil {
+		// The datanodes are already created, so the PVCs can't be restored from the snapshots.
+		exist, err := r.anyStatefulSetExists(ctx, cluster.Namespace, targets)
+		if err != nil {
+			return false, err
+		}
+		if exist {
+			return true, r.failClone(ctx, cluster, "the datanodes already exist")
+		}
+
+		if err := checkCloneMeta(source.GetMeta().GetBackendStorage(), cluster.GetMeta().GetBackendStorage()); err != nil {
+			return true, r.failClone(ctx, cluster, err.Error())
+		}
+
+		if err := r.flushCloneSource(ctx, cluster, source); err != nil {
+			return false, err
+		}
+
+		if err := r.Cloner.CopyMeta(ctx, r.Client, cluster.Namespace, source.GetMeta().GetBackendStorage(), cluster.GetMeta().GetBackendStorage()); err != nil {
+			r.Recorder.Event(cluster, corev1.EventTypeWarning, "CopyMetaFailed", fmt.Sprintf("Copy the meta keys of cluster '%s' failed: %v", source.Name, err))
+			return false, err
+		}
+
+		klog.Infof("Start to clone the cluster '%s/%s' from '%s'", cluster.Namespace, cluster.Name, source.Name)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "CloneStarted", fmt.Sprintf("Taking the snapshots of the datanode PVCs of cluster '%s'", source.Name))
+		cluster.Status.Clone = &v1alpha1.CloneStatus{Source: source.Name, Phase: v1alpha1.ClonePhaseSnapshotting}
+	}
+
+	// The data in the object storage is copied while the snapshots are taken.
+	job, err := r.ensureCopyJob(ctx, cluster, source)
+	if err != nil {
+		return false, err
+	}
+
+	copied := true
+	if job != nil {
+		cluster.Status.Clone.CopyJob = job.Name
+		finished, conditionType := common.IsJobFinished(job)
+		if conditionType == batchv1.JobFailed {
+			return true, r.failClone(ctx, cluster, fmt.Sprintf("the job '%s' failed to copy the data in the object storage", job.Name))
+		}
+		copied = finished
+	}
+
+	var (
+		snapshots []v1alpha1.CloneSnapshot
+		restored  []*corev1.PersistentVolumeClaim
+		ready     = true
+	)
+
+	for _, target := range targets {
+		pvcs, err := common.GetPVCs(ctx, r.Client, cluster.Namespace, target.source, common.FileStorageTypeDatanode)
+		if err != nil {
+			return false, err
+		}
+
+		for _, pvc := range pvcs {
+			// The datanodes that are out of the replicas of the clone are not cloned.
+			ordinal, err := strconv.Atoi(pvc.Name[strings.LastIndex(pvc.Name, "-")+1:])
+			if err != nil || int32(ordinal) >= target.replicas {
+				continue
+			}
+
+			snapshot, err := r.ensureVolumeSnapshot(ctx, cluster, &pvc)
+			if err != nil {
+				return false, err
+			}
+
+			readyToUse, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+			snapshots = append(snapshots, v1alpha1.CloneSnapshot{
+				Name:       snapshot.GetName(),
+				SourcePVC:  pvc.Name,
+				TargetPVC:  fmt.Sprintf("%s-%s-%d", target.fs.GetName(), target.target, ordinal),
+				ReadyToUse: readyToUse,
+			})
+
+			if !readyToUse {
+				ready = false
+				continue
+			}
+
+			restoreSize, _, _ := unstructured.NestedString(snapshot.Object, "status", "restoreSize")
+			restored = append(restored, clonePVC(cluster, target, snapshots[len(snapshots)-1], restoreSize))
+		}
+	}
+
+	cluster.Status.Clone.Snapshots = snapshots
+
+	if !ready {
+		cluster.Status.Clone.Message = "waiting for the snapshots to be ready to use"
+		return false, deployers.UpdateStatus(ctx, cluster, r.Client)
+	}
+
+	if !copied {
+		cluster.Status.Clone.Message = fmt.Sprintf("waiting for the job '%s' to copy the data in the object storage", job.Name)
+		return false, deployers.UpdateStatus(ctx, cluster, r.Client)
+	}
+
+	for _, pvc := range restored {
+		if err := r.Create(ctx, pvc); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return false, err
+		}
+	}
+
+	klog.Infof("The datanode PVCs of the cluster '%s/%s' are restored from the snapshots of '%s'", cluster.Namespace, cluster.Name, source.Name)
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "CloneCompleted", fmt.Sprintf("%d datanode PVCs are restored from the snapshots of cluster '%s'", len(restored), source.Name))
+
+	cluster.Status.Clone.Phase = v1alpha1.ClonePhaseCompleted
+	cluster.Status.Clone.Message = ""
+	cluster.Status.Clone.CompletionTime = ptrNow()
+
+	return true, deployers.UpdateStatus(ctx, cluster, r.Client)
+}
+
+// flushCloneSource flushes the regions of the running source cluster before the snapshots are taken.
+// The stopped datanodes have flushed the regions when they were shut down.
+func (r *Reconciler) flushCloneSource(ctx context.Context, cluster, source *v1alpha1.GreptimeDBCluster) error {
+	if source.Status.ClusterPhase != v1alpha1.PhaseRunning {
+		return nil
+	}
+
+	conn, err := common.NewMySQLConnection(ctx, r.Client, source, cluster.Spec.CloneFrom.CredentialsSecretName)
+	if err != nil {
+		return err
+	}
+
+	if err := r.Cloner.FlushRegions(ctx, conn); err != nil {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "FlushFailed", fmt.Sprintf("Flush the regions of cluster '%s' failed: %v", source.Name, err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *Reconciler) failClone(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, message string) error {
+	klog.Warningf("Failed to clone the cluster '%s/%s': %s", cluster.Namespace, cluster.Name, message)
+	r.Recorder.Event(cluster, corev1.EventTypeWarning, "CloneFailed", fmt.Sprintf("Failed to clone the cluster: %s", message))
+	cluster.Status.Clone = &v1alpha1.CloneStatus{
+		Source:  cluster.Spec.CloneFrom.Name,
+		Phase:   v1alpha1.ClonePhaseFailed,
+		Message: message,
+	}
+	return deployers.UpdateStatus(ctx, cluster, r.Client)
+}
+
+func (r *Reconciler) anyStatefulSetExists(ctx context.Context, namespace string, targets []cloneTarget) (bool, error) {
+	for _, target := range targets {
+		err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: target.target}, new(appsv1.StatefulSet))
+		if err == nil {
+			return true, nil
+		}
+		if !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// ensureVolumeSnapshot creates the VolumeSnapshot of the source PVC if it doesn't exist.
+// The VolumeSnapshot is owned by the clone, so it will be deleted with the clone.
+func (r *Reconciler) ensureVolumeSnapshot(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, pvc *corev1.PersistentVolumeClaim) (*unstructured.Unstructured, error) {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(volumeSnapshotGVK)
+
+	name := fmt.Sprintf("%s-clone-%s", cluster.Name, pvc.Name)
+	err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: name}, snapshot)
+	if err == nil {
+		return snapshot, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	snapshot.SetName(name)
+	snapshot.SetNamespace(cluster.Namespace)
+	snapshot.SetLabels(map[string]string{cloneSourceLabelKey: cluster.Spec.CloneFrom.Name})
+	if err := unstructured.SetNestedField(snapshot.Object, pvc.Name, "spec", "source", "persistentVolumeClaimName"); err != nil {
+		return nil, err
+	}
+	if className := cluster.Spec.CloneFrom.VolumeSnapshotClassName; className != "" {
+		if err := unstructured.SetNestedField(snapshot.Object, className, "spec", "volumeSnapshotClassName"); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := controllerutil.SetControllerReference(cluster, snapshot, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	klog.Infof("Create the VolumeSnapshot '%s/%s' of PVC '%s'", cluster.Namespace, name, pvc.Name)
+	if err := r.Create(ctx, snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// ensureCopyJob creates the job that copies the data under the object storage root of the source cluster to the one of the clone if it doesn't exist.
+// It returns nil if the source cluster doesn't store the data in the object storage.
+func (r *Reconciler) ensureCopyJob(ctx context.Context, cluster, source *v1alpha1.GreptimeDBCluster) (*batchv1.Job, error) {
+	if source.GetObjectStorageProvider() == nil || cluster.GetObjectStorageProvider() == nil {
+		return nil, nil
+	}
+
+	job := new(batchv1.Job)
+	err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: copyJobName(cluster.Name)}, job)
+	if err == nil {
+		return job, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	job, err = buildCopyJob(cluster, source, r.CloneCopyImage)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := controllerutil.SetControllerReference(cluster, job, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	klog.Infof("Create the job '%s/%s' to copy the data in the object storage of '%s'", cluster.Namespace, job.Name, source.Name)
+	if err := r.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func copyJobName(clusterName string) string {
+	return clusterName + "-clone-objects"
+}
+
+// buildCopyJob builds the job that runs `rclone copy` to copy the data under the object storage root of the source cluster to the one of the clone.
+// The root of the clone is excluded from the copy if it's under the root of the source cluster.
+func buildCopyJob(cluster, source *v1alpha1.GreptimeDBCluster, image string) (*batchv1.Job, error) {
+	sourceRemote, sourceEnv, err := common.RcloneRemote("source", source.GetObjectStorageProvider(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	cloneRemote, cloneEnv, err := common.RcloneRemote("clone", cluster.GetObjectStorageProvider(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"copy", sourceRemote, cloneRemote, "--verbose"}
+	if nested := nestedObjectStorageRoot(source.GetObjectStorageProvider(), cluster.GetObjectStorageProvider()); nested != "" {
+		args = append(args, "--exclude", "/"+nested+"/**")
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      copyJobName(cluster.Name),
+			Namespace: cluster.Namespace,
+			Labels:    map[string]string{cloneSourceLabelKey: source.Name},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(3)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:    "copy",
+							Image:   image,
+							Command: []string{"rclone"},
+							Args:    args,
+							Env:     append(sourceEnv, cloneEnv...),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return job, nil
+}
+
+// clonePVC returns the datanode PVC of the clone that is restored from the snapshot.
+// The PVC has the same name as the one created by the StatefulSet, so it will be used by the datanode.
+func clonePVC(cluster *v1alpha1.GreptimeDBCluster, target cloneTarget, snapshot v1alpha1.CloneSnapshot, restoreSize string) *corev1.PersistentVolumeClaim {
+	pvc := common.FileStorageToPVC(cluster.Name, target.group, target.fs, common.FileStorageTypeDatanode, v1alpha1.DatanodeRoleKind)
+	pvc.Name = snapshot.TargetPVC
+	pvc.Namespace = cluster.Namespace
+	pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+		APIGroup: ptr.To(volumeSnapshotGVK.Group),
+		Kind:     volumeSnapshotGVK.Kind,
+		Name:     snapshot.Name,
+	}
+
+	// The size of the restored PVC must not be less than the size of the snapshot.
+	if size, err := resource.ParseQuantity(restoreSize); err == nil && size.Cmp(pvc.Spec.Resources.Requests[corev1.ResourceStorage]) > 0 {
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = size
+	}
+
+	return pvc
+}
+
+// cloneTargets returns the datanode StatefulSets of the clone and the ones of the source cluster that have the same names.
+func cloneTargets(cluster, source *v1alpha1.GreptimeDBCluster) []cloneTarget {
+	var targets []cloneTarget
+
+	add := func(spec *v1alpha1.DatanodeSpec) {
+		fs := spec.GetFileStorage()
+		if !fs.IsUsePVC() {
+			return
+		}
+		targets = append(targets, cloneTarget{
+			source:   common.ResourceName(source.Name, v1alpha1.DatanodeRoleKind, spec.GetName()),
+			target:   common.ResourceName(cluster.Name, v1alpha1.DatanodeRoleKind, spec.GetName()),
+			group:    spec.GetName(),
+			replicas: ptr.Deref(spec.GetReplicas(), 0),
+			fs:       fs,
+		})
+	}
+
+	if cluster.GetDatanode() != nil && source.GetDatanode() != nil {
+		add(cluster.GetDatanode())
+	}
+
+	for _, group := range cluster.GetDatanodeGroups() {
+		for _, sourceGroup := range source.GetDatanodeGroups() {
+			if group.GetName() == sourceGroup.GetName() {
+				add(group)
+			}
+		}
+	}
+
+	return targets
+}
+
+// cloneAlias returns the alias of the path or the name of the source for the clone.
+func cloneAlias(source, clone, separator string) string {
+	source = strings.TrimSuffix(source, "/")
+	if source == "" || source == "/" {
+		return strings.Join([]string{"clone", clone}, separator)
+	}
+	return strings.Join([]string{source, "clone", clone}, separator)
+}
+
+// objectStorageLocation returns the provider, the bucket and the pointer to the root of the object storage.
+func objectStorageLocation(osp *v1alpha1.ObjectStorageProviderSpec) (string, string, *string) {
+	switch {
+	case osp.GetS3Storage() != nil:
+		return "s3", osp.S3.Bucket, &osp.S3.Root
+	case osp.GetOSSStorage() != nil:
+		return "oss", osp.OSS.Bucket, &osp.OSS.Root
+	case osp.GetGCSStorage() != nil:
+		return "gcs", osp.GCS.Bucket, &osp.GCS.Root
+	case osp.GetAZBlobStorage() != nil:
+		return "azblob", osp.AZBlob.Container, &osp.AZBlob.Root
+	}
+	return "", "", nil
+}
+
+func sameObjectStorage(a, b *v1alpha1.ObjectStorageProviderSpec) bool {
+	providerA, bucketA, rootA := objectStorageLocation(a)
+	providerB, bucketB, rootB := objectStorageLocation(b)
+	if rootA == nil || rootB == nil {
+		return false
+	}
+	return providerA == providerB && bucketA == bucketB && common.JoinObjectStoragePath(*rootA) == common.JoinObjectStoragePath(*rootB)
+}
+
+// nestedObjectStorageRoot returns the path of the root of the clone relative to the root of the source cluster if it's under the latter.
+func nestedObjectStorageRoot(source, clone *v1alpha1.ObjectStorageProviderSpec) string {
+	sourceProvider, sourceBucket, sourceRoot := objectStorageLocation(source)
+	cloneProvider, cloneBucket, cloneRoot := objectStorageLocation(clone)
+	if sourceRoot == nil || cloneRoot == nil || sourceProvider != cloneProvider || sourceBucket != cloneBucket {
+		return ""
+	}
+
+	sourcePath, clonePath := common.JoinObjectStoragePath(*sourceRoot), common.JoinObjectStoragePath(*cloneRoot)
+	if sourcePath == "" {
+		return clonePath
+	}
+	if nested, ok := strings.CutPrefix(clonePath, sourcePath+"/"); ok {
+		return nested
+	}
+	return ""
+}
+
+func aliasBackendStorage(backendStorage *v1alpha1.BackendStorage, cluster *v1alpha1.GreptimeDBCluster) {
+	if etcd := backendStorage.GetEtcdStorage(); etcd != nil {
+		etcd.StoreKeyPrefix = cloneAlias(etcd.StoreKeyPrefix, cluster.Name, "-")
+	}
+
+	if mysql := backendStorage.GetMySQLStorage(); mysql != nil {
+		mysql.Table = cloneTableName(mysql.Table, cluster.Name)
+	}
+
+	if postgresql := backendStorage.GetPostgreSQLStorage(); postgresql != nil {
+		postgresql.Table = cloneTableName(postgresql.Table, cluster.Name)
+
+		// The clone must not compete for the election lock with the source cluster.
+		h := fnv.New64a()
+		h.Write([]byte(cluster.Namespace + "/" + cluster.Name))
+		postgresql.ElectionLockID = h.Sum64() >> 1
+	}
+}
+
+func cloneTableName(table, clone string) string {
+	if table == "" {
+		table = defaultMetaTableName
+	}
+	return strings.ReplaceAll(cloneAlias(table, clone, "_"), "-", "_")
+}
+
+// checkCloneMeta returns the reason why the meta keys of the source cluster can't be copied to the meta backend of the clone.
+func checkCloneMeta(source, clone *v1alpha1.BackendStorage) error {
+	if etcd := source.GetEtcdStorage(); etcd != nil {
+		if clone.GetEtcdStorage() == nil {
+			return fmt.Errorf("the meta backend of the clone must be etcd as the source cluster")
+		}
+		// Without the prefix, the meta keys of the source cluster can't be told apart from the other keys in etcd.
+		if etcd.StoreKeyPrefix == "" {
+			return fmt.Errorf("the source cluster has no store key prefix of etcd")
+		}
+	}
+
+	if mysql := source.GetMySQLStorage(); mysql != nil {
+		target := clone.GetMySQLStorage()
+		if target == nil || target.Host != mysql.Host || target.Port != mysql.Port || target.Database != mysql.Database {
+			return fmt.Errorf("the meta table of the clone must be in the same MySQL database as the source cluster")
+		}
+	}
+
+	if postgresql := source.GetPostgreSQLStorage(); postgresql != nil {
+		target := clone.GetPostgreSQLStorage()
+		if target == nil || target.Host != postgresql.Host || target.Port != postgresql.Port || target.Database != postgresql.Database {
+			return fmt.Errorf("the meta table of the clone must be in the same PostgreSQL database as the source cluster")
+		}
+	}
+
+	return nil
+}
+
+func sameBackendStorage(a, b *v1alpha1.BackendStorage) bool {
+	if etcdA, etcdB := a.GetEtcdStorage(), b.GetEtcdStorage(); etcdA != nil && etcdB != nil {
+		shared := false
+		for _, endpoint := range etcdA.Endpoints {
+			if slices.Contains(etcdB.Endpoints, endpoint) {
+				shared = true
+			}
+		}
+		return shared && etcdA.StoreKeyPrefix == etcdB.StoreKeyPrefix
+	}
+
+	if mysqlA, mysqlB := a.GetMySQLStorage(), b.GetMySQLStorage(); mysqlA != nil && mysqlB != nil {
+		return mysqlA.Host == mysqlB.Host && mysqlA.Port == mysqlB.Port && mysqlA.Database == mysqlB.Database &&
+			metaTableName(mysqlA.Table) == metaTableName(mysqlB.Table)
+	}
+
+	if postgresqlA, postgresqlB := a.GetPostgreSQLStorage(), b.GetPostgreSQLStorage(); postgresqlA != nil && postgresqlB != nil {
+		return postgresqlA.Host == postgresqlB.Host && postgresqlA.Port == postgresqlB.Port && postgresqlA.Database == postgresqlB.Database &&
+			metaTableName(postgresqlA.Table) == metaTableName(postgresqlB.Table)
+	}
+
+	return false
+}
+
+func metaTableName(table string) string {
+	if table == "" {
+		return defaultMetaTableName
+	}
+	return table
+}
@@ -0,0 +1,466 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+)
+
+func TestCloneCluster(t *testing.T) {
+	newCluster := func(name string, replicas int32) *v1alpha1.GreptimeDBCluster {
+		return &v1alpha1.GreptimeDBCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID("uid-" + name)},
+			Spec: v1alpha1.GreptimeDBClusterSpec{
+				Meta: &v1alpha1.MetaSpec{},
+				Datanode: &v1alpha1.DatanodeSpec{
+					ComponentSpec: v1alpha1.ComponentSpec{Replicas: ptr.To(replicas)},
+					Storage: &v1alpha1.DatanodeStorageSpec{
+						FileStorage: &v1alpha1.FileStorage{Name: "datanode", StorageSize: "10Gi"},
+					},
+				},
+			},
+		}
+	}
+
+	source := newCluster("source", 3)
+	source.Status.ClusterPhase = v1alpha1.PhaseRunning
+	source.Spec.Frontend = &v1alpha1.FrontendSpec{}
+	source.Spec.Meta.BackendStorage = &v1alpha1.BackendStorage{
+		EtcdStorage: &v1alpha1.EtcdStorage{Endpoints: []string{"etcd:2379"}, StoreKeyPrefix: "source"},
+	}
+
+	clone := newCluster("clone", 2)
+	clone.Spec.CloneFrom = &v1alpha1.CloneSource{Name: "source", VolumeSnapshotClassName: "csi-snapclass"}
+
+	var objects []client.Object
+	objects = append(objects, source, clone)
+	for _, ordinal := range []string{"0", "1", "2"} {
+		objects = append(objects, &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "datanode-source-datanode-" + ordinal,
+				Namespace: "default",
+				Labels:    map[string]string{"app.greptime.io/component": common.ResourceName("source", v1alpha1.DatanodeRoleKind)},
+			},
+		})
+	}
+
+	r := newTestReconciler(objects...)
+	cloner := &fakeCloner{}
+	r.Cloner = cloner
+
+	ctx := context.Background()
+
+	// The meta backend of the source cluster is aliased for the clone.
+	if err := r.aliasCloneStorage(ctx, clone); err != nil {
+		t.Fatal(err)
+	}
+
+	if prefix := clone.Spec.Meta.BackendStorage.EtcdStorage.StoreKeyPrefix; prefix != "source-clone-clone" {
+		t.Errorf("unexpected meta backend key prefix of the clone: %s", prefix)
+	}
+
+	// The clone must not share the meta backend with the source cluster.
+	shared := clone.DeepCopy()
+	shared.Spec.Meta.BackendStorage.EtcdStorage.StoreKeyPrefix = "source"
+	if err := r.aliasCloneStorage(ctx, shared); err == nil {
+		t.Errorf("expected an error when the clone uses the same meta backend key prefix as the source cluster")
+	}
+
+	// Wait for the snapshots to be ready to use.
+	cloned, err := r.prepareClone(ctx, clone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cloned {
+		t.Fatalf("expected the clone to wait for the snapshots")
+	}
+
+	if phase := clone.Status.Clone.Phase; phase != v1alpha1.ClonePhaseSnapshotting {
+		t.Fatalf("unexpected clone phase: %s", phase)
+	}
+
+	// The regions are flushed and the meta keys are copied before the snapshots are taken.
+	if cloner.flushed != 1 || cloner.copied != 1 {
+		t.Errorf("expected the source cluster to be flushed and its meta keys to be copied once, got: %+v", cloner)
+	}
+	if prefix := cloner.cloneMeta.GetEtcdStorage().GetStoreKeyPrefix(); prefix != "source-clone-clone" {
+		t.Errorf("expected the meta keys to be copied to the aliased prefix, got: %s", prefix)
+	}
+
+	// Only the PVCs within the replicas of the clone are snapshotted.
+	if len(clone.Status.Clone.Snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots, got: %d", len(clone.Status.Clone.Snapshots))
+	}
+
+	for _, s := range clone.Status.Clone.Snapshots {
+		snapshot := &unstructured.Unstructured{}
+		snapshot.SetGroupVersionKind(volumeSnapshotGVK)
+		if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: s.Name}, snapshot); err != nil {
+			t.Fatal(err)
+		}
+
+		if className, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName"); className != "csi-snapclass" {
+			t.Errorf("unexpected VolumeSnapshotClass: %s", className)
+		}
+
+		if err := unstructured.SetNestedField(snapshot.Object, true, "status", "readyToUse"); err != nil {
+			t.Fatal(err)
+		}
+		if err := unstructured.SetNestedField(snapshot.Object, "20Gi", "status", "restoreSize"); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Update(ctx, snapshot); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cloned, err = r.prepareClone(ctx, clone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cloned || clone.Status.Clone.Phase != v1alpha1.ClonePhaseCompleted {
+		t.Fatalf("expected the clone to be completed, got: %s", clone.Status.Clone.Phase)
+	}
+
+	if cloner.flushed != 1 || cloner.copied != 1 {
+		t.Errorf("expected the source cluster not to be flushed or copied again, got: %+v", cloner)
+	}
+
+	for _, name := range []string{"datanode-clone-datanode-0", "datanode-clone-datanode-1"} {
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, pvc); err != nil {
+			t.Fatal(err)
+		}
+
+		if pvc.Spec.DataSource == nil || pvc.Spec.DataSource.Kind != "VolumeSnapshot" || pvc.Spec.DataSource.Name != "clone-clone-datanode-source-datanode-"+name[len(name)-1:] {
+			t.Errorf("unexpected data source of PVC '%s': %v", name, pvc.Spec.DataSource)
+		}
+
+		if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "20Gi" {
+			t.Errorf("expected the size of PVC '%s' to be the restore size, got: %s", name, size.String())
+		}
+	}
+}
+
+func TestCloneClusterWithObjectStorage(t *testing.T) {
+	newCluster := func(name string) *v1alpha1.GreptimeDBCluster {
+		return &v1alpha1.GreptimeDBCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID("uid-" + name)},
+			Spec: v1alpha1.GreptimeDBClusterSpec{
+				Meta:     &v1alpha1.MetaSpec{},
+				Datanode: &v1alpha1.DatanodeSpec{ComponentSpec: v1alpha1.ComponentSpec{Replicas: ptr.To(int32(1))}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		jobCondition  batchv1.JobConditionType
+		expectedPhase v1alpha1.ClonePhase
+	}{
+		{
+			name:          "copied",
+			jobCondition:  batchv1.JobComplete,
+			expectedPhase: v1alpha1.ClonePhaseCompleted,
+		},
+		{
+			name:          "copy failed",
+			jobCondition:  batchv1.JobFailed,
+			expectedPhase: v1alpha1.ClonePhaseFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := newCluster("source")
+			source.Spec.ObjectStorageProvider = &v1alpha1.ObjectStorageProviderSpec{
+				S3: &v1alpha1.S3Storage{Bucket: "greptimedb", Root: "/source", Region: "us-west-2", SecretName: "s3-credentials"},
+			}
+			source.Spec.Meta.BackendStorage = &v1alpha1.BackendStorage{
+				EtcdStorage: &v1alpha1.EtcdStorage{Endpoints: []string{"etcd:2379"}, StoreKeyPrefix: "source"},
+			}
+
+			clone := newCluster("clone")
+			clone.Spec.CloneFrom = &v1alpha1.CloneSource{Name: "source"}
+
+			r := newTestReconciler(source, clone)
+			r.Cloner = &fakeCloner{}
+			r.CloneCopyImage = "rclone/rclone:1.69"
+
+			ctx := context.Background()
+
+			// The clone must not use the same object storage root as the source cluster.
+			shared := clone.DeepCopy()
+			shared.Spec.ObjectStorageProvider = source.Spec.ObjectStorageProvider.DeepCopy()
+			if err := r.aliasCloneStorage(ctx, shared); err == nil {
+				t.Errorf("expected an error when the clone uses the same object storage root as the source cluster")
+			}
+
+			// The object storage root of the source cluster is aliased for the clone.
+			if err := r.aliasCloneStorage(ctx, clone); err != nil {
+				t.Fatal(err)
+			}
+
+			s3 := clone.GetObjectStorageProvider().GetS3Storage()
+			if s3 == nil || s3.Bucket != "greptimedb" || s3.Root != "/source-clone-clone" || s3.SecretName != "s3-credentials" {
+				t.Fatalf("unexpected object storage of the clone: %+v", s3)
+			}
+
+			// Wait for the data in the object storage to be copied.
+			cloned, err := r.prepareClone(ctx, clone)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cloned || clone.Status.Clone.Phase != v1alpha1.ClonePhaseSnapshotting {
+				t.Fatalf("expected the clone to wait for the copy, got: %+v", clone.Status.Clone)
+			}
+
+			job := &batchv1.Job{}
+			if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: clone.Status.Clone.CopyJob}, job); err != nil {
+				t.Fatal(err)
+			}
+
+			if owner := metav1.GetControllerOf(job); owner == nil || owner.Name != "clone" {
+				t.Errorf("expected the job to be owned by the clone, got: %v", owner)
+			}
+
+			container := job.Spec.Template.Spec.Containers[0]
+			expectedArgs := []string{"copy", "source:greptimedb/source", "clone:greptimedb/source-clone-clone", "--verbose"}
+			if !reflect.DeepEqual(container.Args, expectedArgs) {
+				t.Errorf("unexpected args of the copy job: %v", container.Args)
+			}
+
+			env := make(map[string]corev1.EnvVar)
+			for _, e := range container.Env {
+				env[e.Name] = e
+			}
+			if env["RCLONE_CONFIG_SOURCE_TYPE"].Value != "s3" || env["RCLONE_CONFIG_CLONE_REGION"].Value != "us-west-2" {
+				t.Errorf("unexpected rclone remotes of the copy job: %v", container.Env)
+			}
+			if ref := env["RCLONE_CONFIG_CLONE_SECRET_ACCESS_KEY"].ValueFrom; ref == nil || ref.SecretKeyRef.Name != "s3-credentials" {
+				t.Errorf("expected the credentials of the clone remote to be read from the secret, got: %v", ref)
+			}
+
+			job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{Type: tt.jobCondition, Status: corev1.ConditionTrue})
+			if err := r.Status().Update(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+
+			cloned, err = r.prepareClone(ctx, clone)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !cloned || clone.Status.Clone.Phase != tt.expectedPhase {
+				t.Fatalf("expected the clone to be %s, got: %+v", tt.expectedPhase, clone.Status.Clone)
+			}
+		})
+	}
+}
+
+func TestNestedObjectStorageRoot(t *testing.T) {
+	s3 := func(bucket, root string) *v1alpha1.ObjectStorageProviderSpec {
+		return &v1alpha1.ObjectStorageProviderSpec{S3: &v1alpha1.S3Storage{Bucket: bucket, Root: root}}
+	}
+
+	tests := []struct {
+		name     string
+		source   *v1alpha1.ObjectStorageProviderSpec
+		clone    *v1alpha1.ObjectStorageProviderSpec
+		expected string
+	}{
+		{
+			name:   "sibling root",
+			source: s3("greptimedb", "source"),
+			clone:  s3("greptimedb", "source-clone-clone"),
+		},
+		{
+			name:     "whole bucket",
+			source:   s3("greptimedb", ""),
+			clone:    s3("greptimedb", "clone-clone"),
+			expected: "clone-clone",
+		},
+		{
+			name:     "nested root",
+			source:   s3("greptimedb", "/source/"),
+			clone:    s3("greptimedb", "source/clone"),
+			expected: "clone",
+		},
+		{
+			name:   "another bucket",
+			source: s3("greptimedb", ""),
+			clone:  s3("another", "clone-clone"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if nested := nestedObjectStorageRoot(tt.source, tt.clone); nested != tt.expected {
+				t.Errorf("expected: %q, got: %q", tt.expected, nested)
+			}
+		})
+	}
+}
+
+func TestCloneRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		source func(*v1alpha1.GreptimeDBCluster)
+	}{
+		{
+			name: "etcd without the store key prefix",
+			source: func(source *v1alpha1.GreptimeDBCluster) {
+				source.Spec.Meta.BackendStorage.EtcdStorage.StoreKeyPrefix = ""
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &v1alpha1.GreptimeDBCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"},
+				Spec: v1alpha1.GreptimeDBClusterSpec{
+					Meta: &v1alpha1.MetaSpec{
+						BackendStorage: &v1alpha1.BackendStorage{
+							EtcdStorage: &v1alpha1.EtcdStorage{Endpoints: []string{"etcd:2379"}, StoreKeyPrefix: "source"},
+						},
+					},
+				},
+			}
+			tt.source(source)
+
+			clone := &v1alpha1.GreptimeDBCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "clone", Namespace: "default"},
+				Spec: v1alpha1.GreptimeDBClusterSpec{
+					Meta:      &v1alpha1.MetaSpec{},
+					CloneFrom: &v1alpha1.CloneSource{Name: "source"},
+				},
+			}
+
+			r := newTestReconciler(source, clone)
+			cloner := &fakeCloner{}
+			r.Cloner = cloner
+
+			ctx := context.Background()
+			if err := r.aliasCloneStorage(ctx, clone); err != nil {
+				t.Fatal(err)
+			}
+
+			cloned, err := r.prepareClone(ctx, clone)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The cluster is created as a new cluster.
+			if !cloned || clone.Status.Clone.Phase != v1alpha1.ClonePhaseFailed || clone.Status.Clone.Message == "" {
+				t.Fatalf("expected the clone to be failed, got: %+v", clone.Status.Clone)
+			}
+			if cloner.flushed != 0 || cloner.copied != 0 {
+				t.Errorf("expected the source cluster not to be flushed or copied, got: %+v", cloner)
+			}
+		})
+	}
+}
+
+func TestCheckCloneMeta(t *testing.T) {
+	mysql := func(host, table string) *v1alpha1.BackendStorage {
+		return &v1alpha1.BackendStorage{
+			MySQLStorage: &v1alpha1.MySQLStorage{Host: host, Port: 3306, Database: "meta", Table: table},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		source  *v1alpha1.BackendStorage
+		clone   *v1alpha1.BackendStorage
+		wantErr bool
+	}{
+		{
+			name: "no meta backend",
+		},
+		{
+			name:   "etcd",
+			source: &v1alpha1.BackendStorage{EtcdStorage: &v1alpha1.EtcdStorage{StoreKeyPrefix: "source"}},
+			clone:  &v1alpha1.BackendStorage{EtcdStorage: &v1alpha1.EtcdStorage{StoreKeyPrefix: "source-clone-clone"}},
+		},
+		{
+			name:    "etcd without the store key prefix",
+			source:  &v1alpha1.BackendStorage{EtcdStorage: &v1alpha1.EtcdStorage{}},
+			clone:   &v1alpha1.BackendStorage{EtcdStorage: &v1alpha1.EtcdStorage{StoreKeyPrefix: "clone-clone"}},
+			wantErr: true,
+		},
+		{
+			name:    "different kinds",
+			source:  &v1alpha1.BackendStorage{EtcdStorage: &v1alpha1.EtcdStorage{StoreKeyPrefix: "source"}},
+			clone:   mysql("mysql", "greptime_metakv_clone_clone"),
+			wantErr: true,
+		},
+		{
+			name:   "mysql in the same database",
+			source: mysql("mysql", ""),
+			clone:  mysql("mysql", "greptime_metakv_clone_clone"),
+		},
+		{
+			name:    "mysql in another server",
+			source:  mysql("mysql", ""),
+			clone:   mysql("another-mysql", ""),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCloneMeta(tt.source, tt.clone)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("want error: %v, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+type fakeCloner struct {
+	flushed   int
+	copied    int
+	cloneMeta *v1alpha1.BackendStorage
+}
+
+var _ Cloner = &fakeCloner{}
+
+func (c *fakeCloner) FlushRegions(_ context.Context, _ *common.MySQLConnection) error {
+	c.flushed++
+	return nil
+}
+
+func (c *fakeCloner) CopyMeta(_ context.Context, _ client.Client, _ string, _, clone *v1alpha1.BackendStorage) error {
+	c.copied++
+	c.cloneMeta = clone
+	return nil
+}
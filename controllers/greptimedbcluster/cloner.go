@@ -0,0 +1,215 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+)
+
+// Cloner flushes the source cluster and copies its meta keys for the clone.
+type Cloner interface {
+	// FlushRegions flushes the memtables of all the tables of the cluster, so the VolumeSnapshots contain the data that are written before.
+	FlushRegions(ctx context.Context, conn *common.MySQLConnection) error
+
+	// CopyMeta copies the meta keys from the meta backend of the source cluster to the one of the clone.
+	// The keys that already exist in the clone are not overwritten.
+	CopyMeta(ctx context.Context, c client.Client, namespace string, source, clone *v1alpha1.BackendStorage) error
+}
+
+type defaultCloner struct{}
+
+var _ Cloner = &defaultCloner{}
+
+func (d *defaultCloner) FlushRegions(ctx context.Context, conn *common.MySQLConnection) error {
+	db, err := conn.Open("")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, "SELECT table_schema, table_name FROM information_schema.tables WHERE table_type = 'BASE TABLE'")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var schema, table string
+		if err := rows.Scan(&schema, &table); err != nil {
+			return err
+		}
+		tables = append(tables, schema+"."+table)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("ADMIN FLUSH_TABLE('%s')", strings.ReplaceAll(table, "'", "''"))); err != nil {
+			return fmt.Errorf("failed to flush table '%s': %v", table, err)
+		}
+	}
+
+	klog.Infof("Flushed %d tables", len(tables))
+
+	return nil
+}
+
+func (d *defaultCloner) CopyMeta(ctx context.Context, c client.Client, namespace string, source, clone *v1alpha1.BackendStorage) error {
+	if err := checkCloneMeta(source, clone); err != nil {
+		return err
+	}
+
+	switch {
+	case source.GetEtcdStorage() != nil:
+		return copyEtcdMeta(ctx, source.GetEtcdStorage(), clone.GetEtcdStorage())
+	case source.GetMySQLStorage() != nil:
+		return copyMySQLMeta(ctx, c, namespace, source.GetMySQLStorage(), clone.GetMySQLStorage())
+	case source.GetPostgreSQLStorage() != nil:
+		return copyPostgreSQLMeta(ctx, c, namespace, source.GetPostgreSQLStorage(), clone.GetPostgreSQLStorage())
+	}
+	return nil
+}
+
+// copyEtcdMeta copies the keys under the store key prefix of the source cluster.
+// The keys that are bound to the leases, for example, the election keys of the meta leader, are not copied.
+func copyEtcdMeta(ctx context.Context, source, clone *v1alpha1.EtcdStorage) error {
+	sourceClient, err := newEtcdClient(source.Endpoints)
+	if err != nil {
+		return err
+	}
+	defer sourceClient.Close()
+
+	cloneClient, err := newEtcdClient(clone.Endpoints)
+	if err != nil {
+		return err
+	}
+	defer cloneClient.Close()
+
+	resp, err := sourceClient.Get(ctx, source.StoreKeyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return err
+	}
+
+	copied := 0
+	for _, kv := range resp.Kvs {
+		key := string(kv.Key)
+
+		// The prefix of the clone is aliased from the one of the source cluster, so its keys are under the prefix of the source cluster.
+		if kv.Lease != 0 || strings.HasPrefix(key, clone.StoreKeyPrefix) {
+			continue
+		}
+
+		cloneKey := clone.StoreKeyPrefix + strings.TrimPrefix(key, source.StoreKeyPrefix)
+		txn := cloneClient.Txn(ctx).
+			If(clientv3.Compare(clientv3.CreateRevision(cloneKey), "=", 0)).
+			Then(clientv3.OpPut(cloneKey, string(kv.Value)))
+		if _, err := txn.Commit(); err != nil {
+			return err
+		}
+		copied++
+	}
+
+	klog.Infof("Copied %d meta keys from etcd prefix '%s' to '%s'", copied, source.StoreKeyPrefix, clone.StoreKeyPrefix)
+
+	return nil
+}
+
+func newEtcdClient(endpoints []string) (*clientv3.Client, error) {
+	return clientv3.New(clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: 5 * time.Second,
+	})
+}
+
+// copyMySQLMeta copies the rows of the meta table of the source cluster to the one of the clone in the same database.
+func copyMySQLMeta(ctx context.Context, c client.Client, namespace string, source, clone *v1alpha1.MySQLStorage) error {
+	username, password, err := common.GetCredentials(ctx, c, namespace, source.CredentialsSecretName)
+	if err != nil {
+		return err
+	}
+
+	conn := &common.MySQLConnection{
+		Addr:     net.JoinHostPort(source.Host, strconv.Itoa(int(source.Port))),
+		Username: username,
+		Password: password,
+	}
+	db, err := conn.Open(source.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	sourceTable, cloneTable := quoteIdentifier(metaTableName(source.Table), "`"), quoteIdentifier(metaTableName(clone.Table), "`")
+	return execStatements(ctx, db,
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s LIKE %s", cloneTable, sourceTable),
+		fmt.Sprintf("INSERT IGNORE INTO %s SELECT * FROM %s", cloneTable, sourceTable),
+	)
+}
+
+// copyPostgreSQLMeta copies the rows of the meta table of the source cluster to the one of the clone in the same database.
+func copyPostgreSQLMeta(ctx context.Context, c client.Client, namespace string, source, clone *v1alpha1.PostgreSQLStorage) error {
+	username, password, err := common.GetCredentials(ctx, c, namespace, source.CredentialsSecretName)
+	if err != nil {
+		return err
+	}
+
+	dsn := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(username, password),
+		Host:   net.JoinHostPort(source.Host, strconv.Itoa(int(source.Port))),
+		Path:   source.Database,
+	}
+	db, err := sql.Open("pgx", dsn.String())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	sourceTable, cloneTable := quoteIdentifier(metaTableName(source.Table), `"`), quoteIdentifier(metaTableName(clone.Table), `"`)
+	return execStatements(ctx, db,
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (LIKE %s INCLUDING ALL)", cloneTable, sourceTable),
+		fmt.Sprintf("INSERT INTO %s SELECT * FROM %s ON CONFLICT DO NOTHING", cloneTable, sourceTable),
+	)
+}
+
+func execStatements(ctx context.Context, db *sql.DB, statements ...string) error {
+	for _, statement := range statements {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to execute '%s': %v", statement, err)
+		}
+	}
+	return nil
+}
+
+func quoteIdentifier(name, quote string) string {
+	return quote + strings.ReplaceAll(name, quote, quote+quote) + quote
+}
@@ -22,7 +22,9 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
@@ -58,6 +60,15 @@ type Reconciler struct {
 	Deployers        []deployer.Deployer
 	Recorder         record.EventRecorder
 	MetricsCollector *metrics.MetricsCollector
+
+	// Cloner flushes the source cluster and copies its meta keys when the cluster is cloned.
+	Cloner Cloner
+
+	// CloneCopyImage is the image of the job that copies the data in the object storage of the source cluster to the clone.
+	CloneCopyImage string
+
+	// metaHTTPServiceURL returns the URL of the metasrv HTTP service to rebalance the regions.
+	metaHTTPServiceURL func(cluster *v1alpha1.GreptimeDBCluster) string
 }
 
 func Setup(mgr ctrl.Manager, o *options.Options) error {
@@ -67,6 +78,11 @@ func Setup(mgr ctrl.Manager, o *options.Options) error {
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
 		Recorder: mgr.GetEventRecorderFor("greptimedbcluster-controller"),
+		Cloner:   &defaultCloner{},
+
+		CloneCopyImage: o.CloneCopyImage,
+
+		metaHTTPServiceURL: common.GetMetaHTTPServiceURL,
 	}
 
 	metricsCollector, err := metrics.NewMetricsCollector()
@@ -76,12 +92,13 @@ func Setup(mgr ctrl.Manager, o *options.Options) error {
 	reconciler.MetricsCollector = metricsCollector
 
 	// sync will execute the sync logic of multiple deployers in order.
+	// The order is also the order of the components to be upgraded.
 	reconciler.Deployers = []deployer.Deployer{
 		deployers.NewMonitoringDeployer(mgr),
 		deployers.NewMetaDeployer(mgr, deployers.WithMaintenanceModeWhenCreateCluster(true)),
 		deployers.NewDatanodeDeployer(mgr),
-		deployers.NewFrontendDeployer(mgr),
 		deployers.NewFlownodeDeployer(mgr),
+		deployers.NewFrontendDeployer(mgr),
 	}
 
 	return reconciler.SetupWithManager(mgr)
@@ -94,6 +111,8 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.Service{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&v1alpha1.GreptimeDBStandalone{}).
 		Complete(r)
 }
@@ -101,7 +120,7 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 // +kubebuilder:rbac:groups=greptime.io,resources=greptimedbclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=greptime.io,resources=greptimedbclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=greptime.io,resources=greptimedbclusters/finalizers,verbs=update
-// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;patch;create;
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;patch;create;delete;
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;patch;watch;create;update;delete;
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;patch;watch;
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;patch;watch;create;update;delete;
@@ -110,6 +129,12 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;patch;watch;create;update;delete;
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
@@ -146,11 +171,25 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
+	// The cluster is paused, skip the sync until it's unpaused.
+	if cluster.IsPaused() {
+		err = r.pause(ctx, cluster)
+		return ctrl.Result{}, err
+	}
+
 	if !r.EnableAdmissionWebhook {
 		if err = cluster.Validate(); err != nil {
 			r.Recorder.Event(cluster, corev1.EventTypeWarning, "InvalidCluster", fmt.Sprintf("Invalid cluster: %v", err))
 			return ctrl.Result{}, err
 		}
+
+		// The zones of the created cluster are recorded in the status.
+		if len(cluster.Status.ClusterPhase) > 0 {
+			if err = cluster.ValidateZonesUpdate(cluster.Status.Datanode.Zones); err != nil {
+				r.Recorder.Event(cluster, corev1.EventTypeWarning, "InvalidCluster", fmt.Sprintf("Invalid cluster: %v", err))
+				return ctrl.Result{}, err
+			}
+		}
 	}
 
 	if err = cluster.Check(ctx, r.Client); err != nil {
@@ -164,6 +203,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
+	// Alias the storage of the clone before it's persisted with the default values.
+	if err = r.aliasCloneStorage(ctx, cluster); err != nil {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "CloneFailed", fmt.Sprintf("Alias the storage of the clone failed: %v", err))
+		return ctrl.Result{}, err
+	}
+
 	if !cmp.Equal(originalObject.Spec, cluster.Spec) {
 		// Update the default values to the cluster spec if it is not set.
 		if err = r.Update(ctx, cluster); err != nil {
@@ -175,6 +220,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	// Means the cluster is just created.
 	if len(cluster.Status.ClusterPhase) == 0 {
 		klog.Infof("Start to create the cluster '%s/%s'", cluster.Namespace, cluster.Name)
+		cluster.Status.Datanode.Zones = cluster.GetDatanode().GetZones()
 		if err = r.updateClusterStatus(ctx, cluster, v1alpha1.PhaseStarting); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -192,6 +238,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
+	// The datanode groups of the zones are only expanded in memory, so the spec keeps the zones.
+	cluster.ExpandDatanodeZones()
+
 	// FIXME(zyy17): The following code should be elegant to move to the deployers.
 	if !cluster.Spec.Monitoring.IsEnabled() {
 		if err := r.removeMonitoringDB(ctx, cluster); err != nil {
@@ -199,38 +248,128 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 	}
 
+	if err = r.resume(ctx, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The cluster is hibernated, scale all the components to zero.
+	if cluster.Spec.Hibernate {
+		return r.hibernate(ctx, cluster)
+	}
+
+	awake, err := r.wakeUp(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Wait for the components to be woken up before syncing.
+	if !awake {
+		return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+	}
+
+	cloned, err := r.prepareClone(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Wait for the datanode PVCs to be restored from the snapshots of the source cluster.
+	if !cloned {
+		return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+	}
+
+	ok, err := r.prepareUpgrade(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The upgrade is rejected, wait for the version to be fixed.
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+
 	return r.sync(ctx, cluster)
 }
 
 func (r *Reconciler) sync(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) (ctrl.Result, error) {
+	// The spec failed to be ready and is rolled back, it will not be applied again until it's changed.
+	if cluster.Status.RolledBackGeneration == cluster.Generation {
+		return r.syncRolledBack(ctx, cluster)
+	}
+
+	if err := r.clearRolledBack(ctx, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	canaryRequeueAfter, err := r.syncCanaries(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The canary rollout is aborted and the spec is rolled back.
+	if cluster.Status.RolledBackGeneration == cluster.Generation {
+		return r.syncRolledBack(ctx, cluster)
+	}
+
 	for _, d := range r.Deployers {
 		err := d.Sync(ctx, cluster, d)
 		if errors.Is(err, deployer.ErrSyncNotReady) {
-			var (
-				currentPhase = cluster.Status.ClusterPhase
-				nextPhase    = currentPhase
-			)
-
-			// If the cluster is already running, we will set it to updating phase.
-			if currentPhase == v1alpha1.PhaseRunning {
-				nextPhase = v1alpha1.PhaseUpdating
+			if kind, ok := componentKind(d); ok {
+				if err := r.syncRestartStatus(ctx, cluster, kind, false); err != nil {
+					return ctrl.Result{}, err
+				}
 			}
 
-			// If the cluster is in error phase, we will set it to starting phase.
-			if currentPhase == v1alpha1.PhaseError {
-				nextPhase = v1alpha1.PhaseStarting
+			if err := r.updateClusterStatus(ctx, cluster, notReadyPhase(cluster)); err != nil {
+				return ctrl.Result{}, err
 			}
 
-			if err := r.updateClusterStatus(ctx, cluster, nextPhase); err != nil {
-				return ctrl.Result{}, err
+			requeueAfter := canaryRequeueAfter
+			if len(cluster.Status.Datanode.ScaleIns) > 0 {
+				// Check the progress of the region migrations.
+				requeueAfter = defaultRequeueAfter
 			}
 
-			return ctrl.Result{}, nil
+			// The partitioned StatefulSets are not ready until the canary rollout completes, which can be longer than the progress deadline.
+			if hasCanaryInProgress(cluster) {
+				return ctrl.Result{RequeueAfter: requeueAfter}, nil
+			}
+
+			return r.checkProgressDeadline(ctx, cluster, requeueAfter)
 		}
 
 		if err != nil {
 			return ctrl.Result{RequeueAfter: defaultRequeueAfter}, err
 		}
+
+		if kind, ok := componentKind(d); ok {
+			// The component is ready, so the restart requests that are applied are completed.
+			if err := r.syncRestartStatus(ctx, cluster, kind, true); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			// During the upgrade, the next component will not start until the component is fully rolled out and healthy.
+			upgraded, err := r.syncUpgradeProgress(ctx, cluster, kind)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+
+			if !upgraded {
+				if err := r.updateClusterStatus(ctx, cluster, notReadyPhase(cluster)); err != nil {
+					return ctrl.Result{}, err
+				}
+
+				// The component is not rolled out until its canary rollout completes.
+				if hasCanaryInProgress(cluster) {
+					return ctrl.Result{RequeueAfter: minRequeueAfter(canaryRequeueAfter, defaultRequeueAfter)}, nil
+				}
+
+				return r.checkProgressDeadline(ctx, cluster, defaultRequeueAfter)
+			}
+		}
+	}
+
+	if err := r.completeUpgrade(ctx, cluster); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	if cluster.Status.ClusterPhase == v1alpha1.PhaseStarting ||
@@ -250,16 +389,35 @@ func (r *Reconciler) sync(ctx context.Context, cluster *v1alpha1.GreptimeDBClust
 		}
 	}
 
+	if err := r.sweepOrphanPVCs(ctx, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.sweepPodDisruptionBudgets(ctx, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	rebalanceRequeueAfter, err := r.rebalanceRegions(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	scalingRequeueAfter, err := r.scaleDatanodes(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	requeueAfter := minRequeueAfter(canaryRequeueAfter, rebalanceRequeueAfter, scalingRequeueAfter, maintenanceWindowRequeueAfter(cluster))
+
 	if cluster.Status.ClusterPhase == v1alpha1.PhaseRunning && r.MetricsCollector != nil {
 		if err := r.MetricsCollector.CollectClusterPodMetrics(ctx, cluster); err != nil {
 			klog.Errorf("Failed to collect cluster pod metrics: '%v'", err)
 
 			// We will not return error here because it is not a critical issue.
-			return ctrl.Result{}, nil
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
 		}
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *Reconciler) addFinalizer(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) error {
@@ -290,8 +448,12 @@ func (r *Reconciler) delete(ctx context.Context, cluster *v1alpha1.GreptimeDBClu
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	// Clean up the storage of the datanode groups of the zones.
+	expanded := cluster.DeepCopy()
+	expanded.ExpandDatanodeZones()
+
 	for _, d := range r.Deployers {
-		if err := d.CleanUp(ctx, cluster); err != nil {
+		if err := d.CleanUp(ctx, expanded); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
@@ -315,7 +477,21 @@ func (r *Reconciler) updateClusterStatus(ctx context.Context, cluster *v1alpha1.
 	}
 
 	cluster.Status.ClusterPhase = phase
-	cluster.Status.Version = cluster.Spec.Version
+
+	switch phase {
+	case v1alpha1.PhaseStarting, v1alpha1.PhaseUpdating:
+		// The transition time of the condition is the start time of the rollout, which is used to check the progress deadline.
+		cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeProgressing, corev1.ConditionTrue, "RollingOut", "the components are rolling out"))
+	case v1alpha1.PhaseRunning:
+		cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeProgressing, corev1.ConditionFalse, "RolloutCompleted", "all the components are ready"))
+	case v1alpha1.PhaseHibernated:
+		cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeProgressing, corev1.ConditionFalse, "Hibernated", "all the components are scaled to zero"))
+	}
+
+	// During the upgrade, the version is updated when all the components are upgraded.
+	if !cluster.Status.Upgrade.IsInProgress() {
+		cluster.Status.Version = cluster.Spec.Version
+	}
 
 	r.setObservedGeneration(cluster)
 	r.recordNormalEventByPhase(cluster)
@@ -323,6 +499,42 @@ func (r *Reconciler) updateClusterStatus(ctx context.Context, cluster *v1alpha1.
 	return deployers.UpdateStatus(ctx, cluster, r.Client)
 }
 
+// notReadyPhase returns the phase of the cluster when some components are not ready.
+func notReadyPhase(cluster *v1alpha1.GreptimeDBCluster) v1alpha1.Phase {
+	switch cluster.Status.ClusterPhase {
+	case v1alpha1.PhaseRunning:
+		// If the cluster is already running, we will set it to updating phase.
+		return v1alpha1.PhaseUpdating
+	case v1alpha1.PhaseError:
+		// If the cluster is in error phase, we will set it to starting phase.
+		return v1alpha1.PhaseStarting
+	default:
+		return cluster.Status.ClusterPhase
+	}
+}
+
+// maintenanceWindowRequeueAfter returns the duration until the next maintenance window opens if there are pending changes.
+func maintenanceWindowRequeueAfter(cluster *v1alpha1.GreptimeDBCluster) time.Duration {
+	status := cluster.Status.MaintenanceWindow
+	if status == nil || len(status.PendingWorkloads) == 0 || status.NextWindowTime == nil {
+		return 0
+	}
+
+	// Requeue a bit later than the start time to make sure the window is open.
+	return max(time.Until(status.NextWindowTime.Time), 0) + time.Second
+}
+
+// minRequeueAfter returns the minimum non-zero duration.
+func minRequeueAfter(durations ...time.Duration) time.Duration {
+	var result time.Duration
+	for _, d := range durations {
+		if d > 0 && (result == 0 || d < result) {
+			result = d
+		}
+	}
+	return result
+}
+
 func (r *Reconciler) recordNormalEventByPhase(cluster *v1alpha1.GreptimeDBCluster) {
 	switch cluster.Status.ClusterPhase {
 	case v1alpha1.PhaseStarting:
@@ -333,6 +545,8 @@ func (r *Reconciler) recordNormalEventByPhase(cluster *v1alpha1.GreptimeDBCluste
 		r.Recorder.Event(cluster, corev1.EventTypeNormal, "UpdatingCluster", "Cluster is updating")
 	case v1alpha1.PhaseTerminating:
 		r.Recorder.Event(cluster, corev1.EventTypeNormal, "TerminatingCluster", "Cluster is terminating")
+	case v1alpha1.PhaseHibernated:
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "ClusterIsHibernated", "Cluster is hibernated")
 	}
 }
 
@@ -0,0 +1,61 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployers
+
+import (
+	"encoding/json"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/util"
+)
+
+// CanaryRevision returns the revision of the pod template for the canary rollout.
+func CanaryRevision(template *corev1.PodTemplateSpec) string {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return ""
+	}
+	return util.CalculateConfigHash(data)
+}
+
+// canaryRollingUpdate returns the rolling update strategy of the StatefulSet with the partition of the running canary rollout.
+func canaryRollingUpdate(cluster *v1alpha1.GreptimeDBCluster, canary *v1alpha1.CanarySpec, sts *appsv1.StatefulSet) *appsv1.RollingUpdateStatefulSetStrategy {
+	rollingUpdate := sts.Spec.UpdateStrategy.RollingUpdate
+	if canary == nil || sts.Spec.Replicas == nil {
+		return rollingUpdate
+	}
+
+	status := cluster.Status.GetCanary(sts.Name)
+	if !status.IsInProgress() || status.Revision != CanaryRevision(&sts.Spec.Template) {
+		return rollingUpdate
+	}
+
+	partition := *sts.Spec.Replicas - status.UpdatedReplicas
+	if partition < 0 {
+		partition = 0
+	}
+
+	if rollingUpdate == nil {
+		rollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{}
+	} else {
+		rollingUpdate = rollingUpdate.DeepCopy()
+	}
+	rollingUpdate.Partition = &partition
+
+	return rollingUpdate
+}
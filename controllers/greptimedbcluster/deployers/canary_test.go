@@ -0,0 +1,90 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployers
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+)
+
+func TestCanaryRollingUpdate(t *testing.T) {
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-datanode", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To(int32(5)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "datanode", Image: "greptime/greptimedb:v0.2.0"}}},
+			},
+		},
+	}
+	canary := &v1alpha1.CanarySpec{Steps: []int32{1, 3}}
+	revision := CanaryRevision(&sts.Spec.Template)
+
+	tests := []struct {
+		name   string
+		status *v1alpha1.CanaryStatus
+		want   *int32
+	}{
+		{
+			name: "no canary rollout",
+		},
+		{
+			name:   "first step",
+			status: &v1alpha1.CanaryStatus{Revision: revision, Phase: v1alpha1.CanaryPhaseProgressing, Step: 0, UpdatedReplicas: 1},
+			want:   ptr.To(int32(4)),
+		},
+		{
+			name:   "soaking second step",
+			status: &v1alpha1.CanaryStatus{Revision: revision, Phase: v1alpha1.CanaryPhaseSoaking, Step: 1, UpdatedReplicas: 3},
+			want:   ptr.To(int32(2)),
+		},
+		{
+			name:   "completed",
+			status: &v1alpha1.CanaryStatus{Revision: revision, Phase: v1alpha1.CanaryPhaseCompleted, Step: 1, UpdatedReplicas: 5},
+		},
+		{
+			name:   "aborted",
+			status: &v1alpha1.CanaryStatus{Revision: revision, Phase: v1alpha1.CanaryPhaseAborted, Step: 0, UpdatedReplicas: 1},
+		},
+		{
+			name:   "pod template changed during the canary rollout",
+			status: &v1alpha1.CanaryStatus{Revision: "other", Phase: v1alpha1.CanaryPhaseSoaking, Step: 0, UpdatedReplicas: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &v1alpha1.GreptimeDBCluster{}
+			if tt.status != nil {
+				tt.status.StatefulSet = sts.Name
+				cluster.Status.SetCanary(*tt.status)
+			}
+
+			rollingUpdate := canaryRollingUpdate(cluster, canary, sts)
+			switch {
+			case tt.want == nil && rollingUpdate != nil:
+				t.Errorf("expected no partition, got: %+v", rollingUpdate)
+			case tt.want != nil && (rollingUpdate == nil || rollingUpdate.Partition == nil || *rollingUpdate.Partition != *tt.want):
+				t.Errorf("expected the partition %d, got: %+v", *tt.want, rollingUpdate)
+			}
+		})
+	}
+}
@@ -20,11 +20,13 @@ import (
 	"fmt"
 	"io/fs"
 	"text/template"
+	"time"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +37,7 @@ import (
 	"github.com/GreptimeTeam/greptimedb-operator/controllers/greptimedbcluster/deployers/config"
 	"github.com/GreptimeTeam/greptimedb-operator/pkg/dbconfig"
 	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/util"
 )
 
 // CommonDeployer is the common deployer for all components of GreptimeDBCluster.
@@ -43,6 +46,8 @@ type CommonDeployer struct {
 
 	client.Client
 	deployer.DefaultDeployer
+
+	Recorder record.EventRecorder
 }
 
 // NewFromManager creates a new CommonDeployer from controller manager.
@@ -54,6 +59,8 @@ func NewFromManager(mgr ctrl.Manager) *CommonDeployer {
 		DefaultDeployer: deployer.DefaultDeployer{
 			Client: mgr.GetClient(),
 		},
+
+		Recorder: mgr.GetEventRecorderFor("greptimedbcluster-controller"),
 	}
 }
 
@@ -110,6 +117,20 @@ func (c *CommonBuilder) GeneratePodTemplateSpec(template *v1alpha1.PodTemplateSp
 	return common.GeneratePodTemplateSpec(c.RoleKind, template)
 }
 
+// AddRestartedAtAnnotation sets the restartedAt of the component as the pod template annotation to restart the pods when it's changed.
+func (c *CommonBuilder) AddRestartedAtAnnotation(template *corev1.PodTemplateSpec, restartedAt *metav1.Time) {
+	if restartedAt == nil {
+		return
+	}
+
+	template.Annotations = util.MergeStringMap(template.Annotations, map[string]string{deployer.RestartedAt: FormatRestartedAt(restartedAt)})
+}
+
+// FormatRestartedAt returns the value of the restartedAt pod template annotation.
+func FormatRestartedAt(restartedAt *metav1.Time) string {
+	return restartedAt.UTC().Format(time.RFC3339)
+}
+
 func (c *CommonBuilder) GeneratePodMonitor(namespace, resourceName string) (*monitoringv1.PodMonitor, error) {
 	return common.GeneratePodMonitor(namespace, resourceName, c.Cluster.Spec.PrometheusMonitor)
 }
@@ -19,13 +19,15 @@ import (
 	"fmt"
 	"path"
 	"reflect"
+	"strings"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -42,14 +44,18 @@ import (
 type DatanodeDeployer struct {
 	*CommonDeployer
 	maintenanceMode bool
+
+	// metaHTTPServiceURL returns the URL of the metasrv HTTP service to migrate the regions.
+	metaHTTPServiceURL func(cluster *v1alpha1.GreptimeDBCluster) string
 }
 
 var _ deployer.Deployer = &DatanodeDeployer{}
 
 func NewDatanodeDeployer(mgr ctrl.Manager) *DatanodeDeployer {
 	return &DatanodeDeployer{
-		CommonDeployer:  NewFromManager(mgr),
-		maintenanceMode: false,
+		CommonDeployer:     NewFromManager(mgr),
+		maintenanceMode:    false,
+		metaHTTPServiceURL: common.GetMetaHTTPServiceURL,
 	}
 }
 
@@ -65,6 +71,7 @@ func (d *DatanodeDeployer) Generate(crdObject client.Object) ([]client.Object, e
 		BuildConfigMap().
 		BuildStatefulSet().
 		BuildPodMonitor().
+		BuildPodDisruptionBudget().
 		SetControllerAndAnnotation().
 		Generate()
 
@@ -81,11 +88,7 @@ func (d *DatanodeDeployer) CleanUp(ctx context.Context, crdObject client.Object)
 		return err
 	}
 
-	if fs := cluster.GetDatanode().GetFileStorage(); fs != nil {
-		if fs.IsUseEmptyDir() {
-			return nil
-		}
-
+	if fs := cluster.GetDatanode().GetFileStorage(); fs.IsUsePVC() {
 		if fs.GetPolicy() == v1alpha1.StorageRetainPolicyTypeDelete {
 			if err := d.deleteStorage(ctx, cluster.Namespace, common.ResourceName(cluster.Name, v1alpha1.DatanodeRoleKind), common.FileStorageTypeDatanode); err != nil {
 				return err
@@ -94,11 +97,7 @@ func (d *DatanodeDeployer) CleanUp(ctx context.Context, crdObject client.Object)
 	}
 
 	for _, datanodeGroup := range cluster.GetDatanodeGroups() {
-		if fs := datanodeGroup.GetFileStorage(); fs != nil {
-			if fs.IsUseEmptyDir() {
-				continue
-			}
-
+		if fs := datanodeGroup.GetFileStorage(); fs.IsUsePVC() {
 			if fs.GetPolicy() == v1alpha1.StorageRetainPolicyTypeDelete {
 				if err := d.deleteStorage(ctx, cluster.Namespace, common.ResourceName(cluster.Name, v1alpha1.DatanodeRoleKind, datanodeGroup.GetName()), common.FileStorageTypeDatanode); err != nil {
 					return err
@@ -128,6 +127,11 @@ func (d *DatanodeDeployer) CheckAndUpdateStatus(ctx context.Context, crdObject c
 		return false, err
 	}
 
+	// Refresh the progress of expanding the PVCs, and it will be updated with the replicas.
+	if _, err := common.UpdatePVCResizeStatus(ctx, d.Client, cluster.Namespace, cluster.Status.Datanode.PVCResizes); err != nil {
+		return false, err
+	}
+
 	if cluster.GetDatanode() != nil {
 		return d.checkDatanodeStatus(ctx, cluster.Namespace, common.ResourceName(cluster.Name, v1alpha1.DatanodeRoleKind), cluster)
 	}
@@ -140,6 +144,7 @@ func (d *DatanodeDeployer) CheckAndUpdateStatus(ctx context.Context, crdObject c
 }
 
 // Apply is re-implemented for datanode to handle the maintenance mode.
+// The pod template changes are held outside the maintenance windows only if the pods need to be restarted.
 func (d *DatanodeDeployer) Apply(ctx context.Context, crdObject client.Object, objects []client.Object) error {
 	updateObject := false
 
@@ -148,6 +153,14 @@ func (d *DatanodeDeployer) Apply(ctx context.Context, crdObject client.Object, o
 		return err
 	}
 
+	if err := d.holdDisruptiveChanges(ctx, cluster, objects, d.isOldPodRestart); err != nil {
+		return err
+	}
+
+	if err := d.keepStorageSizes(ctx, cluster, objects); err != nil {
+		return err
+	}
+
 	for _, newObject := range objects {
 		oldObject, err := k8sutils.CreateObjectIfNotExist(ctx, d.Client, k8sutils.SourceObject(newObject), newObject)
 		if err != nil {
@@ -164,11 +177,33 @@ func (d *DatanodeDeployer) Apply(ctx context.Context, crdObject client.Object, o
 
 			// If the spec or labels is not equal, update the object.
 			if !specEqual || !labelsEqual {
+				if sts, ok := newObject.(*appsv1.StatefulSet); ok {
+					recreated, err := d.expandPVCs(ctx, cluster, sts)
+					if err != nil {
+						return err
+					}
+
+					// The StatefulSet will be recreated with the expanded volume claim templates in the next reconciliation.
+					if recreated {
+						return deployer.ErrSyncNotReady
+					}
+
+					migrated, err := d.migrateRegionsBeforeScaleIn(ctx, sts, cluster)
+					if err != nil {
+						return err
+					}
+
+					// The replicas will not be decreased until all the regions are migrated off the datanodes that will be removed.
+					if !migrated {
+						return deployer.ErrSyncNotReady
+					}
+				}
 				if sts, ok := newObject.(*appsv1.StatefulSet); ok && d.shouldUseMaintenanceMode(cluster) {
 					if err := d.turnOnMaintenanceMode(ctx, sts, cluster); err != nil {
 						return err
 					}
 				}
+				deployer.SetPreviousAppliedResourceSpec(oldObject, newObject)
 				if err := d.Patch(ctx, newObject, client.MergeFrom(oldObject)); err != nil {
 					return err
 				}
@@ -304,6 +339,158 @@ func (d *DatanodeDeployer) turnOffMaintenanceMode(ctx context.Context, crdObject
 	return nil
 }
 
+// migrateRegionsBeforeScaleIn migrates the regions off the datanodes that will be removed when the replicas of the StatefulSet is decreased.
+// It returns true if there is no region on the datanodes that will be removed.
+func (d *DatanodeDeployer) migrateRegionsBeforeScaleIn(ctx context.Context, newSts *appsv1.StatefulSet, cluster *v1alpha1.GreptimeDBCluster) (bool, error) {
+	oldSts := new(appsv1.StatefulSet)
+	// The oldSts must exist since we have checked it before.
+	if err := d.Get(ctx, client.ObjectKeyFromObject(newSts), oldSts); err != nil {
+		return false, err
+	}
+
+	var (
+		oldReplicas = ptr.Deref(oldSts.Spec.Replicas, 1)
+		newReplicas = ptr.Deref(newSts.Spec.Replicas, 1)
+	)
+
+	// The StatefulSet is not scaling in, or the scale in is canceled.
+	if newReplicas >= oldReplicas {
+		if cluster.Status.Datanode.RemoveScaleIn(newSts.Name) {
+			return true, UpdateStatus(ctx, cluster, d.Client)
+		}
+		return true, nil
+	}
+
+	groupID, spec := datanodeSpecOfStatefulSet(cluster, newSts.Name)
+	if spec == nil {
+		return true, nil
+	}
+
+	// The regions are only migrated to the remaining datanodes of the same StatefulSet,
+	// since the datanodes of the other groups may be configured differently.
+	var removedNodeIDs, remainingNodeIDs []uint64
+	for ordinal := int32(0); ordinal < oldReplicas; ordinal++ {
+		if ordinal < newReplicas {
+			remainingNodeIDs = append(remainingNodeIDs, DatanodeNodeID(groupID, spec, ordinal))
+		} else {
+			removedNodeIDs = append(removedNodeIDs, DatanodeNodeID(groupID, spec, ordinal))
+		}
+	}
+
+	metaHTTPServiceURL := d.metaHTTPServiceURL(cluster)
+	regions, err := common.GetDatanodeRegions(metaHTTPServiceURL)
+	if err != nil {
+		return false, err
+	}
+
+	status := cluster.Status.Datanode.GetScaleIn(newSts.Name)
+	if status == nil || status.Replicas != newReplicas {
+		klog.Infof("Scale in datanode statefulset '%s/%s' from %d to %d replicas, migrating the regions off the datanodes %v",
+			newSts.Namespace, newSts.Name, oldReplicas, newReplicas, removedNodeIDs)
+		status = &v1alpha1.DatanodeScaleInStatus{
+			StatefulSet:    newSts.Name,
+			Replicas:       newReplicas,
+			RemovedNodeIDs: removedNodeIDs,
+			StartTime:      ptr.To(metav1.Now()),
+		}
+	}
+
+	migrations, err := common.PlanRegionMigrations(regions, removedNodeIDs, remainingNodeIDs)
+	if err != nil {
+		return false, err
+	}
+
+	if len(migrations) == 0 {
+		klog.Infof("All the regions are migrated off the datanodes %v of statefulset '%s/%s'", removedNodeIDs, newSts.Namespace, newSts.Name)
+		cluster.Status.Datanode.RemoveScaleIn(newSts.Name)
+		return true, UpdateStatus(ctx, cluster, d.Client)
+	}
+
+	for _, migration := range migrations {
+		// The migration is submitted and is still running.
+		if submitted := status.GetMigration(migration.RegionID); submitted != nil && submitted.FromPeerID == migration.FromPeerID &&
+			submitted.SubmitTime != nil && time.Since(submitted.SubmitTime.Time) < common.RegionMigrationTimeout {
+			continue
+		}
+
+		procedureID, err := common.SubmitRegionMigration(metaHTTPServiceURL, migration)
+		if err != nil {
+			return false, err
+		}
+
+		status.SetMigration(v1alpha1.RegionMigrationStatus{
+			RegionID:    migration.RegionID,
+			FromPeerID:  migration.FromPeerID,
+			ToPeerID:    migration.ToPeerID,
+			ProcedureID: procedureID,
+			SubmitTime:  ptr.To(metav1.Now()),
+		})
+	}
+
+	status.PendingRegions = int32(len(migrations))
+	cluster.Status.Datanode.SetScaleIn(*status)
+
+	return false, UpdateStatus(ctx, cluster, d.Client)
+}
+
+// keepStorageSizes skips the decreases of the storage sizes of the datanode PVCs instead of failing the sync, since the PVCs can't be shrunk.
+// The skipped decreases are reported by a Warning event and the StorageShrinkRejected condition.
+func (d *DatanodeDeployer) keepStorageSizes(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, objects []client.Object) error {
+	var skipped []string
+	for _, object := range objects {
+		sts, ok := object.(*appsv1.StatefulSet)
+		if !ok {
+			continue
+		}
+
+		messages, err := common.KeepStorageSizes(ctx, d.Client, sts)
+		if err != nil {
+			return err
+		}
+		skipped = append(skipped, messages...)
+	}
+
+	condition := cluster.Status.GetCondition(v1alpha1.ConditionTypeStorageShrinkRejected)
+	if len(skipped) == 0 {
+		if condition == nil || condition.Status != corev1.ConditionTrue {
+			return nil
+		}
+		cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeStorageShrinkRejected, corev1.ConditionFalse,
+			"StorageNotDecreased", "the storage sizes are not decreased"))
+		return UpdateStatus(ctx, cluster, d.Client)
+	}
+
+	message := strings.Join(skipped, "; ")
+	if condition != nil && condition.Status == corev1.ConditionTrue && condition.Message == message {
+		return nil
+	}
+
+	d.Recorder.Event(cluster, corev1.EventTypeWarning, "StorageShrinkRejected", message)
+	cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeStorageShrinkRejected, corev1.ConditionTrue,
+		"StorageShrinkRejected", message))
+
+	return UpdateStatus(ctx, cluster, d.Client)
+}
+
+// expandPVCs expands or modifies the datanode PVCs if the volume claim templates are changed, and records the resize progress in the status.
+// It returns true if the StatefulSet is deleted to be recreated with the new volume claim templates.
+func (d *DatanodeDeployer) expandPVCs(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, newSts *appsv1.StatefulSet) (bool, error) {
+	oldSts := new(appsv1.StatefulSet)
+	// The oldSts must exist since we have checked it before.
+	if err := d.Get(ctx, client.ObjectKeyFromObject(newSts), oldSts); err != nil {
+		return false, err
+	}
+
+	statuses, recreated, err := common.ExpandStatefulSetPVCs(ctx, d.Client, oldSts, newSts)
+	if err != nil || !recreated {
+		return false, err
+	}
+
+	cluster.Status.Datanode.PVCResizes = common.MergePVCResizeStatus(cluster.Status.Datanode.PVCResizes, statuses)
+
+	return true, UpdateStatus(ctx, cluster, d.Client)
+}
+
 func (d *DatanodeDeployer) deleteStorage(ctx context.Context, namespace, resourceName string, fsType common.FileStorageType) error {
 	klog.Infof("Deleting datanode storage...")
 
@@ -338,10 +525,11 @@ func (d *DatanodeDeployer) isOldPodRestart(new, old appsv1.StatefulSet) bool {
 		return true
 	}
 
-	// If the tolerations, affinity, nodeSelector are changed, the original Pod may need to be restarted for re-scheduling.
+	// If the tolerations, affinity, nodeSelector, topologySpreadConstraints are changed, the original Pod may need to be restarted for re-scheduling.
 	if !reflect.DeepEqual(newPodTemplate.Spec.Tolerations, oldPodTemplate.Spec.Tolerations) ||
 		!reflect.DeepEqual(newPodTemplate.Spec.Affinity, oldPodTemplate.Spec.Affinity) ||
-		!reflect.DeepEqual(newPodTemplate.Spec.NodeSelector, oldPodTemplate.Spec.NodeSelector) {
+		!reflect.DeepEqual(newPodTemplate.Spec.NodeSelector, oldPodTemplate.Spec.NodeSelector) ||
+		!reflect.DeepEqual(newPodTemplate.Spec.TopologySpreadConstraints, oldPodTemplate.Spec.TopologySpreadConstraints) {
 		return true
 	}
 
@@ -368,6 +556,36 @@ func (d *DatanodeDeployer) shouldUseMaintenanceMode(cluster *v1alpha1.GreptimeDB
 	return cluster.GetMeta().IsEnableRegionFailover()
 }
 
+// datanodeSpecOfStatefulSet returns the group id and the spec of the datanode StatefulSet.
+func datanodeSpecOfStatefulSet(cluster *v1alpha1.GreptimeDBCluster, name string) (*int32, *v1alpha1.DatanodeSpec) {
+	if spec := cluster.GetDatanode(); spec != nil && common.ResourceName(cluster.Name, v1alpha1.DatanodeRoleKind, spec.GetName()) == name {
+		return nil, spec
+	}
+
+	for i, spec := range cluster.GetDatanodeGroups() {
+		if common.ResourceName(cluster.Name, v1alpha1.DatanodeRoleKind, spec.GetName()) == name {
+			groupID := int32(i)
+			return &groupID, spec
+		}
+	}
+
+	return nil, nil
+}
+
+// DatanodeNodeID returns the node id of the datanode pod, which is the same as the one allocated by the initializer.
+func DatanodeNodeID(groupID *int32, spec *v1alpha1.DatanodeSpec, ordinal int32) uint64 {
+	nodeID := uint64(ordinal)
+	if groupID != nil {
+		nodeID = uint64(*groupID)<<32 | nodeID
+	}
+
+	if spec.GetStartNodeID() != nil {
+		nodeID += uint64(*spec.GetStartNodeID())
+	}
+
+	return nodeID
+}
+
 var _ deployer.Builder = &datanodeBuilder{}
 
 type datanodeBuilder struct {
@@ -503,6 +721,26 @@ func (b *datanodeBuilder) BuildPodMonitor() deployer.Builder {
 	return b
 }
 
+func (b *datanodeBuilder) BuildPodDisruptionBudget() deployer.Builder {
+	if b.Err != nil {
+		return b
+	}
+
+	datanodes := b.Cluster.GetDatanodeGroups()
+	if b.Cluster.GetDatanode() != nil {
+		datanodes = []*v1alpha1.DatanodeSpec{b.Cluster.GetDatanode()}
+	}
+
+	for _, datanodeSpec := range datanodes {
+		if datanodeSpec.GetPodDisruptionBudget().IsEnabled() {
+			b.Objects = append(b.Objects, common.GeneratePodDisruptionBudget(b.Cluster.Namespace,
+				common.ResourceName(b.Cluster.Name, b.RoleKind, datanodeSpec.GetName()), datanodeSpec.GetPodDisruptionBudget()))
+		}
+	}
+
+	return b
+}
+
 func (b *datanodeBuilder) generateDatanodeStatefulSet(groupID *int32, spec *v1alpha1.DatanodeSpec) (*appsv1.StatefulSet, error) {
 	resourceName := common.ResourceName(b.Cluster.Name, b.RoleKind, spec.GetName())
 
@@ -535,8 +773,9 @@ func (b *datanodeBuilder) generateDatanodeStatefulSet(groupID *int32, spec *v1al
 		},
 	}
 
-	if !spec.GetFileStorage().IsUseEmptyDir() {
-		sts.Spec.VolumeClaimTemplates = b.generatePVCs(spec)
+	sts.Spec.VolumeClaimTemplates = b.generatePVCs(spec)
+	if len(sts.Spec.VolumeClaimTemplates) > 0 {
+		sts.Spec.PersistentVolumeClaimRetentionPolicy = common.PVCRetentionPolicy(spec.GetFileStorage())
 	}
 
 	configData, err := dbconfig.FromCluster(b.Cluster, spec)
@@ -546,6 +785,10 @@ func (b *datanodeBuilder) generateDatanodeStatefulSet(groupID *int32, spec *v1al
 
 	sts.Spec.Template.Annotations = util.MergeStringMap(sts.Spec.Template.Annotations,
 		map[string]string{deployer.ConfigHash: util.CalculateConfigHash(configData)})
+	b.AddRestartedAtAnnotation(&sts.Spec.Template, spec.GetRestartedAt())
+
+	// The partition is set after the pod template is completed because the canary rollout is identified by the pod template.
+	sts.Spec.UpdateStrategy.RollingUpdate = canaryRollingUpdate(b.Cluster, spec.GetCanary(), sts)
 
 	return sts, nil
 }
@@ -602,9 +845,7 @@ func (b *datanodeBuilder) generatePodTemplateSpec(spec *v1alpha1.DatanodeSpec, g
 	b.addVolumeMounts(podTemplateSpec, spec)
 	b.addInitConfigDirVolume(podTemplateSpec, common.ResourceName(b.Cluster.Name, b.RoleKind, spec.GetName()))
 
-	if spec.GetFileStorage().IsUseEmptyDir() {
-		b.addEmptyDirAsStorage(podTemplateSpec, spec)
-	}
+	b.addEphemeralStorage(podTemplateSpec, spec)
 
 	if logging := spec.GetLogging(); logging != nil &&
 		!logging.IsOnlyLogToStdout() && !logging.IsPersistentWithData() {
@@ -621,24 +862,82 @@ func (b *datanodeBuilder) generatePodTemplateSpec(spec *v1alpha1.DatanodeSpec, g
 		constant.GreptimeDBComponentName: common.ResourceName(b.Cluster.Name, b.RoleKind, spec.GetName()),
 	})
 
+	if zone := spec.GetZone(); zone != "" {
+		b.addZoneAffinity(podTemplateSpec, spec, zone)
+	}
+
 	return *podTemplateSpec
 }
 
+// addZoneAffinity schedules the datanodes to the nodes in the zone, and spreads them across the hosts if the pod anti-affinity is not set.
+func (b *datanodeBuilder) addZoneAffinity(template *corev1.PodTemplateSpec, spec *v1alpha1.DatanodeSpec, zone string) {
+	// The affinity is shared with the spec of the datanode.
+	affinity := template.Spec.Affinity.DeepCopy()
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+	}
+
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelTopologyZone,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{zone},
+	}
+
+	// The node selector terms are ORed, so the zone requirement is added to each of them.
+	if selector := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; selector != nil && len(selector.NodeSelectorTerms) > 0 {
+		for i := range selector.NodeSelectorTerms {
+			selector.NodeSelectorTerms[i].MatchExpressions = append(selector.NodeSelectorTerms[i].MatchExpressions, requirement)
+		}
+	} else {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{
+					MatchExpressions: []corev1.NodeSelectorRequirement{requirement},
+				},
+			},
+		}
+	}
+
+	if affinity.PodAntiAffinity == nil {
+		affinity.PodAntiAffinity = &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								constant.GreptimeDBComponentName: common.ResourceName(b.Cluster.Name, b.RoleKind, spec.GetName()),
+							},
+						},
+						TopologyKey: corev1.LabelHostname,
+					},
+				},
+			},
+		}
+	}
+
+	template.Spec.Affinity = affinity
+}
+
 func (b *datanodeBuilder) generatePVCs(spec *v1alpha1.DatanodeSpec) []corev1.PersistentVolumeClaim {
 	var claims []corev1.PersistentVolumeClaim
 
 	// It's always not nil because it's the default value.
-	if fs := spec.GetFileStorage(); fs != nil {
+	if fs := spec.GetFileStorage(); fs.IsUsePVC() {
 		claims = append(claims, *common.FileStorageToPVC(b.Cluster.Name, spec.GetName(), fs, common.FileStorageTypeDatanode, v1alpha1.DatanodeRoleKind))
 	}
 
 	// Allocate the standalone WAL storage for the raft-engine.
-	if fs := b.Cluster.GetWALProvider().GetRaftEngineWAL().GetFileStorage(); fs != nil {
+	if fs := b.Cluster.GetWALProvider().GetRaftEngineWAL().GetFileStorage(); fs.IsUsePVC() {
 		claims = append(claims, *common.FileStorageToPVC(b.Cluster.Name, spec.GetName(), fs, common.FileStorageTypeWAL, v1alpha1.DatanodeRoleKind))
 	}
 
 	// Allocate the standalone cache file storage for the datanode.
-	if fs := b.Cluster.GetObjectStorageProvider().GetCacheFileStorage(); fs != nil {
+	if fs := b.Cluster.GetObjectStorageProvider().GetCacheFileStorage(); fs.IsUsePVC() {
 		claims = append(claims, *common.FileStorageToPVC(b.Cluster.Name, spec.GetName(), fs, common.FileStorageTypeCache, v1alpha1.DatanodeRoleKind))
 	}
 
@@ -681,6 +980,10 @@ func (b *datanodeBuilder) generateInitializer(spec *v1alpha1.DatanodeSpec, group
 		initializer.Args = append(initializer.Args, "--start-node-id", fmt.Sprintf("%d", *spec.GetStartNodeID()))
 	}
 
+	if zone := spec.GetZone(); zone != "" {
+		initializer.Args = append(initializer.Args, "--zone", zone)
+	}
+
 	return initializer
 }
 
@@ -749,16 +1052,22 @@ func (b *datanodeBuilder) addInitConfigDirVolume(template *corev1.PodTemplateSpe
 	})
 }
 
-func (b *datanodeBuilder) addEmptyDirAsStorage(template *corev1.PodTemplateSpec, spec *v1alpha1.DatanodeSpec) {
-	storageSize := resource.MustParse(spec.GetFileStorage().GetSize())
-	template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
-		Name: spec.GetFileStorage().GetName(),
-		VolumeSource: corev1.VolumeSource{
-			EmptyDir: &corev1.EmptyDirVolumeSource{
-				SizeLimit: &storageSize,
-			},
-		},
-	})
+// addEphemeralStorage adds the volumes of the datanode, WAL and cache storage that use the empty dir or the generic ephemeral volume.
+func (b *datanodeBuilder) addEphemeralStorage(template *corev1.PodTemplateSpec, spec *v1alpha1.DatanodeSpec) {
+	storages := []struct {
+		fs     *v1alpha1.FileStorage
+		fsType common.FileStorageType
+	}{
+		{spec.GetFileStorage(), common.FileStorageTypeDatanode},
+		{b.Cluster.GetWALProvider().GetRaftEngineWAL().GetFileStorage(), common.FileStorageTypeWAL},
+		{b.Cluster.GetObjectStorageProvider().GetCacheFileStorage(), common.FileStorageTypeCache},
+	}
+
+	for _, storage := range storages {
+		if volume := common.FileStorageToVolume(b.Cluster.Name, spec.GetName(), storage.fs, storage.fsType, v1alpha1.DatanodeRoleKind); volume != nil {
+			template.Spec.Volumes = append(template.Spec.Volumes, *volume)
+		}
+	}
 }
 
 func (b *datanodeBuilder) servicePorts(spec *v1alpha1.DatanodeSpec) []corev1.ServicePort {
@@ -0,0 +1,400 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+)
+
+func TestBuildZonedDatanodes(t *testing.T) {
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			Base: &v1alpha1.PodTemplateSpec{
+				MainContainer: &v1alpha1.MainContainerSpec{Image: "greptime/greptimedb:latest"},
+			},
+			Meta: &v1alpha1.MetaSpec{},
+			Datanode: &v1alpha1.DatanodeSpec{
+				ComponentSpec: v1alpha1.ComponentSpec{
+					Replicas: ptr.To(int32(2)),
+					Template: &v1alpha1.PodTemplateSpec{
+						SlimPodSpec: v1alpha1.SlimPodSpec{
+							Affinity: &corev1.Affinity{
+								NodeAffinity: &corev1.NodeAffinity{
+									RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+										NodeSelectorTerms: []corev1.NodeSelectorTerm{
+											{
+												MatchExpressions: []corev1.NodeSelectorRequirement{
+													{Key: "node-role", Operator: corev1.NodeSelectorOpIn, Values: []string{"datanode"}},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Zones: []string{"zone-a", "zone-b"},
+			},
+		},
+	}
+
+	if err := cluster.SetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cluster.MergeWithBaseTemplate(); err != nil {
+		t.Fatal(err)
+	}
+	cluster.ExpandDatanodeZones()
+
+	d := &DatanodeDeployer{CommonDeployer: &CommonDeployer{}}
+	objects, err := d.NewBuilder(cluster).BuildStatefulSet().Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 2 {
+		t.Fatalf("expected 2 statefulsets for the zones, got: %d", len(objects))
+	}
+
+	for i, zone := range []string{"zone-a", "zone-b"} {
+		sts := objects[i].(*appsv1.StatefulSet)
+		if sts.Name != "test-datanode-"+zone || *sts.Spec.Replicas != 2 {
+			t.Errorf("unexpected statefulset '%s' with %d replicas", sts.Name, *sts.Spec.Replicas)
+		}
+
+		affinity := sts.Spec.Template.Spec.Affinity
+		terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		if len(terms) != 1 || len(terms[0].MatchExpressions) != 2 ||
+			terms[0].MatchExpressions[1].Key != corev1.LabelTopologyZone || terms[0].MatchExpressions[1].Values[0] != zone {
+			t.Errorf("expected the zone requirement to be added to the node selector term, got: %+v", terms)
+		}
+
+		if affinity.PodAntiAffinity == nil || affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm.TopologyKey != corev1.LabelHostname {
+			t.Errorf("expected the datanodes to be spread across the hosts, got: %+v", affinity.PodAntiAffinity)
+		}
+
+		if args := sts.Spec.Template.Spec.InitContainers[0].Args; !slices.Contains(args, zone) {
+			t.Errorf("expected the zone to be passed to the initializer, got: %v", args)
+		}
+	}
+
+	// The affinity of the datanode spec is not changed.
+	if terms := cluster.Spec.DatanodeGroups[0].Template.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms; len(terms[0].MatchExpressions) != 1 {
+		t.Errorf("expected the affinity of the datanode spec not to be changed, got: %+v", terms)
+	}
+}
+
+func TestKeepStorageSizes(t *testing.T) {
+	newSts := func(size string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-datanode", Namespace: "default"},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To(int32(1)),
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "datanode"},
+						Spec: corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.VolumeResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	cluster := &v1alpha1.GreptimeDBCluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	d := &DatanodeDeployer{
+		CommonDeployer: &CommonDeployer{
+			Client: fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(cluster, newSts("10Gi")).
+				WithStatusSubresource(cluster).
+				Build(),
+			Recorder: recorder,
+		},
+	}
+
+	// The storage size is decreased with the replicas, and only the decrease is skipped.
+	sts := newSts("5Gi")
+	sts.Spec.Replicas = ptr.To(int32(3))
+	if err := d.keepStorageSizes(context.Background(), cluster, []client.Object{sts}); err != nil {
+		t.Fatal(err)
+	}
+
+	if size := sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "10Gi" {
+		t.Errorf("expected the storage size to be kept as 10Gi, got: %s", size.String())
+	}
+	if replicas := *sts.Spec.Replicas; replicas != 3 {
+		t.Errorf("expected the replicas to be applied, got: %d", replicas)
+	}
+	if condition := cluster.Status.GetCondition(v1alpha1.ConditionTypeStorageShrinkRejected); condition == nil || condition.Status != corev1.ConditionTrue {
+		t.Errorf("expected the StorageShrinkRejected condition to be true, got: %v", condition)
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("expected a warning event, got: %d", len(recorder.Events))
+	}
+
+	// The same decrease is not reported again.
+	if err := d.keepStorageSizes(context.Background(), cluster, []client.Object{newSts("5Gi")}); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("expected no more event, got: %d", len(recorder.Events))
+	}
+
+	// The condition is cleared after the decrease is reverted.
+	if err := d.keepStorageSizes(context.Background(), cluster, []client.Object{newSts("10Gi")}); err != nil {
+		t.Fatal(err)
+	}
+	if condition := cluster.Status.GetCondition(v1alpha1.ConditionTypeStorageShrinkRejected); condition.Status != corev1.ConditionFalse {
+		t.Errorf("expected the StorageShrinkRejected condition to be false, got: %v", condition)
+	}
+}
+
+func TestMigrateRegionsBeforeScaleIn(t *testing.T) {
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			Meta: &v1alpha1.MetaSpec{HTTPPort: 4000},
+			DatanodeGroups: []*v1alpha1.DatanodeSpec{
+				{Name: "a", ComponentSpec: v1alpha1.ComponentSpec{Replicas: ptr.To(int32(1))}},
+				{Name: "b", ComponentSpec: v1alpha1.ComponentSpec{Replicas: ptr.To(int32(2))}},
+			},
+		},
+	}
+
+	newSts := func(replicas int32) *appsv1.StatefulSet {
+		return newAppliedStatefulSet(t, "test-datanode-a", replicas)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	// The datanodes of the group 'b' have the node ids starting from 1<<32 and no region.
+	var (
+		heartbeat string
+		submitted []string
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin/heartbeat", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, heartbeat)
+	})
+	mux.HandleFunc("/admin/region-migration", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		submitted = append(submitted, fmt.Sprintf("%s:%s->%s", query.Get("region_id"), query.Get("from_peer_id"), query.Get("to_peer_id")))
+		fmt.Fprintf(w, `{"procedure_id": "procedure-%d"}`, len(submitted))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	d := &DatanodeDeployer{
+		CommonDeployer: &CommonDeployer{
+			Client: fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(cluster, newSts(3)).
+				WithStatusSubresource(cluster).
+				Build(),
+			Recorder: record.NewFakeRecorder(10),
+		},
+		metaHTTPServiceURL: func(_ *v1alpha1.GreptimeDBCluster) string {
+			return server.URL
+		},
+	}
+
+	ctx := context.Background()
+	apply := func() error {
+		t.Helper()
+		return d.Apply(ctx, cluster, []client.Object{newSts(1)})
+	}
+	replicas := func() int32 {
+		t.Helper()
+		sts := new(appsv1.StatefulSet)
+		if err := d.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-datanode-a"}, sts); err != nil {
+			t.Fatal(err)
+		}
+		return *sts.Spec.Replicas
+	}
+
+	// Scale in the group 'a' from 3 to 1 replicas, the regions are only migrated to the remaining datanode of the group.
+	heartbeat = `[
+  {"stats": [{"id": 0, "addr": "datanode-a-0", "region_stats": [{"id": 1, "role": "Leader"}]}]},
+  {"stats": [{"id": 1, "addr": "datanode-a-1", "region_stats": [{"id": 2, "role": "Leader"}, {"id": 3, "role": "Leader"}]}]},
+  {"stats": [{"id": 2, "addr": "datanode-a-2", "region_stats": [{"id": 4, "role": "Leader"}]}]},
+  {"stats": [{"id": 4294967296, "addr": "datanode-b-0", "region_stats": []}]},
+  {"stats": [{"id": 4294967297, "addr": "datanode-b-1", "region_stats": []}]}
+]`
+	if err := apply(); !errors.Is(err, deployer.ErrSyncNotReady) {
+		t.Fatalf("expected the sync to wait for the region migrations, got: %v", err)
+	}
+
+	wantSubmitted := []string{"2:1->0", "3:1->0", "4:2->0"}
+	if !reflect.DeepEqual(submitted, wantSubmitted) {
+		t.Errorf("unexpected region migrations, want: %v, got: %v", wantSubmitted, submitted)
+	}
+	if got := replicas(); got != 3 {
+		t.Errorf("expected the replicas not to be decreased before the regions are migrated, got: %d", got)
+	}
+	if status := cluster.Status.Datanode.GetScaleIn("test-datanode-a"); status == nil || status.PendingRegions != 3 ||
+		!reflect.DeepEqual(status.RemovedNodeIDs, []uint64{1, 2}) {
+		t.Errorf("unexpected scale in status: %+v", status)
+	}
+
+	// The running migrations are not submitted again.
+	if err := apply(); !errors.Is(err, deployer.ErrSyncNotReady) {
+		t.Fatalf("expected the sync to wait for the region migrations, got: %v", err)
+	}
+	if len(submitted) != 3 {
+		t.Errorf("expected the running migrations not to be submitted again, got: %v", submitted)
+	}
+
+	// The replicas are decreased after all the regions are migrated.
+	heartbeat = `[
+  {"stats": [{"id": 0, "addr": "datanode-a-0", "region_stats": [{"id": 1, "role": "Leader"}, {"id": 2, "role": "Leader"}, {"id": 3, "role": "Leader"}, {"id": 4, "role": "Leader"}]}]},
+  {"stats": [{"id": 1, "addr": "datanode-a-1", "region_stats": []}]},
+  {"stats": [{"id": 2, "addr": "datanode-a-2", "region_stats": []}]},
+  {"stats": [{"id": 4294967296, "addr": "datanode-b-0", "region_stats": []}]},
+  {"stats": [{"id": 4294967297, "addr": "datanode-b-1", "region_stats": []}]}
+]`
+	if err := apply(); !errors.Is(err, deployer.ErrSyncNotReady) {
+		t.Fatalf("expected the sync to wait for the updated statefulset, got: %v", err)
+	}
+	if got := replicas(); got != 1 {
+		t.Errorf("expected the replicas to be decreased to 1, got: %d", got)
+	}
+	if status := cluster.Status.Datanode.GetScaleIn("test-datanode-a"); status != nil {
+		t.Errorf("expected the scale in status to be removed, got: %+v", status)
+	}
+}
+
+func TestMigrateRegionsBeforeScaleInWithoutTargets(t *testing.T) {
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			Meta: &v1alpha1.MetaSpec{HTTPPort: 4000},
+			DatanodeGroups: []*v1alpha1.DatanodeSpec{
+				{Name: "a", ComponentSpec: v1alpha1.ComponentSpec{Replicas: ptr.To(int32(0))}},
+				{Name: "b", ComponentSpec: v1alpha1.ComponentSpec{Replicas: ptr.To(int32(1))}},
+			},
+		},
+	}
+
+	sts := newAppliedStatefulSet(t, "test-datanode-a", 1)
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	var submitted int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin/heartbeat", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+  {"stats": [{"id": 0, "addr": "datanode-a-0", "region_stats": [{"id": 1, "role": "Leader"}]}]},
+  {"stats": [{"id": 4294967296, "addr": "datanode-b-0", "region_stats": []}]}
+]`)
+	})
+	mux.HandleFunc("/admin/region-migration", func(w http.ResponseWriter, r *http.Request) {
+		submitted++
+		fmt.Fprint(w, `{"procedure_id": "procedure"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	d := &DatanodeDeployer{
+		CommonDeployer: &CommonDeployer{
+			Client: fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(cluster, sts.DeepCopy()).
+				WithStatusSubresource(cluster).
+				Build(),
+			Recorder: record.NewFakeRecorder(10),
+		},
+		metaHTTPServiceURL: func(_ *v1alpha1.GreptimeDBCluster) string {
+			return server.URL
+		},
+	}
+
+	// The regions of the group 'a' can't be migrated to the datanodes of the group 'b' when the group is scaled in to 0.
+	if err := d.Apply(context.Background(), cluster, []client.Object{newAppliedStatefulSet(t, "test-datanode-a", 0)}); err == nil || errors.Is(err, deployer.ErrSyncNotReady) {
+		t.Fatalf("expected an error when no datanode of the group is left, got: %v", err)
+	}
+	if submitted != 0 {
+		t.Errorf("expected no region migration to be submitted, got: %d", submitted)
+	}
+
+	current := new(appsv1.StatefulSet)
+	if err := d.Get(context.Background(), client.ObjectKeyFromObject(sts), current); err != nil {
+		t.Fatal(err)
+	}
+	if *current.Spec.Replicas != 1 {
+		t.Errorf("expected the replicas not to be decreased, got: %d", *current.Spec.Replicas)
+	}
+}
+
+// newAppliedStatefulSet returns the StatefulSet with the last applied spec annotation as it's generated by the deployer.
+func newAppliedStatefulSet(t *testing.T, name string, replicas int32) *appsv1.StatefulSet {
+	sts := &appsv1.StatefulSet{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(replicas)},
+	}
+	data, err := json.Marshal(sts.Spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sts.Annotations = map[string]string{deployer.LastAppliedResourceSpec: string(data)}
+	return sts
+}
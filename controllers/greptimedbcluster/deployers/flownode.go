@@ -61,6 +61,7 @@ func (d *FlownodeDeployer) Generate(crdObject client.Object) ([]client.Object, e
 		BuildConfigMap().
 		BuildStatefulSet().
 		BuildPodMonitor().
+		BuildPodDisruptionBudget().
 		SetControllerAndAnnotation().
 		Generate()
 
@@ -82,29 +83,54 @@ func (d *FlownodeDeployer) CheckAndUpdateStatus(ctx context.Context, crdObject c
 	}
 
 	var (
-		sts = new(appsv1.StatefulSet)
+		replicas      int32
+		readyReplicas int32
+		readyCount    int
+		specs         = flownodeSpecs(cluster)
+	)
 
-		objectKey = client.ObjectKey{
+	for _, spec := range specs {
+		sts := new(appsv1.StatefulSet)
+		objectKey := client.ObjectKey{
 			Namespace: cluster.Namespace,
-			Name:      common.ResourceName(cluster.Name, v1alpha1.FlownodeRoleKind),
+			Name:      common.ResourceName(cluster.Name, v1alpha1.FlownodeRoleKind, spec.GetName()),
 		}
-	)
 
-	err = d.Get(ctx, objectKey, sts)
-	if errors.IsNotFound(err) {
-		return false, nil
-	}
-	if err != nil {
-		return false, err
+		err = d.Get(ctx, objectKey, sts)
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		replicas += *sts.Spec.Replicas
+		readyReplicas += sts.Status.ReadyReplicas
+		if k8sutil.IsStatefulSetReady(sts) {
+			readyCount++
+		}
 	}
 
-	cluster.Status.Flownode.Replicas = *sts.Spec.Replicas
-	cluster.Status.Flownode.ReadyReplicas = sts.Status.ReadyReplicas
+	cluster.Status.Flownode.Replicas = replicas
+	cluster.Status.Flownode.ReadyReplicas = readyReplicas
 	if err := UpdateStatus(ctx, cluster, d.Client); err != nil {
 		klog.Errorf("Failed to update status: %s", err)
 	}
 
-	return k8sutil.IsStatefulSetReady(sts), nil
+	return readyCount == len(specs), nil
+}
+
+// flownodeSpecs returns the flownode groups, or the flownode if no group is set.
+func flownodeSpecs(cluster *v1alpha1.GreptimeDBCluster) []*v1alpha1.FlownodeSpec {
+	if groups := cluster.GetFlownodeGroups(); len(groups) > 0 {
+		return groups
+	}
+
+	if cluster.GetFlownode() != nil {
+		return []*v1alpha1.FlownodeSpec{cluster.GetFlownode()}
+	}
+
+	return nil
 }
 
 var _ deployer.Builder = &flownodeBuilder{}
@@ -118,32 +144,31 @@ func (b *flownodeBuilder) BuildService() deployer.Builder {
 		return b
 	}
 
-	if b.Cluster.Spec.Flownode == nil {
-		return b
-	}
-
-	svc := &corev1.Service{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       "Service",
-			APIVersion: "v1",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: b.Cluster.Namespace,
-			Name:      common.ResourceName(b.Cluster.Name, b.RoleKind),
-			Labels: map[string]string{
-				constant.GreptimeDBComponentName: common.ResourceName(b.Cluster.Name, b.RoleKind),
+	for _, spec := range flownodeSpecs(b.Cluster) {
+		resourceName := common.ResourceName(b.Cluster.Name, b.RoleKind, spec.GetName())
+		svc := &corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
 			},
-		},
-		Spec: corev1.ServiceSpec{
-			ClusterIP: corev1.ClusterIPNone,
-			Selector: map[string]string{
-				constant.GreptimeDBComponentName: common.ResourceName(b.Cluster.Name, b.RoleKind),
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: b.Cluster.Namespace,
+				Name:      resourceName,
+				Labels: map[string]string{
+					constant.GreptimeDBComponentName: resourceName,
+				},
 			},
-			Ports: b.servicePorts(),
-		},
-	}
+			Spec: corev1.ServiceSpec{
+				ClusterIP: corev1.ClusterIPNone,
+				Selector: map[string]string{
+					constant.GreptimeDBComponentName: resourceName,
+				},
+				Ports: b.servicePorts(spec),
+			},
+		}
 
-	b.Objects = append(b.Objects, svc)
+		b.Objects = append(b.Objects, svc)
+	}
 
 	return b
 }
@@ -153,123 +178,157 @@ func (b *flownodeBuilder) BuildConfigMap() deployer.Builder {
 		return b
 	}
 
-	if b.Cluster.GetFlownode() == nil {
+	for _, spec := range flownodeSpecs(b.Cluster) {
+		cm, err := b.GenerateConfigMap(spec)
+		if err != nil {
+			b.Err = err
+			return b
+		}
+
+		b.Objects = append(b.Objects, cm)
+	}
+
+	return b
+}
+
+func (b *flownodeBuilder) BuildStatefulSet() deployer.Builder {
+	if b.Err != nil {
 		return b
 	}
 
-	cm, err := b.GenerateConfigMap(b.Cluster.GetFlownode())
-	if err != nil {
-		b.Err = err
+	if b.Cluster.GetFlownode() != nil {
+		sts, err := b.generateFlownodeStatefulSet(nil, b.Cluster.GetFlownode())
+		if err != nil {
+			b.Err = err
+			return b
+		}
+		b.Objects = append(b.Objects, sts)
 		return b
 	}
 
-	b.Objects = append(b.Objects, cm)
+	for i, spec := range b.Cluster.GetFlownodeGroups() {
+		groupID := int32(i)
+		sts, err := b.generateFlownodeStatefulSet(&groupID, spec)
+		if err != nil {
+			b.Err = err
+			return b
+		}
+		b.Objects = append(b.Objects, sts)
+	}
 
 	return b
 }
 
-func (b *flownodeBuilder) BuildStatefulSet() deployer.Builder {
+func (b *flownodeBuilder) BuildPodMonitor() deployer.Builder {
 	if b.Err != nil {
 		return b
 	}
 
-	if b.Cluster.Spec.Flownode == nil {
+	if b.Cluster.Spec.PrometheusMonitor == nil || !b.Cluster.Spec.PrometheusMonitor.Enabled {
 		return b
 	}
 
+	for _, spec := range flownodeSpecs(b.Cluster) {
+		pm, err := b.GeneratePodMonitor(b.Cluster.Namespace, common.ResourceName(b.Cluster.Name, b.RoleKind, spec.GetName()))
+		if err != nil {
+			b.Err = err
+			return b
+		}
+
+		b.Objects = append(b.Objects, pm)
+	}
+
+	return b
+}
+
+func (b *flownodeBuilder) BuildPodDisruptionBudget() deployer.Builder {
+	if b.Err != nil {
+		return b
+	}
+
+	for _, spec := range flownodeSpecs(b.Cluster) {
+		if spec.GetPodDisruptionBudget().IsEnabled() {
+			b.Objects = append(b.Objects, common.GeneratePodDisruptionBudget(b.Cluster.Namespace,
+				common.ResourceName(b.Cluster.Name, b.RoleKind, spec.GetName()), spec.GetPodDisruptionBudget()))
+		}
+	}
+
+	return b
+}
+
+func (b *flownodeBuilder) generateFlownodeStatefulSet(groupID *int32, spec *v1alpha1.FlownodeSpec) (*appsv1.StatefulSet, error) {
+	resourceName := common.ResourceName(b.Cluster.Name, b.RoleKind, spec.GetName())
+
 	sts := &appsv1.StatefulSet{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "StatefulSet",
 			APIVersion: "apps/v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      common.ResourceName(b.Cluster.Name, b.RoleKind),
+			Name:      resourceName,
 			Namespace: b.Cluster.Namespace,
 			Labels: map[string]string{
-				constant.GreptimeDBComponentName: common.ResourceName(b.Cluster.Name, b.RoleKind),
+				constant.GreptimeDBComponentName: resourceName,
 			},
 		},
 		Spec: appsv1.StatefulSetSpec{
 			PodManagementPolicy: appsv1.ParallelPodManagement,
-			ServiceName:         common.ResourceName(b.Cluster.Name, b.RoleKind),
-			Replicas:            b.Cluster.Spec.Flownode.Replicas,
+			ServiceName:         resourceName,
+			Replicas:            spec.Replicas,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{
-					constant.GreptimeDBComponentName: common.ResourceName(b.Cluster.Name, b.RoleKind),
+					constant.GreptimeDBComponentName: resourceName,
 				},
 			},
-			Template: b.generatePodTemplateSpec(),
+			Template: b.generatePodTemplateSpec(spec, groupID),
 			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
 				Type:          appsv1.RollingUpdateStatefulSetStrategyType,
-				RollingUpdate: b.Cluster.Spec.Flownode.RollingUpdate,
+				RollingUpdate: spec.RollingUpdate,
 			},
 		},
 	}
 
-	configData, err := dbconfig.FromCluster(b.Cluster, b.Cluster.GetFlownode())
+	configData, err := dbconfig.FromCluster(b.Cluster, spec)
 	if err != nil {
-		b.Err = err
-		return b
+		return nil, err
 	}
 
 	sts.Spec.Template.Annotations = util.MergeStringMap(sts.Spec.Template.Annotations,
 		map[string]string{deployer.ConfigHash: util.CalculateConfigHash(configData)})
+	b.AddRestartedAtAnnotation(&sts.Spec.Template, spec.GetRestartedAt())
 
-	b.Objects = append(b.Objects, sts)
-
-	return b
-}
-
-func (b *flownodeBuilder) BuildPodMonitor() deployer.Builder {
-	if b.Err != nil {
-		return b
-	}
-
-	if b.Cluster.Spec.Flownode == nil {
-		return b
-	}
+	// The partition is set after the pod template is completed because the canary rollout is identified by the pod template.
+	sts.Spec.UpdateStrategy.RollingUpdate = canaryRollingUpdate(b.Cluster, spec.GetCanary(), sts)
 
-	if b.Cluster.Spec.PrometheusMonitor == nil || !b.Cluster.Spec.PrometheusMonitor.Enabled {
-		return b
-	}
-
-	pm, err := b.GeneratePodMonitor(b.Cluster.Namespace, common.ResourceName(b.Cluster.Name, b.RoleKind))
-	if err != nil {
-		b.Err = err
-		return b
-	}
-
-	b.Objects = append(b.Objects, pm)
-
-	return b
+	return sts, nil
 }
 
-func (b *flownodeBuilder) generateMainContainerArgs() []string {
+func (b *flownodeBuilder) generateMainContainerArgs(spec *v1alpha1.FlownodeSpec) []string {
 	return []string{
 		"flownode", "start",
-		"--rpc-bind-addr", common.GetBindAddress(b.Cluster.Spec.EnableIPv6, b.Cluster.Spec.Flownode.RPCPort),
-		"--rpc-server-addr", common.GetServerAddress(b.Cluster.Spec.EnableIPv6, fmt.Sprintf("$(%s)", deployer.EnvPodIP), b.Cluster.Spec.Flownode.RPCPort),
-		"--http-addr", common.GetBindAddress(b.Cluster.Spec.EnableIPv6, b.Cluster.Spec.Flownode.HTTPPort),
+		"--rpc-bind-addr", common.GetBindAddress(b.Cluster.Spec.EnableIPv6, spec.RPCPort),
+		"--rpc-server-addr", common.GetServerAddress(b.Cluster.Spec.EnableIPv6, fmt.Sprintf("$(%s)", deployer.EnvPodIP), spec.RPCPort),
+		"--http-addr", common.GetBindAddress(b.Cluster.Spec.EnableIPv6, spec.HTTPPort),
 		"--metasrv-addrs", fmt.Sprintf("%s.%s:%d", common.ResourceName(b.Cluster.Name, v1alpha1.MetaRoleKind), b.Cluster.Namespace, b.Cluster.Spec.Meta.RPCPort),
 		"--config-file", path.Join(constant.GreptimeDBConfigDir, constant.GreptimeDBConfigFileName),
 	}
 }
 
-func (b *flownodeBuilder) generatePodTemplateSpec() corev1.PodTemplateSpec {
-	podTemplateSpec := b.GeneratePodTemplateSpec(b.Cluster.Spec.Flownode.Template)
+func (b *flownodeBuilder) generatePodTemplateSpec(spec *v1alpha1.FlownodeSpec, groupID *int32) corev1.PodTemplateSpec {
+	podTemplateSpec := b.GeneratePodTemplateSpec(spec.Template)
 
-	if len(b.Cluster.Spec.Flownode.Template.MainContainer.Args) == 0 {
+	if len(spec.Template.MainContainer.Args) == 0 {
 		// Setup main container args.
-		podTemplateSpec.Spec.Containers[constant.MainContainerIndex].Args = append(b.generateMainContainerArgs(), b.Cluster.Spec.Flownode.Template.MainContainer.ExtraArgs...)
+		podTemplateSpec.Spec.Containers[constant.MainContainerIndex].Args = append(b.generateMainContainerArgs(spec), spec.Template.MainContainer.ExtraArgs...)
 	}
 
-	podTemplateSpec.Spec.Containers[constant.MainContainerIndex].Ports = b.containerPorts()
+	podTemplateSpec.Spec.Containers[constant.MainContainerIndex].Ports = b.containerPorts(spec)
 	podTemplateSpec.Spec.Containers[constant.MainContainerIndex].Env = append(podTemplateSpec.Spec.Containers[constant.MainContainerIndex].Env, b.env(v1alpha1.FlownodeRoleKind)...)
 
 	b.mountConfigDir(podTemplateSpec)
-	b.addInitConfigDirVolume(podTemplateSpec)
+	b.addInitConfigDirVolume(podTemplateSpec, common.ResourceName(b.Cluster.Name, b.RoleKind, spec.GetName()))
 
-	if logging := b.Cluster.GetFlownode().GetLogging(); logging != nil && !logging.IsOnlyLogToStdout() {
+	if logging := spec.GetLogging(); logging != nil && !logging.IsOnlyLogToStdout() {
 		b.AddLogsVolume(podTemplateSpec, logging.GetLogsDir())
 	}
 
@@ -278,15 +337,15 @@ func (b *flownodeBuilder) generatePodTemplateSpec() corev1.PodTemplateSpec {
 		b.AddVectorSidecar(podTemplateSpec, v1alpha1.FlownodeRoleKind)
 	}
 
-	podTemplateSpec.Spec.InitContainers = append(podTemplateSpec.Spec.InitContainers, *b.generateInitializer())
+	podTemplateSpec.Spec.InitContainers = append(podTemplateSpec.Spec.InitContainers, *b.generateInitializer(spec, groupID))
 	podTemplateSpec.Labels = util.MergeStringMap(podTemplateSpec.Labels, map[string]string{
-		constant.GreptimeDBComponentName: common.ResourceName(b.Cluster.Name, b.RoleKind),
+		constant.GreptimeDBComponentName: common.ResourceName(b.Cluster.Name, b.RoleKind, spec.GetName()),
 	})
 
 	return *podTemplateSpec
 }
 
-func (b *flownodeBuilder) generateInitializer() *corev1.Container {
+func (b *flownodeBuilder) generateInitializer(spec *v1alpha1.FlownodeSpec, groupID *int32) *corev1.Container {
 	initializer := &corev1.Container{
 		Name:  "initializer",
 		Image: b.Cluster.Spec.Initializer.Image,
@@ -296,7 +355,7 @@ func (b *flownodeBuilder) generateInitializer() *corev1.Container {
 		Args: []string{
 			"--config-path", path.Join(constant.GreptimeDBConfigDir, constant.GreptimeDBConfigFileName),
 			"--init-config-path", path.Join(constant.GreptimeDBInitConfigDir, constant.GreptimeDBConfigFileName),
-			"--rpc-port", fmt.Sprintf("%d", b.Cluster.Spec.Flownode.RPCPort),
+			"--rpc-port", fmt.Sprintf("%d", spec.RPCPort),
 			"--component-kind", string(b.RoleKind),
 		},
 		VolumeMounts: []corev1.VolumeMount{
@@ -330,8 +389,13 @@ func (b *flownodeBuilder) generateInitializer() *corev1.Container {
 			},
 		},
 	}
-	if b.Cluster.GetFlownode().GetStartNodeID() != nil {
-		initializer.Args = append(initializer.Args, "--start-node-id", fmt.Sprintf("%d", *b.Cluster.GetFlownode().GetStartNodeID()))
+
+	if groupID != nil {
+		initializer.Args = append(initializer.Args, "--flownode-group-id", fmt.Sprintf("%d", *groupID))
+	}
+
+	if spec.GetStartNodeID() != nil {
+		initializer.Args = append(initializer.Args, "--start-node-id", fmt.Sprintf("%d", *spec.GetStartNodeID()))
 	}
 
 	return initializer
@@ -356,36 +420,36 @@ func (b *flownodeBuilder) mountConfigDir(template *corev1.PodTemplateSpec) {
 }
 
 // The init-config volume is used for initializer.
-func (b *flownodeBuilder) addInitConfigDirVolume(template *corev1.PodTemplateSpec) {
+func (b *flownodeBuilder) addInitConfigDirVolume(template *corev1.PodTemplateSpec, configMapName string) {
 	template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
 		Name: constant.InitConfigVolumeName,
 		VolumeSource: corev1.VolumeSource{
 			// Mount the configmap as init-config.
 			ConfigMap: &corev1.ConfigMapVolumeSource{
 				LocalObjectReference: corev1.LocalObjectReference{
-					Name: common.ResourceName(b.Cluster.Name, b.RoleKind),
+					Name: configMapName,
 				},
 			},
 		},
 	})
 }
 
-func (b *flownodeBuilder) servicePorts() []corev1.ServicePort {
+func (b *flownodeBuilder) servicePorts(spec *v1alpha1.FlownodeSpec) []corev1.ServicePort {
 	return []corev1.ServicePort{
 		{
 			Name:     "rpc",
 			Protocol: corev1.ProtocolTCP,
-			Port:     b.Cluster.Spec.Flownode.RPCPort,
+			Port:     spec.RPCPort,
 		},
 	}
 }
 
-func (b *flownodeBuilder) containerPorts() []corev1.ContainerPort {
+func (b *flownodeBuilder) containerPorts(spec *v1alpha1.FlownodeSpec) []corev1.ContainerPort {
 	return []corev1.ContainerPort{
 		{
 			Name:          "grpc",
 			Protocol:      corev1.ProtocolTCP,
-			ContainerPort: b.Cluster.Spec.Flownode.RPCPort,
+			ContainerPort: spec.RPCPort,
 		},
 	}
 }
@@ -0,0 +1,158 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployers
+
+import (
+	"slices"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/constant"
+)
+
+func newFlownodeGroupsCluster() *v1alpha1.GreptimeDBCluster {
+	return &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			Base: &v1alpha1.PodTemplateSpec{
+				MainContainer: &v1alpha1.MainContainerSpec{Image: "greptime/greptimedb:latest"},
+			},
+			Meta:     &v1alpha1.MetaSpec{},
+			Datanode: &v1alpha1.DatanodeSpec{},
+			Frontend: &v1alpha1.FrontendSpec{},
+			FlownodeGroups: []*v1alpha1.FlownodeSpec{
+				{
+					Name:          "light",
+					ComponentSpec: v1alpha1.ComponentSpec{Replicas: ptr.To(int32(1))},
+				},
+				{
+					Name: "heavy",
+					ComponentSpec: v1alpha1.ComponentSpec{
+						Replicas: ptr.To(int32(2)),
+						Template: &v1alpha1.PodTemplateSpec{
+							MainContainer: &v1alpha1.MainContainerSpec{
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+								},
+							},
+						},
+					},
+					StartNodeID: ptr.To(int32(100)),
+				},
+			},
+		},
+	}
+}
+
+func TestBuildFlownodeGroups(t *testing.T) {
+	cluster := newFlownodeGroupsCluster()
+	if err := cluster.SetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cluster.MergeWithBaseTemplate(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := &FlownodeDeployer{CommonDeployer: &CommonDeployer{}}
+	objects, err := d.NewBuilder(cluster).BuildService().BuildStatefulSet().Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 4 {
+		t.Fatalf("expected a service and a statefulset for each of the 2 groups, got: %d", len(objects))
+	}
+
+	tests := []struct {
+		name        string
+		replicas    int32
+		groupID     string
+		startNodeID string
+		cpu         string
+	}{
+		{name: "test-flownode-light", replicas: 1, groupID: "0"},
+		{name: "test-flownode-heavy", replicas: 2, groupID: "1", startNodeID: "100", cpu: "2"},
+	}
+
+	for i, tt := range tests {
+		svc := objects[i].(*corev1.Service)
+		if svc.Name != tt.name || svc.Spec.ClusterIP != corev1.ClusterIPNone || svc.Spec.Selector[constant.GreptimeDBComponentName] != tt.name {
+			t.Errorf("unexpected service '%s' with selector %v", svc.Name, svc.Spec.Selector)
+		}
+
+		sts := objects[len(tests)+i].(*appsv1.StatefulSet)
+		if sts.Name != tt.name || sts.Spec.ServiceName != tt.name || *sts.Spec.Replicas != tt.replicas {
+			t.Errorf("unexpected statefulset '%s' of the service '%s' with %d replicas", sts.Name, sts.Spec.ServiceName, *sts.Spec.Replicas)
+		}
+		if sts.Spec.Selector.MatchLabels[constant.GreptimeDBComponentName] != tt.name ||
+			sts.Spec.Template.Labels[constant.GreptimeDBComponentName] != tt.name {
+			t.Errorf("expected the pods of the statefulset '%s' to be selected by its own name, got: %v", sts.Name, sts.Spec.Template.Labels)
+		}
+
+		// The group id and the start node id are passed to the initializer to offset the node ids of the group.
+		args := sts.Spec.Template.Spec.InitContainers[0].Args
+		if index := slices.Index(args, "--flownode-group-id"); index < 0 || args[index+1] != tt.groupID {
+			t.Errorf("expected the group id '%s' to be passed to the initializer, got: %v", tt.groupID, args)
+		}
+		if index := slices.Index(args, "--start-node-id"); (index >= 0) != (tt.startNodeID != "") || (index >= 0 && args[index+1] != tt.startNodeID) {
+			t.Errorf("expected the start node id '%s' to be passed to the initializer, got: %v", tt.startNodeID, args)
+		}
+
+		if cpu := sts.Spec.Template.Spec.Containers[constant.MainContainerIndex].Resources.Requests.Cpu(); tt.cpu != "" && cpu.String() != tt.cpu {
+			t.Errorf("expected the template of the group to be used, got cpu request: %s", cpu.String())
+		}
+	}
+}
+
+func TestBuildFlownodeWithoutGroups(t *testing.T) {
+	cluster := newFlownodeGroupsCluster()
+	cluster.Spec.FlownodeGroups = nil
+	cluster.Spec.Flownode = &v1alpha1.FlownodeSpec{}
+	if err := cluster.SetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cluster.MergeWithBaseTemplate(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := &FlownodeDeployer{CommonDeployer: &CommonDeployer{}}
+	objects, err := d.NewBuilder(cluster).BuildService().BuildStatefulSet().Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 2 {
+		t.Fatalf("expected a service and a statefulset, got: %d", len(objects))
+	}
+
+	if svc := objects[0].(*corev1.Service); svc.Name != "test-flownode" {
+		t.Errorf("unexpected service '%s'", svc.Name)
+	}
+
+	// The flownode keeps the names and the node ids of the clusters created before the groups are supported.
+	sts := objects[1].(*appsv1.StatefulSet)
+	if sts.Name != "test-flownode" {
+		t.Errorf("unexpected statefulset '%s'", sts.Name)
+	}
+	if args := sts.Spec.Template.Spec.InitContainers[0].Args; slices.Contains(args, "--flownode-group-id") {
+		t.Errorf("expected no group id to be passed to the initializer, got: %v", args)
+	}
+}
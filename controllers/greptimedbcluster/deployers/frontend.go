@@ -20,12 +20,14 @@ import (
 	"path"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -63,6 +65,8 @@ func (d *FrontendDeployer) Generate(crdObject client.Object) ([]client.Object, e
 		BuildDeployment().
 		BuildPodMonitor().
 		BuildIngress().
+		BuildHorizontalPodAutoscaler().
+		BuildPodDisruptionBudget().
 		SetControllerAndAnnotation().
 		Generate()
 
@@ -130,8 +134,14 @@ func (d *FrontendDeployer) CheckAndUpdateStatus(ctx context.Context, crdObject c
 		}
 	}
 
+	autoscaling, err := d.autoscalingStatus(ctx, cluster)
+	if err != nil {
+		return false, err
+	}
+
 	cluster.Status.Frontend.Replicas = replicas
 	cluster.Status.Frontend.ReadyReplicas = readyReplicas
+	cluster.Status.Frontend.Autoscaling = autoscaling
 	if err := UpdateStatus(ctx, cluster, d.Client); err != nil {
 		klog.Errorf("Failed to update status: %s", err)
 	}
@@ -139,6 +149,138 @@ func (d *FrontendDeployer) CheckAndUpdateStatus(ctx context.Context, crdObject c
 	return true, nil
 }
 
+// Apply keeps the replicas of the deployments that are scaled by the HorizontalPodAutoscalers,
+// and deletes the HorizontalPodAutoscalers of the frontends that the autoscaling is disabled.
+func (d *FrontendDeployer) Apply(ctx context.Context, crdObject client.Object, objects []client.Object) error {
+	cluster, err := d.GetCluster(crdObject)
+	if err != nil {
+		return err
+	}
+
+	if err := d.deleteDisabledAutoscalers(ctx, cluster); err != nil {
+		return err
+	}
+
+	if err := d.holdDisruptiveChanges(ctx, cluster, objects, nil); err != nil {
+		return err
+	}
+
+	// The replicas are set after the disruptive changes are held, so they are not recorded in the last applied resource spec.
+	if err := d.keepAutoscaledReplicas(ctx, cluster, objects); err != nil {
+		return err
+	}
+
+	return d.DefaultDeployer.Apply(ctx, crdObject, objects)
+}
+
+// keepAutoscaledReplicas sets the replicas of the autoscaled deployments to the current ones, so the replicas that are scaled by the
+// HorizontalPodAutoscalers are not overwritten. The deployment is created with the min replicas of the autoscaling.
+func (d *FrontendDeployer) keepAutoscaledReplicas(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, objects []client.Object) error {
+	autoscaled := make(map[string]*v1alpha1.FrontendSpec)
+	for _, frontend := range frontendSpecs(cluster) {
+		if frontend.GetAutoscaling().IsEnabled() {
+			autoscaled[common.ResourceName(cluster.Name, v1alpha1.FrontendRoleKind, frontend.GetName())] = frontend
+		}
+	}
+
+	for _, object := range objects {
+		deployment, ok := object.(*appsv1.Deployment)
+		if !ok {
+			continue
+		}
+
+		frontend, ok := autoscaled[deployment.Name]
+		if !ok {
+			continue
+		}
+
+		current := new(appsv1.Deployment)
+		if err := d.Get(ctx, client.ObjectKeyFromObject(deployment), current); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+			deployment.Spec.Replicas = ptr.To(frontend.GetAutoscaling().GetMinReplicas(frontend.GetReplicas()))
+			continue
+		}
+
+		deployment.Spec.Replicas = current.Spec.Replicas
+	}
+
+	return nil
+}
+
+// deleteDisabledAutoscalers deletes the HorizontalPodAutoscalers of the frontends that the autoscaling is disabled,
+// otherwise they will keep scaling the deployments.
+func (d *FrontendDeployer) deleteDisabledAutoscalers(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) error {
+	for _, frontend := range frontendSpecs(cluster) {
+		if frontend.GetAutoscaling().IsEnabled() {
+			continue
+		}
+
+		hpa := new(autoscalingv2.HorizontalPodAutoscaler)
+		objectKey := client.ObjectKey{
+			Namespace: cluster.Namespace,
+			Name:      common.ResourceName(cluster.Name, v1alpha1.FrontendRoleKind, frontend.GetName()),
+		}
+		if err := d.Get(ctx, objectKey, hpa); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		if !metav1.IsControlledBy(hpa, cluster) {
+			continue
+		}
+
+		klog.Infof("Delete the HorizontalPodAutoscaler '%s/%s' since the autoscaling is disabled", hpa.Namespace, hpa.Name)
+		if err := d.Delete(ctx, hpa); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// autoscalingStatus returns the current and desired replicas of the HorizontalPodAutoscalers of the frontends.
+func (d *FrontendDeployer) autoscalingStatus(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) ([]v1alpha1.AutoscalingStatus, error) {
+	var status []v1alpha1.AutoscalingStatus
+	for _, frontend := range frontendSpecs(cluster) {
+		if !frontend.GetAutoscaling().IsEnabled() {
+			continue
+		}
+
+		hpa := new(autoscalingv2.HorizontalPodAutoscaler)
+		objectKey := client.ObjectKey{
+			Namespace: cluster.Namespace,
+			Name:      common.ResourceName(cluster.Name, v1alpha1.FrontendRoleKind, frontend.GetName()),
+		}
+		if err := d.Get(ctx, objectKey, hpa); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		status = append(status, v1alpha1.AutoscalingStatus{
+			Name:            hpa.Name,
+			CurrentReplicas: hpa.Status.CurrentReplicas,
+			DesiredReplicas: hpa.Status.DesiredReplicas,
+		})
+	}
+
+	return status, nil
+}
+
+// frontendSpecs returns the specs of the frontend and frontend groups.
+func frontendSpecs(cluster *v1alpha1.GreptimeDBCluster) []*v1alpha1.FrontendSpec {
+	var specs []*v1alpha1.FrontendSpec
+	if frontend := cluster.GetFrontend(); frontend != nil {
+		specs = append(specs, frontend)
+	}
+	return append(specs, cluster.GetFrontendGroups()...)
+}
+
 var _ deployer.Builder = &frontendBuilder{}
 
 type frontendBuilder struct {
@@ -226,6 +368,11 @@ func (b *frontendBuilder) generateDeployment(frontend *v1alpha1.FrontendSpec) {
 		},
 	}
 
+	// The replicas of the autoscaled deployment are managed by the HorizontalPodAutoscaler.
+	if frontend.GetAutoscaling().IsEnabled() {
+		deployment.Spec.Replicas = nil
+	}
+
 	configData, err := dbconfig.FromCluster(b.Cluster, frontend)
 	if err != nil {
 		b.Err = err
@@ -234,6 +381,7 @@ func (b *frontendBuilder) generateDeployment(frontend *v1alpha1.FrontendSpec) {
 
 	deployment.Spec.Template.Annotations = util.MergeStringMap(deployment.Spec.Template.Annotations,
 		map[string]string{deployer.ConfigHash: util.CalculateConfigHash(configData)})
+	b.AddRestartedAtAnnotation(&deployment.Spec.Template, frontend.GetRestartedAt())
 
 	b.Objects = append(b.Objects, deployment)
 }
@@ -329,6 +477,89 @@ func (b *frontendBuilder) BuildPodMonitor() deployer.Builder {
 	return b
 }
 
+func (b *frontendBuilder) generateHorizontalPodAutoscaler(frontend *v1alpha1.FrontendSpec) {
+	name := common.ResourceName(b.Cluster.Name, b.RoleKind, frontend.GetName())
+	autoscaling := frontend.GetAutoscaling()
+
+	var metrics []autoscalingv2.MetricSpec
+	if autoscaling.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, resourceUtilizationMetric(corev1.ResourceCPU, *autoscaling.TargetCPUUtilizationPercentage))
+	}
+	if autoscaling.TargetMemoryUtilizationPercentage != nil {
+		metrics = append(metrics, resourceUtilizationMetric(corev1.ResourceMemory, *autoscaling.TargetMemoryUtilizationPercentage))
+	}
+	metrics = append(metrics, autoscaling.Metrics...)
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: "autoscaling/v2",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: b.Cluster.Namespace,
+			Labels: map[string]string{
+				constant.GreptimeDBComponentName: name,
+			},
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				Kind:       "Deployment",
+				APIVersion: "apps/v1",
+				Name:       name,
+			},
+			MinReplicas: ptr.To(autoscaling.GetMinReplicas(frontend.GetReplicas())),
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     metrics,
+			Behavior:    autoscaling.Behavior,
+		},
+	}
+
+	b.Objects = append(b.Objects, hpa)
+}
+
+func (b *frontendBuilder) BuildHorizontalPodAutoscaler() deployer.Builder {
+	if b.Err != nil {
+		return b
+	}
+
+	for _, frontend := range frontendSpecs(b.Cluster) {
+		if frontend.GetAutoscaling().IsEnabled() {
+			b.generateHorizontalPodAutoscaler(frontend)
+		}
+	}
+
+	return b
+}
+
+func (b *frontendBuilder) BuildPodDisruptionBudget() deployer.Builder {
+	if b.Err != nil {
+		return b
+	}
+
+	for _, frontend := range frontendSpecs(b.Cluster) {
+		if frontend.GetPodDisruptionBudget().IsEnabled() {
+			b.Objects = append(b.Objects, common.GeneratePodDisruptionBudget(b.Cluster.Namespace,
+				common.ResourceName(b.Cluster.Name, b.RoleKind, frontend.GetName()), frontend.GetPodDisruptionBudget()))
+		}
+	}
+
+	return b
+}
+
+func resourceUtilizationMetric(resource corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: resource,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: ptr.To(utilization),
+			},
+		},
+	}
+}
+
 func (b *frontendBuilder) generateIngress() {
 	var rules []networkingv1.IngressRule
 	for _, rule := range b.Cluster.GetIngress().Rules {
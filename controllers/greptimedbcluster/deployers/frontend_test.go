@@ -0,0 +1,166 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployers
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/constant"
+)
+
+func newAutoscaledCluster() *v1alpha1.GreptimeDBCluster {
+	return &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			FrontendGroups: []*v1alpha1.FrontendSpec{
+				{
+					Name:          "read",
+					ComponentSpec: v1alpha1.ComponentSpec{Replicas: ptr.To(int32(2))},
+					Autoscaling: &v1alpha1.AutoscalingSpec{
+						Enabled:                        true,
+						MaxReplicas:                    10,
+						TargetCPUUtilizationPercentage: ptr.To(int32(70)),
+						Metrics: []autoscalingv2.MetricSpec{
+							{
+								Type: autoscalingv2.PodsMetricSourceType,
+								Pods: &autoscalingv2.PodsMetricSource{
+									Metric: autoscalingv2.MetricIdentifier{Name: "greptime_servers_http_requests_total"},
+									Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType},
+								},
+							},
+						},
+					},
+				},
+				{
+					Name:          "write",
+					ComponentSpec: v1alpha1.ComponentSpec{Replicas: ptr.To(int32(3))},
+					Autoscaling: &v1alpha1.AutoscalingSpec{
+						Enabled:     true,
+						MinReplicas: ptr.To(int32(1)),
+						MaxReplicas: 5,
+					},
+				},
+				{
+					Name:          "internal",
+					ComponentSpec: v1alpha1.ComponentSpec{Replicas: ptr.To(int32(1))},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildHorizontalPodAutoscaler(t *testing.T) {
+	d := &FrontendDeployer{CommonDeployer: &CommonDeployer{}}
+	objects, err := d.NewBuilder(newAutoscaledCluster()).BuildHorizontalPodAutoscaler().Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 2 {
+		t.Fatalf("expected 2 HorizontalPodAutoscalers for the autoscaled frontend groups, got: %d", len(objects))
+	}
+
+	read := objects[0].(*autoscalingv2.HorizontalPodAutoscaler)
+	if read.Name != "test-frontend-read" || read.Spec.ScaleTargetRef.Name != "test-frontend-read" {
+		t.Errorf("unexpected HorizontalPodAutoscaler: %s, target: %s", read.Name, read.Spec.ScaleTargetRef.Name)
+	}
+	if *read.Spec.MinReplicas != 2 || read.Spec.MaxReplicas != 10 {
+		t.Errorf("expected the replicas range [2, 10], got: [%d, %d]", *read.Spec.MinReplicas, read.Spec.MaxReplicas)
+	}
+	if len(read.Spec.Metrics) != 2 || read.Spec.Metrics[0].Resource.Name != corev1.ResourceCPU ||
+		read.Spec.Metrics[1].Type != autoscalingv2.PodsMetricSourceType {
+		t.Errorf("expected the CPU metric followed by the custom metric, got: %+v", read.Spec.Metrics)
+	}
+
+	write := objects[1].(*autoscalingv2.HorizontalPodAutoscaler)
+	if *write.Spec.MinReplicas != 1 || write.Spec.MaxReplicas != 5 {
+		t.Errorf("expected the replicas range [1, 5], got: [%d, %d]", *write.Spec.MinReplicas, write.Spec.MaxReplicas)
+	}
+}
+
+func TestKeepAutoscaledReplicas(t *testing.T) {
+	cluster := newAutoscaledCluster()
+
+	current := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-frontend-read", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(7))},
+	}
+
+	d := &FrontendDeployer{
+		CommonDeployer: &CommonDeployer{Client: fake.NewClientBuilder().WithObjects(current).Build()},
+	}
+
+	objects := []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test-frontend-read", Namespace: "default"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test-frontend-write", Namespace: "default"}},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-frontend-internal", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(1))},
+		},
+	}
+
+	if err := d.keepAutoscaledReplicas(context.Background(), cluster, objects); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []int32{7, 1, 1} {
+		deployment := objects[i].(*appsv1.Deployment)
+		if got := ptr.Deref(deployment.Spec.Replicas, 0); got != want {
+			t.Errorf("deployment '%s': want replicas %d, got: %d", deployment.Name, want, got)
+		}
+	}
+}
+
+func TestBuildPodDisruptionBudget(t *testing.T) {
+	cluster := newAutoscaledCluster()
+	cluster.Spec.FrontendGroups[0].PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{Enabled: ptr.To(true)}
+	cluster.Spec.FrontendGroups[1].PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{Enabled: ptr.To(true), MinAvailable: ptr.To(intstr.FromString("50%"))}
+	cluster.Spec.FrontendGroups[2].PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{MaxUnavailable: ptr.To(intstr.FromInt32(2))}
+
+	d := &FrontendDeployer{CommonDeployer: &CommonDeployer{}}
+	objects, err := d.NewBuilder(cluster).BuildPodDisruptionBudget().Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 2 {
+		t.Fatalf("expected 2 PodDisruptionBudgets for the enabled frontend groups, got: %d", len(objects))
+	}
+
+	read := objects[0].(*policyv1.PodDisruptionBudget)
+	if read.Name != "test-frontend-read" || read.Spec.Selector.MatchLabels[constant.GreptimeDBComponentName] != "test-frontend-read" {
+		t.Errorf("unexpected PodDisruptionBudget: %s, selector: %v", read.Name, read.Spec.Selector.MatchLabels)
+	}
+	if read.Spec.MinAvailable != nil || read.Spec.MaxUnavailable == nil || read.Spec.MaxUnavailable.IntValue() != 1 {
+		t.Errorf("expected the default maxUnavailable 1, got: minAvailable %v, maxUnavailable %v", read.Spec.MinAvailable, read.Spec.MaxUnavailable)
+	}
+
+	write := objects[1].(*policyv1.PodDisruptionBudget)
+	if write.Spec.MaxUnavailable != nil || write.Spec.MinAvailable == nil || write.Spec.MinAvailable.String() != "50%" {
+		t.Errorf("expected the minAvailable 50%%, got: minAvailable %v, maxUnavailable %v", write.Spec.MinAvailable, write.Spec.MaxUnavailable)
+	}
+}
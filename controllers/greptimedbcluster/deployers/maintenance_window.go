@@ -0,0 +1,234 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+)
+
+// restartChecker checks if the pods of the StatefulSet need to be restarted by the new spec.
+type restartChecker func(new, old appsv1.StatefulSet) bool
+
+// Apply holds the pod template changes of the workloads outside the maintenance windows before applying the objects.
+func (c *CommonDeployer) Apply(ctx context.Context, crdObject client.Object, objects []client.Object) error {
+	cluster, err := c.GetCluster(crdObject)
+	if err != nil {
+		return err
+	}
+
+	if err := c.holdDisruptiveChanges(ctx, cluster, objects, nil); err != nil {
+		return err
+	}
+
+	return c.DefaultDeployer.Apply(ctx, crdObject, objects)
+}
+
+// holdDisruptiveChanges keeps the pod templates of the workloads unchanged if the changes will restart the pods outside the maintenance windows,
+// and records the workloads that are waiting for the next maintenance window in the status.
+// The ConfigMaps of the held workloads are also kept unchanged, otherwise the pods that are restarted for other reasons will run with the new config.
+// The StatefulSet uses the checker to decide whether the pods will be restarted, and any pod template change of the others is disruptive.
+func (c *CommonDeployer) holdDisruptiveChanges(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, objects []client.Object, checker restartChecker) error {
+	inWindow, next, err := common.InMaintenanceWindow(cluster.GetMaintenanceWindows(), time.Now())
+	if err != nil {
+		return err
+	}
+
+	var (
+		statusChanged = false
+		heldWorkloads []string
+	)
+	for _, newObject := range objects {
+		if _, ok := newObject.(*appsv1.StatefulSet); !ok {
+			if _, ok := newObject.(*appsv1.Deployment); !ok {
+				continue
+			}
+		}
+
+		held := false
+		if !inWindow {
+			oldObject := newObject.DeepCopyObject().(client.Object)
+			if err := c.Get(ctx, client.ObjectKeyFromObject(newObject), oldObject); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			} else if err == nil {
+				if held, err = holdPodTemplate(oldObject, newObject, checker); err != nil {
+					return err
+				}
+			}
+		}
+
+		if held {
+			klog.Infof("Hold the pod template change of '%s/%s' until the next maintenance window at %s", newObject.GetNamespace(), newObject.GetName(), next)
+			heldWorkloads = append(heldWorkloads, newObject.GetName())
+		}
+
+		if setPendingWorkload(cluster, newObject.GetName(), held, next) {
+			statusChanged = true
+		}
+	}
+
+	// The ConfigMap of the component has the same name as its workload.
+	for _, newObject := range objects {
+		if configMap, ok := newObject.(*corev1.ConfigMap); ok && slices.Contains(heldWorkloads, configMap.Name) {
+			if err := c.holdConfigMap(ctx, configMap); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !statusChanged {
+		return nil
+	}
+
+	return UpdateStatus(ctx, cluster, c.Client)
+}
+
+// holdConfigMap replaces the data and the last applied resource spec of the new ConfigMap with the live ones, so it will not be updated.
+func (c *CommonDeployer) holdConfigMap(ctx context.Context, configMap *corev1.ConfigMap) error {
+	live := new(corev1.ConfigMap)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(configMap), live); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	data, ok := live.Annotations[deployer.LastAppliedResourceSpec]
+	if !ok {
+		return nil
+	}
+
+	if !equality.Semantic.DeepEqual(live.Data, configMap.Data) {
+		klog.Infof("Hold the config change of '%s/%s' with its workload", configMap.Namespace, configMap.Name)
+	}
+
+	configMap.Data = live.Data
+	annotations := configMap.GetAnnotations()
+	annotations[deployer.LastAppliedResourceSpec] = data
+	configMap.SetAnnotations(annotations)
+
+	return nil
+}
+
+// holdPodTemplate replaces the pod template of the new object with the last applied one if the change is disruptive.
+// The last applied resource spec is also updated so that the change will be detected again in the next sync.
+func holdPodTemplate(oldObject, newObject client.Object, checker restartChecker) (bool, error) {
+	data, ok := oldObject.GetAnnotations()[deployer.LastAppliedResourceSpec]
+	if !ok {
+		return false, nil
+	}
+
+	var spec any
+	switch obj := newObject.(type) {
+	case *appsv1.Deployment:
+		var applied appsv1.DeploymentSpec
+		if err := json.Unmarshal([]byte(data), &applied); err != nil {
+			return false, err
+		}
+
+		if equality.Semantic.DeepEqual(applied.Template, obj.Spec.Template) {
+			return false, nil
+		}
+
+		obj.Spec.Template = applied.Template
+		spec = obj.Spec
+	case *appsv1.StatefulSet:
+		var applied appsv1.StatefulSetSpec
+		if err := json.Unmarshal([]byte(data), &applied); err != nil {
+			return false, err
+		}
+
+		if equality.Semantic.DeepEqual(applied.Template, obj.Spec.Template) {
+			return false, nil
+		}
+
+		if checker != nil && !checker(*obj, appsv1.StatefulSet{Spec: applied}) {
+			return false, nil
+		}
+
+		obj.Spec.Template = applied.Template
+		spec = obj.Spec
+	default:
+		return false, nil
+	}
+
+	newData, err := json.Marshal(spec)
+	if err != nil {
+		return false, err
+	}
+
+	annotations := newObject.GetAnnotations()
+	annotations[deployer.LastAppliedResourceSpec] = string(newData)
+	newObject.SetAnnotations(annotations)
+
+	return true, nil
+}
+
+// setPendingWorkload adds or removes the workload in the pending workloads of the maintenance window status,
+// and updates the PendingMaintenanceWindow condition. It returns true if the status is changed.
+func setPendingWorkload(cluster *v1alpha1.GreptimeDBCluster, name string, pending bool, next time.Time) bool {
+	status := cluster.Status.MaintenanceWindow
+	if status == nil {
+		if !pending {
+			return false
+		}
+		status = &v1alpha1.MaintenanceWindowStatus{}
+	}
+
+	index := slices.Index(status.PendingWorkloads, name)
+	switch {
+	case pending && index < 0:
+		status.PendingWorkloads = append(status.PendingWorkloads, name)
+	case !pending && index >= 0:
+		status.PendingWorkloads = slices.Delete(status.PendingWorkloads, index, index+1)
+	default:
+		return false
+	}
+
+	if len(status.PendingWorkloads) == 0 {
+		cluster.Status.MaintenanceWindow = nil
+		cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypePendingMaintenanceWindow, corev1.ConditionFalse,
+			"MaintenanceWindowOpened", "the pending changes are applied in the maintenance window"))
+		return true
+	}
+
+	status.NextWindowTime = nil
+	if !next.IsZero() {
+		status.NextWindowTime = &metav1.Time{Time: next}
+	}
+	cluster.Status.MaintenanceWindow = status
+
+	message := fmt.Sprintf("the changes of %s are waiting for the next maintenance window", strings.Join(status.PendingWorkloads, ", "))
+	if status.NextWindowTime != nil {
+		message = fmt.Sprintf("%s at %s", message, status.NextWindowTime.UTC().Format(time.RFC3339))
+	}
+	cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypePendingMaintenanceWindow, corev1.ConditionTrue,
+		"WaitingForMaintenanceWindow", message))
+
+	return true
+}
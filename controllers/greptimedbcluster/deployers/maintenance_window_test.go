@@ -0,0 +1,192 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployers
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+)
+
+func TestHoldPodTemplate(t *testing.T) {
+	newDeployment := func(replicas int32, image string) *appsv1.Deployment {
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-frontend", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To(replicas),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "frontend", Image: image}}},
+				},
+			},
+		}
+		data, err := json.Marshal(deployment.Spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		deployment.Annotations = map[string]string{deployer.LastAppliedResourceSpec: string(data)}
+		return deployment
+	}
+
+	oldObject := newDeployment(1, "greptime/greptimedb:v0.1.0")
+
+	// Scaling doesn't restart the pods.
+	scaled := newDeployment(3, "greptime/greptimedb:v0.1.0")
+	if held, err := holdPodTemplate(oldObject, scaled, nil); err != nil || held {
+		t.Fatalf("expected the scaling not to be held, got held: %v, err: %v", held, err)
+	}
+
+	upgraded := newDeployment(3, "greptime/greptimedb:v0.2.0")
+	held, err := holdPodTemplate(oldObject, upgraded, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !held {
+		t.Fatalf("expected the image change to be held")
+	}
+
+	// The replicas are still applied, but the image is kept.
+	if *upgraded.Spec.Replicas != 3 || upgraded.Spec.Template.Spec.Containers[0].Image != "greptime/greptimedb:v0.1.0" {
+		t.Errorf("unexpected spec after holding: %+v", upgraded.Spec)
+	}
+	if upgraded.Annotations[deployer.LastAppliedResourceSpec] != scaled.Annotations[deployer.LastAppliedResourceSpec] {
+		t.Errorf("expected the last applied spec to be the held spec, got: %s", upgraded.Annotations[deployer.LastAppliedResourceSpec])
+	}
+}
+
+func TestHoldConfigMap(t *testing.T) {
+	withLastApplied := func(object client.Object, spec any) client.Object {
+		data, err := json.Marshal(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		object.SetAnnotations(map[string]string{deployer.LastAppliedResourceSpec: string(data)})
+		return object
+	}
+
+	newDeployment := func(image string) *appsv1.Deployment {
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-frontend", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To(int32(1)),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "frontend", Image: image}}},
+				},
+			},
+		}
+		return withLastApplied(deployment, deployment.Spec).(*appsv1.Deployment)
+	}
+
+	newConfigMap := func(name, config string) *corev1.ConfigMap {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Data:       map[string]string{"config.toml": config},
+		}
+		return withLastApplied(configMap, configMap.Data).(*corev1.ConfigMap)
+	}
+
+	// The maintenance window lasts for a minute in a year, so it's closed when the test runs.
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			MaintenanceWindows: []v1alpha1.MaintenanceWindow{{Schedule: "0 0 1 1 *", Duration: metav1.Duration{Duration: time.Minute}}},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &CommonDeployer{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(cluster, newDeployment("greptime/greptimedb:v0.1.0"), newConfigMap("test-frontend", "old"), newConfigMap("test-meta", "old")).
+			WithStatusSubresource(cluster).
+			Build(),
+	}
+
+	// The config of the frontend is changed with its pod template, and the config of the meta is changed alone.
+	deployment := newDeployment("greptime/greptimedb:v0.2.0")
+	frontendConfig := newConfigMap("test-frontend", "new")
+	metaConfig := newConfigMap("test-meta", "new")
+	newMetaConfig := metaConfig.Annotations[deployer.LastAppliedResourceSpec]
+
+	if err := c.holdDisruptiveChanges(context.Background(), cluster, []client.Object{deployment, frontendConfig, metaConfig}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if image := deployment.Spec.Template.Spec.Containers[0].Image; image != "greptime/greptimedb:v0.1.0" {
+		t.Errorf("expected the pod template to be held, got image: %s", image)
+	}
+
+	held := newConfigMap("test-frontend", "old")
+	if frontendConfig.Data["config.toml"] != "old" || frontendConfig.Annotations[deployer.LastAppliedResourceSpec] != held.Annotations[deployer.LastAppliedResourceSpec] {
+		t.Errorf("expected the config of the held workload to be held, got: %+v", frontendConfig)
+	}
+
+	if metaConfig.Data["config.toml"] != "new" || metaConfig.Annotations[deployer.LastAppliedResourceSpec] != newMetaConfig {
+		t.Errorf("expected the config of the other component to be applied, got: %+v", metaConfig)
+	}
+
+	if status := cluster.Status.MaintenanceWindow; status == nil || !slices.Equal(status.PendingWorkloads, []string{"test-frontend"}) {
+		t.Errorf("unexpected maintenance window status: %+v", status)
+	}
+}
+
+func TestSetPendingWorkload(t *testing.T) {
+	cluster := &v1alpha1.GreptimeDBCluster{}
+	next := time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC)
+
+	if setPendingWorkload(cluster, "test-frontend", false, next) {
+		t.Fatalf("expected no status change if nothing is pending")
+	}
+
+	setPendingWorkload(cluster, "test-frontend", true, next)
+	setPendingWorkload(cluster, "test-datanode", true, next)
+
+	condition := cluster.Status.GetCondition(v1alpha1.ConditionTypePendingMaintenanceWindow)
+	if condition == nil || condition.Status != corev1.ConditionTrue {
+		t.Fatalf("expected the PendingMaintenanceWindow condition to be true, got: %v", condition)
+	}
+	if want := "the changes of test-frontend, test-datanode are waiting for the next maintenance window at 2026-10-17T02:00:00Z"; condition.Message != want {
+		t.Errorf("expected message '%s', got '%s'", want, condition.Message)
+	}
+
+	setPendingWorkload(cluster, "test-frontend", false, time.Time{})
+	setPendingWorkload(cluster, "test-datanode", false, time.Time{})
+
+	if cluster.Status.MaintenanceWindow != nil {
+		t.Errorf("expected the maintenance window status to be cleared, got: %v", cluster.Status.MaintenanceWindow)
+	}
+	if condition := cluster.Status.GetCondition(v1alpha1.ConditionTypePendingMaintenanceWindow); condition.Status != corev1.ConditionFalse {
+		t.Errorf("expected the PendingMaintenanceWindow condition to be false, got: %v", condition)
+	}
+}
@@ -50,6 +50,8 @@ type MetaDeployer struct {
 
 	etcdMaintenanceBuilder func(etcdEndpoints []string) (clientv3.Maintenance, error)
 
+	leaderGetter MetaLeaderGetter
+
 	// If true, the meta will be in maintenance mode when creating cluster.
 	maintenanceModeWhenCreateCluster bool
 }
@@ -62,6 +64,7 @@ func NewMetaDeployer(mgr ctrl.Manager, opts ...MetaDeployerOption) *MetaDeployer
 	md := &MetaDeployer{
 		CommonDeployer:         NewFromManager(mgr),
 		etcdMaintenanceBuilder: buildEtcdMaintenance,
+		leaderGetter:           common.GetMetaLeader,
 	}
 
 	for _, opt := range opts {
@@ -95,6 +98,7 @@ func (d *MetaDeployer) Generate(crdObject client.Object) ([]client.Object, error
 		BuildConfigMap().
 		BuildDeployment().
 		BuildPodMonitor().
+		BuildPodDisruptionBudget().
 		SetControllerAndAnnotation().
 		Generate()
 
@@ -139,6 +143,11 @@ func (d *MetaDeployer) CheckAndUpdateStatus(ctx context.Context, highLevelObject
 
 	ready := k8sutil.IsDeploymentReady(deployment)
 
+	// The meta is not ready until the leader is elected when the rollout is leader-aware.
+	if ready && cluster.GetMeta().IsLeaderAwareRollingUpdate() && *deployment.Spec.Replicas > 0 {
+		ready = d.isLeaderElected(cluster)
+	}
+
 	// It should meet the following conditions to turn on maintenance mode:
 	// 1. The cluster is in starting phase that means the cluster is in the process of being created.
 	// 2. The meta deployment is ready and the maintenance mode is not enabled.
@@ -292,6 +301,11 @@ func (b *metaBuilder) BuildDeployment() deployer.Builder {
 
 	deployment.Spec.Template.Annotations = util.MergeStringMap(deployment.Spec.Template.Annotations,
 		map[string]string{deployer.ConfigHash: util.CalculateConfigHash(configData)})
+	b.AddRestartedAtAnnotation(&deployment.Spec.Template, b.Cluster.GetMeta().GetRestartedAt())
+
+	if b.Cluster.GetMeta().IsLeaderAwareRollingUpdate() {
+		deployment.Spec.Strategy.RollingUpdate = leaderAwareRollingUpdate()
+	}
 
 	b.Objects = append(b.Objects, deployment)
 
@@ -342,6 +356,21 @@ func (b *metaBuilder) BuildPodMonitor() deployer.Builder {
 	return b
 }
 
+func (b *metaBuilder) BuildPodDisruptionBudget() deployer.Builder {
+	if b.Err != nil {
+		return b
+	}
+
+	if !b.Cluster.GetMeta().GetPodDisruptionBudget().IsEnabled() {
+		return b
+	}
+
+	b.Objects = append(b.Objects, common.GeneratePodDisruptionBudget(b.Cluster.Namespace,
+		common.ResourceName(b.Cluster.Name, b.RoleKind), b.Cluster.GetMeta().GetPodDisruptionBudget()))
+
+	return b
+}
+
 func (b *metaBuilder) Generate() ([]client.Object, error) {
 	return b.Objects, b.Err
 }
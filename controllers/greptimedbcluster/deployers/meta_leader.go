@@ -0,0 +1,234 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployers
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/constant"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+)
+
+// leaderPodDeletionCost is the deletion cost of the meta leader pod.
+// The ReplicaSet deletes the pods with the lower cost first, so the old followers are replaced before the leader.
+const leaderPodDeletionCost = "1"
+
+// MetaLeaderGetter returns the address of the metasrv leader, and an empty address if no leader is elected.
+type MetaLeaderGetter func(metaHTTPServiceURL string) (string, error)
+
+func WithMetaLeaderGetter(getter MetaLeaderGetter) func(*MetaDeployer) {
+	return func(d *MetaDeployer) {
+		d.leaderGetter = getter
+	}
+}
+
+// Apply makes the rollout of the meta deployment leader-aware before applying the objects if it's enabled.
+// The disruptive changes are held first, so the rollout is only gated for the pod template that will be applied.
+func (d *MetaDeployer) Apply(ctx context.Context, crdObject client.Object, objects []client.Object) error {
+	cluster, err := d.GetCluster(crdObject)
+	if err != nil {
+		return err
+	}
+
+	if err := d.holdDisruptiveChanges(ctx, cluster, objects, nil); err != nil {
+		return err
+	}
+
+	if cluster.GetMeta().IsLeaderAwareRollingUpdate() {
+		if err := d.syncLeaderAwareRollout(ctx, cluster, objects); err != nil {
+			return err
+		}
+	}
+
+	return d.DefaultDeployer.Apply(ctx, crdObject, objects)
+}
+
+// syncLeaderAwareRollout marks the leader pod with the higher deletion cost so it's replaced last,
+// and gates each step of the rollout of the meta deployment by pausing it:
+//
+//  1. The new pod template is applied with the deployment paused, so no pod is replaced yet.
+//  2. The deployment is resumed if all its pods are available and the leader is elected.
+//  3. The deployment is paused again once the next new pod is created, since it's not available yet.
+//
+// With the max surge of one pod, the steps are repeated until all the pods are updated, and every step replaces one pod.
+func (d *MetaDeployer) syncLeaderAwareRollout(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, objects []client.Object) error {
+	var deployment *appsv1.Deployment
+	for _, object := range objects {
+		if obj, ok := object.(*appsv1.Deployment); ok {
+			deployment = obj
+		}
+	}
+	if deployment == nil {
+		return nil
+	}
+
+	current := new(appsv1.Deployment)
+	if err := d.Get(ctx, client.ObjectKeyFromObject(deployment), current); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	leader, err := d.leaderGetter(common.GetMetaHTTPServiceURL(cluster))
+	if err != nil {
+		// The metasrv may not respond while the leader is being elected.
+		klog.Warningf("Failed to get the leader of meta '%s/%s': %v", cluster.Namespace, cluster.Name, err)
+		leader = ""
+	}
+
+	if err := d.markLeaderPod(ctx, cluster, leader); err != nil {
+		return err
+	}
+
+	templateChanged, err := isPodTemplateChanged(current, deployment)
+	if err != nil {
+		return err
+	}
+
+	if !templateChanged && isDeploymentRolledOut(current) {
+		return nil
+	}
+
+	var (
+		observed     = current.Status.ObservedGeneration >= current.Generation
+		allAvailable = current.Status.AvailableReplicas >= current.Status.Replicas
+		paused       = current.Spec.Paused
+	)
+	switch {
+	case templateChanged:
+		klog.Infof("Apply the new pod template of meta deployment '%s/%s' with the rollout paused", deployment.Namespace, deployment.Name)
+		paused = true
+	case !observed:
+		// Wait for the deployment controller to observe the last pause or resume.
+	case !current.Spec.Paused && !allAvailable:
+		klog.Infof("Pause the rollout of meta deployment '%s/%s' until the new pod is available", deployment.Namespace, deployment.Name)
+		paused = true
+	case current.Spec.Paused && allAvailable && leader != "":
+		klog.Infof("Resume the rollout of meta deployment '%s/%s' for the next pod", deployment.Namespace, deployment.Name)
+		paused = false
+	}
+
+	if deployment.Spec.Paused == paused {
+		return nil
+	}
+	deployment.Spec.Paused = paused
+
+	data, err := json.Marshal(deployment.Spec)
+	if err != nil {
+		return err
+	}
+
+	annotations := deployment.GetAnnotations()
+	annotations[deployer.LastAppliedResourceSpec] = string(data)
+	deployment.SetAnnotations(annotations)
+
+	return nil
+}
+
+// isPodTemplateChanged returns true if the pod template of the new deployment is different from the last applied one.
+func isPodTemplateChanged(current, deployment *appsv1.Deployment) (bool, error) {
+	data, ok := current.Annotations[deployer.LastAppliedResourceSpec]
+	if !ok {
+		return false, nil
+	}
+
+	var applied appsv1.DeploymentSpec
+	if err := json.Unmarshal([]byte(data), &applied); err != nil {
+		return false, err
+	}
+
+	return !equality.Semantic.DeepEqual(applied.Template, deployment.Spec.Template), nil
+}
+
+// isDeploymentRolledOut returns true if all the pods of the deployment are updated and available, and no old pod is left.
+func isDeploymentRolledOut(deployment *appsv1.Deployment) bool {
+	replicas := ptr.Deref(deployment.Spec.Replicas, 1)
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.Replicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas
+}
+
+// markLeaderPod sets the deletion cost of the meta leader pod, and removes it from the other pods.
+func (d *MetaDeployer) markLeaderPod(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, leader string) error {
+	leaderHost := leader
+	if host, _, err := net.SplitHostPort(leader); err == nil {
+		leaderHost = host
+	}
+
+	var pods corev1.PodList
+	if err := d.List(ctx, &pods, client.InNamespace(cluster.Namespace), client.MatchingLabels{
+		constant.GreptimeDBComponentName: common.ResourceName(cluster.Name, v1alpha1.MetaRoleKind),
+	}); err != nil {
+		return err
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		isLeader := leaderHost != "" && pod.Status.PodIP == leaderHost
+		if _, marked := pod.Annotations[corev1.PodDeletionCost]; marked == isLeader {
+			continue
+		}
+
+		patch := client.MergeFrom(pod.DeepCopy())
+		if isLeader {
+			klog.Infof("Mark the meta pod '%s/%s' as the leader", pod.Namespace, pod.Name)
+			if pod.Annotations == nil {
+				pod.Annotations = map[string]string{}
+			}
+			pod.Annotations[corev1.PodDeletionCost] = leaderPodDeletionCost
+		} else {
+			delete(pod.Annotations, corev1.PodDeletionCost)
+		}
+
+		if err := d.Patch(ctx, pod, patch); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isLeaderElected returns true if the leader of the meta is elected.
+func (d *MetaDeployer) isLeaderElected(cluster *v1alpha1.GreptimeDBCluster) bool {
+	leader, err := d.leaderGetter(common.GetMetaHTTPServiceURL(cluster))
+	if err != nil {
+		klog.Warningf("Failed to get the leader of meta '%s/%s': %v", cluster.Namespace, cluster.Name, err)
+		return false
+	}
+	return leader != ""
+}
+
+// leaderAwareRollingUpdate updates the meta pods one by one without reducing the available pods.
+func leaderAwareRollingUpdate() *appsv1.RollingUpdateDeployment {
+	return &appsv1.RollingUpdateDeployment{
+		MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+		MaxSurge:       ptr.To(intstr.FromInt32(1)),
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/constant"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+)
+
+func TestSyncLeaderAwareRollout(t *testing.T) {
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			Meta: &v1alpha1.MetaSpec{
+				ComponentSpec:            v1alpha1.ComponentSpec{Replicas: ptr.To(int32(3))},
+				HTTPPort:                 4000,
+				LeaderAwareRollingUpdate: ptr.To(true),
+			},
+		},
+	}
+
+	newDeployment := func(image string, paused bool) *appsv1.Deployment {
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-meta", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To(int32(3)),
+				Paused:   paused,
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "meta", Image: image}}},
+				},
+			},
+		}
+		data, err := json.Marshal(deployment.Spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		deployment.Annotations = map[string]string{deployer.LastAppliedResourceSpec: string(data)}
+		return deployment
+	}
+
+	objects := []client.Object{newDeployment("greptime/greptimedb:v0.1.0", false)}
+	for i := 0; i < 3; i++ {
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-meta-%d", i),
+				Namespace: "default",
+				Labels:    map[string]string{constant.GreptimeDBComponentName: "test-meta"},
+			},
+			Status: corev1.PodStatus{PodIP: fmt.Sprintf("10.0.0.%d", i)},
+		})
+	}
+
+	leader := "10.0.0.1:3002"
+	d := &MetaDeployer{
+		CommonDeployer: &CommonDeployer{Client: fake.NewClientBuilder().WithObjects(objects...).Build()},
+		leaderGetter: func(_ string) (string, error) {
+			return leader, nil
+		},
+	}
+
+	ctx := context.Background()
+	checkLeaderPod := func(want string) {
+		t.Helper()
+		for i := 0; i < 3; i++ {
+			pod := new(corev1.Pod)
+			if err := d.Get(ctx, client.ObjectKey{Namespace: "default", Name: fmt.Sprintf("test-meta-%d", i)}, pod); err != nil {
+				t.Fatal(err)
+			}
+			if _, marked := pod.Annotations[corev1.PodDeletionCost]; marked != (pod.Name == want) {
+				t.Errorf("pod '%s': want marked as leader %v, got: %v", pod.Name, pod.Name == want, marked)
+			}
+		}
+	}
+
+	// live sets the deployment that is applied and its status that is reported by the deployment controller.
+	live := func(deployment *appsv1.Deployment, status appsv1.DeploymentStatus) {
+		t.Helper()
+		current := new(appsv1.Deployment)
+		if err := d.Get(ctx, client.ObjectKeyFromObject(deployment), current); err != nil {
+			t.Fatal(err)
+		}
+		current.Annotations = deployment.Annotations
+		current.Spec = deployment.Spec
+		if err := d.Update(ctx, current); err != nil {
+			t.Fatal(err)
+		}
+		current.Status = status
+		if err := d.Status().Update(ctx, current); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// sync returns the deployment to be applied with the new pod template.
+	sync := func(image string) *appsv1.Deployment {
+		t.Helper()
+		deployment := newDeployment(image, false)
+		if err := d.syncLeaderAwareRollout(ctx, cluster, []client.Object{deployment}); err != nil {
+			t.Fatal(err)
+		}
+
+		var applied appsv1.DeploymentSpec
+		if err := json.Unmarshal([]byte(deployment.Annotations[deployer.LastAppliedResourceSpec]), &applied); err != nil {
+			t.Fatal(err)
+		}
+		if applied.Paused != deployment.Spec.Paused {
+			t.Errorf("expected the last applied spec to be paused %v, got: %v", deployment.Spec.Paused, applied.Paused)
+		}
+		return deployment
+	}
+
+	status := func(replicas, updated, available int32) appsv1.DeploymentStatus {
+		return appsv1.DeploymentStatus{Replicas: replicas, UpdatedReplicas: updated, AvailableReplicas: available}
+	}
+
+	v1, v2 := "greptime/greptimedb:v0.1.0", "greptime/greptimedb:v0.2.0"
+
+	// The rollout is not gated if the pod template is not changed.
+	live(newDeployment(v1, false), status(3, 3, 3))
+	if sync(v1).Spec.Paused {
+		t.Errorf("expected the deployment not to be paused without rollout")
+	}
+	checkLeaderPod("test-meta-1")
+
+	// The new pod template is applied with the rollout paused.
+	applied := sync(v2)
+	if !applied.Spec.Paused {
+		t.Fatalf("expected the new pod template to be applied with the rollout paused")
+	}
+
+	steps := []struct {
+		name       string
+		leader     string
+		status     appsv1.DeploymentStatus
+		wantPaused bool
+	}{
+		{"resume for the first pod", "10.0.0.1:3002", status(3, 0, 3), false},
+		{"pause until the first pod is available", "10.0.0.1:3002", status(4, 1, 3), true},
+		{"resume for the second pod", "10.0.0.1:3002", status(4, 1, 4), false},
+		{"keep going until the second pod is created", "10.0.0.1:3002", status(3, 1, 3), false},
+		{"pause until the second pod is available", "", status(4, 2, 3), true},
+		{"keep paused until the leader is elected", "", status(4, 2, 4), true},
+		{"resume after the leader is elected", "10.0.0.2:3002", status(4, 2, 4), false},
+		{"pause until the last pod is available", "10.0.0.2:3002", status(4, 3, 3), true},
+		{"resume to remove the last old pod", "10.0.0.2:3002", status(4, 3, 4), false},
+		{"rolled out", "10.0.0.2:3002", status(3, 3, 3), false},
+	}
+	for _, step := range steps {
+		live(applied, step.status)
+		leader = step.leader
+		applied = sync(v2)
+		if applied.Spec.Paused != step.wantPaused {
+			t.Fatalf("%s: want paused %v, got: %v", step.name, step.wantPaused, applied.Spec.Paused)
+		}
+	}
+	checkLeaderPod("test-meta-2")
+
+	// The deployment is not resumed until the last pause is observed by the deployment controller.
+	live(newDeployment(v2, true), status(4, 1, 4))
+	current := new(appsv1.Deployment)
+	if err := d.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-meta"}, current); err != nil {
+		t.Fatal(err)
+	}
+	current.Generation = 2
+	if err := d.Update(ctx, current); err != nil {
+		t.Fatal(err)
+	}
+	current.Status.ObservedGeneration = 1
+	if err := d.Status().Update(ctx, current); err != nil {
+		t.Fatal(err)
+	}
+	if !sync(v2).Spec.Paused {
+		t.Errorf("expected the deployment to be kept paused before the pause is observed")
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+)
+
+// testScheme is the scheme of the fake clients used by the unit tests.
+var testScheme = newTestScheme()
+
+func newTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	return scheme
+}
+
+// newTestReconciler returns a Reconciler backed by a fake client that is seeded with objs.
+func newTestReconciler(objs ...client.Object) *Reconciler {
+	return &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(testScheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&v1alpha1.GreptimeDBCluster{}).
+			Build(),
+		Scheme:   testScheme,
+		Recorder: record.NewFakeRecorder(100),
+
+		metaHTTPServiceURL: common.GetMetaHTTPServiceURL,
+	}
+}
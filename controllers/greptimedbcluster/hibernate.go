@@ -0,0 +1,270 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/greptimedbcluster/deployers"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+	k8sutils "github.com/GreptimeTeam/greptimedb-operator/pkg/util/k8s"
+)
+
+const (
+	workloadKindDeployment  = "Deployment"
+	workloadKindStatefulSet = "StatefulSet"
+)
+
+// hibernationOrder is the order to scale the components to zero. The components are woken up in reverse order.
+var hibernationOrder = []v1alpha1.RoleKind{
+	v1alpha1.FrontendRoleKind,
+	v1alpha1.FlownodeRoleKind,
+	v1alpha1.DatanodeRoleKind,
+	v1alpha1.MetaRoleKind,
+}
+
+// hibernate scales the components to zero one by one, and the next component will not be scaled until all the pods of the previous one are gone.
+// The PVCs are kept whatever the storage retain policies are, since the cluster is not deleted.
+func (r *Reconciler) hibernate(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) (ctrl.Result, error) {
+	if cluster.Status.ClusterPhase == v1alpha1.PhaseHibernated {
+		return ctrl.Result{}, nil
+	}
+
+	if cluster.Status.Hibernation == nil {
+		klog.Infof("Start to hibernate the cluster '%s/%s'", cluster.Namespace, cluster.Name)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "Hibernating", "Scaling all the components to zero")
+		cluster.Status.Hibernation = &v1alpha1.HibernationStatus{}
+		if err := r.updateClusterStatus(ctx, cluster, v1alpha1.PhaseUpdating); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	workloads, err := r.listHibernationWorkloads(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	for _, kind := range hibernationOrder {
+		var (
+			scaling  []client.Object
+			original []client.Object
+			stopped  = true
+		)
+
+		for _, workload := range workloads[kind] {
+			replicas, currentReplicas := workloadReplicas(workload)
+			if replicas > 0 {
+				// Record the replicas before scaling to zero, so they can be restored when the cluster is woken up.
+				if cluster.Status.Hibernation.GetWorkload(workloadKind(workload), workload.GetName()) == nil {
+					cluster.Status.Hibernation.Workloads = append(cluster.Status.Hibernation.Workloads, v1alpha1.HibernatedWorkload{
+						Component: kind,
+						Kind:      workloadKind(workload),
+						Name:      workload.GetName(),
+						Replicas:  replicas,
+					})
+				}
+				original = append(original, workload.DeepCopyObject().(client.Object))
+				scaling = append(scaling, setWorkloadReplicas(workload, 0))
+			}
+
+			if currentReplicas > 0 {
+				stopped = false
+			}
+		}
+
+		if len(scaling) > 0 {
+			if err := deployers.UpdateStatus(ctx, cluster, r.Client); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			for i := range scaling {
+				klog.Infof("Scale %s '%s/%s' to zero for hibernation", workloadKind(scaling[i]), scaling[i].GetNamespace(), scaling[i].GetName())
+				if err := r.Patch(ctx, scaling[i], client.MergeFrom(original[i])); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+
+			return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+		}
+
+		// Wait for all the pods of the component to be deleted.
+		if !stopped {
+			return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+		}
+	}
+
+	cluster.Status.Hibernation.HibernatedTime = ptrNow()
+	cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeReady, corev1.ConditionFalse, "Hibernated", "the cluster is hibernated"))
+
+	return ctrl.Result{}, r.updateClusterStatus(ctx, cluster, v1alpha1.PhaseHibernated)
+}
+
+// wakeUp restores the replicas of the hibernated components in reverse order,
+// and the next component will not be scaled until the previous one is ready.
+// It returns true if all the components are restored.
+func (r *Reconciler) wakeUp(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) (bool, error) {
+	hibernation := cluster.Status.Hibernation
+	if hibernation == nil {
+		return true, nil
+	}
+
+	if cluster.Status.ClusterPhase == v1alpha1.PhaseHibernated {
+		klog.Infof("Start to wake up the cluster '%s/%s'", cluster.Namespace, cluster.Name)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "WakingUp", "Restoring the replicas of all the components")
+		if err := r.updateClusterStatus(ctx, cluster, v1alpha1.PhaseStarting); err != nil {
+			return false, err
+		}
+	}
+
+	workloads, err := r.listHibernationWorkloads(ctx, cluster)
+	if err != nil {
+		return false, err
+	}
+
+	for _, kind := range slices.Backward(hibernationOrder) {
+		ready := true
+		for _, workload := range workloads[kind] {
+			hibernated := hibernation.GetWorkload(workloadKind(workload), workload.GetName())
+			if hibernated == nil {
+				continue
+			}
+
+			if replicas, _ := workloadReplicas(workload); replicas == 0 {
+				klog.Infof("Restore the replicas of %s '%s/%s' to %d", hibernated.Kind, workload.GetNamespace(), workload.GetName(), hibernated.Replicas)
+				original := workload.DeepCopyObject().(client.Object)
+				if err := r.Patch(ctx, setWorkloadReplicas(workload, hibernated.Replicas), client.MergeFrom(original)); err != nil {
+					return false, err
+				}
+				ready = false
+				continue
+			}
+
+			if !isWorkloadReady(workload) {
+				ready = false
+			}
+		}
+
+		if !ready {
+			return false, nil
+		}
+	}
+
+	klog.Infof("The cluster '%s/%s' is woken up", cluster.Namespace, cluster.Name)
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "WokenUp", "The replicas of all the components are restored")
+	cluster.Status.Hibernation = nil
+
+	return true, deployers.UpdateStatus(ctx, cluster, r.Client)
+}
+
+// listHibernationWorkloads lists the workloads of the cluster grouped by the components.
+func (r *Reconciler) listHibernationWorkloads(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) (map[v1alpha1.RoleKind][]client.Object, error) {
+	deployments, statefulSets, err := r.listWorkloads(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []client.Object
+	for i := range deployments {
+		objects = append(objects, &deployments[i])
+	}
+	for i := range statefulSets {
+		objects = append(objects, &statefulSets[i])
+	}
+
+	workloads := make(map[v1alpha1.RoleKind][]client.Object)
+	for _, object := range objects {
+		for _, kind := range hibernationOrder {
+			prefix := common.ResourceName(cluster.Name, kind)
+			if object.GetName() == prefix || strings.HasPrefix(object.GetName(), prefix+"-") {
+				workloads[kind] = append(workloads[kind], object)
+				break
+			}
+		}
+	}
+
+	return workloads, nil
+}
+
+func workloadKind(object client.Object) string {
+	if _, ok := object.(*appsv1.StatefulSet); ok {
+		return workloadKindStatefulSet
+	}
+	return workloadKindDeployment
+}
+
+// workloadReplicas returns the desired replicas and the current replicas of the workload.
+func workloadReplicas(object client.Object) (int32, int32) {
+	switch workload := object.(type) {
+	case *appsv1.Deployment:
+		return ptr.Deref(workload.Spec.Replicas, 1), workload.Status.Replicas
+	case *appsv1.StatefulSet:
+		return ptr.Deref(workload.Spec.Replicas, 1), workload.Status.Replicas
+	}
+	return 0, 0
+}
+
+// setWorkloadReplicas returns the copy of the workload with the replicas.
+func setWorkloadReplicas(object client.Object, replicas int32) client.Object {
+	workload := object.DeepCopyObject().(client.Object)
+	switch w := workload.(type) {
+	case *appsv1.Deployment:
+		w.Spec.Replicas = ptr.To(replicas)
+	case *appsv1.StatefulSet:
+		w.Spec.Replicas = ptr.To(replicas)
+		w.Spec.PersistentVolumeClaimRetentionPolicy = hibernationPVCRetentionPolicy(w, replicas)
+	}
+	return workload
+}
+
+// hibernationPVCRetentionPolicy keeps the PVCs when the StatefulSet is scaled to zero for hibernation,
+// and restores the retention policy from the last applied spec when it's woken up.
+func hibernationPVCRetentionPolicy(sts *appsv1.StatefulSet, replicas int32) *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy {
+	if replicas == 0 {
+		policy := sts.Spec.PersistentVolumeClaimRetentionPolicy.DeepCopy()
+		if policy != nil {
+			policy.WhenScaled = appsv1.RetainPersistentVolumeClaimRetentionPolicyType
+		}
+		return policy
+	}
+
+	var spec appsv1.StatefulSetSpec
+	if err := json.Unmarshal([]byte(sts.Annotations[deployer.LastAppliedResourceSpec]), &spec); err != nil {
+		return sts.Spec.PersistentVolumeClaimRetentionPolicy
+	}
+
+	return spec.PersistentVolumeClaimRetentionPolicy
+}
+
+func isWorkloadReady(object client.Object) bool {
+	switch workload := object.(type) {
+	case *appsv1.Deployment:
+		return k8sutils.IsDeploymentReady(workload)
+	case *appsv1.StatefulSet:
+		return k8sutils.IsStatefulSetReady(workload)
+	}
+	return true
+}
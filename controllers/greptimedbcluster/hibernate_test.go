@@ -0,0 +1,113 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+)
+
+func TestHibernateAndWakeUp(t *testing.T) {
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid"},
+		Spec:       v1alpha1.GreptimeDBClusterSpec{Hibernate: true},
+		Status:     v1alpha1.GreptimeDBClusterStatus{ClusterPhase: v1alpha1.PhaseRunning},
+	}
+
+	var (
+		frontend = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-frontend", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+		}
+		datanode = &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-datanode", Namespace: "default"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(3))},
+		}
+		meta = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-meta", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(1))},
+		}
+	)
+
+	for _, obj := range []client.Object{frontend, datanode, meta} {
+		if err := controllerutil.SetControllerReference(cluster, obj, testScheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := newTestReconciler(cluster, frontend, datanode, meta)
+
+	ctx := context.Background()
+	replicasOf := func(obj client.Object) int32 {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			t.Fatal(err)
+		}
+		replicas, _ := workloadReplicas(obj)
+		return replicas
+	}
+
+	// The components are scaled to zero one by one, frontends first.
+	wantOrder := []client.Object{frontend, datanode, meta}
+	for i, obj := range wantOrder {
+		if _, err := r.hibernate(ctx, cluster); err != nil {
+			t.Fatal(err)
+		}
+
+		for j, other := range wantOrder {
+			if replicas := replicasOf(other); (j <= i) != (replicas == 0) {
+				t.Fatalf("step %d: unexpected replicas %d of '%s'", i, replicas, other.GetName())
+			}
+		}
+
+		if cluster.Status.Hibernation.GetWorkload(workloadKind(obj), obj.GetName()) == nil {
+			t.Fatalf("expected the replicas of '%s' to be recorded", obj.GetName())
+		}
+	}
+
+	if _, err := r.hibernate(ctx, cluster); err != nil {
+		t.Fatal(err)
+	}
+
+	if cluster.Status.ClusterPhase != v1alpha1.PhaseHibernated {
+		t.Fatalf("expected the cluster to be hibernated, got: %s", cluster.Status.ClusterPhase)
+	}
+
+	// The metas are woken up first, and the others wait for them to be ready.
+	cluster.Spec.Hibernate = false
+	awake, err := r.wakeUp(ctx, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if awake {
+		t.Fatalf("expected the cluster not to be awake")
+	}
+
+	if replicas := replicasOf(meta); replicas != 1 {
+		t.Errorf("expected the replicas of meta to be restored to 1, got: %d", replicas)
+	}
+
+	if replicas := replicasOf(datanode); replicas != 0 {
+		t.Errorf("expected the datanode to wait for the meta, got replicas: %d", replicas)
+	}
+}
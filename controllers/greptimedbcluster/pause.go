@@ -0,0 +1,54 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/greptimedbcluster/deployers"
+)
+
+// pause marks the cluster as paused. The operator will not touch the resources of the cluster until it's unpaused.
+func (r *Reconciler) pause(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) error {
+	if !common.Pause(r.Recorder, cluster, &cluster.Status) {
+		return nil
+	}
+
+	return deployers.UpdateStatus(ctx, cluster, r.Client)
+}
+
+// resume reports the drift of the resources that are modified during the pause, and the drift will be overwritten by the next sync.
+func (r *Reconciler) resume(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) error {
+	resumed, err := common.Resume(ctx, r.Client, r.Recorder, cluster, &cluster.Status, func() ([]client.Object, error) {
+		var objects []client.Object
+		for _, d := range r.Deployers {
+			generated, err := d.Generate(cluster)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, generated...)
+		}
+		return objects, nil
+	})
+	if err != nil || !resumed {
+		return err
+	}
+
+	return deployers.UpdateStatus(ctx, cluster, r.Client)
+}
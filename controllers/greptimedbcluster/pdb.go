@@ -0,0 +1,84 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+)
+
+// sweepPodDisruptionBudgets deletes the PodDisruptionBudgets of the cluster that are disabled or whose components are removed,
+// otherwise they will keep blocking the evictions of the pods that have the same labels.
+func (r *Reconciler) sweepPodDisruptionBudgets(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) error {
+	var pdbs policyv1.PodDisruptionBudgetList
+	if err := r.List(ctx, &pdbs, client.InNamespace(cluster.Namespace)); err != nil {
+		return err
+	}
+
+	expected := expectedPodDisruptionBudgets(cluster)
+	for i := range pdbs.Items {
+		pdb := &pdbs.Items[i]
+		if !metav1.IsControlledBy(pdb, cluster) || expected[pdb.Name] || pdb.DeletionTimestamp != nil {
+			continue
+		}
+
+		klog.Infof("Delete the PodDisruptionBudget '%s/%s' since it's disabled", pdb.Namespace, pdb.Name)
+		if err := r.Delete(ctx, pdb); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expectedPodDisruptionBudgets returns the names of the PodDisruptionBudgets that are enabled in the cluster.
+func expectedPodDisruptionBudgets(cluster *v1alpha1.GreptimeDBCluster) map[string]bool {
+	expected := make(map[string]bool)
+
+	if cluster.GetMeta().GetPodDisruptionBudget().IsEnabled() {
+		expected[common.ResourceName(cluster.Name, v1alpha1.MetaRoleKind)] = true
+	}
+
+	datanodes := append([]*v1alpha1.DatanodeSpec{cluster.GetDatanode()}, cluster.GetDatanodeGroups()...)
+	for _, datanode := range datanodes {
+		if datanode.GetPodDisruptionBudget().IsEnabled() {
+			expected[common.ResourceName(cluster.Name, v1alpha1.DatanodeRoleKind, datanode.GetName())] = true
+		}
+	}
+
+	flownodes := append([]*v1alpha1.FlownodeSpec{cluster.GetFlownode()}, cluster.GetFlownodeGroups()...)
+	for _, flownode := range flownodes {
+		if flownode.GetPodDisruptionBudget().IsEnabled() {
+			expected[common.ResourceName(cluster.Name, v1alpha1.FlownodeRoleKind, flownode.GetName())] = true
+		}
+	}
+
+	frontends := append([]*v1alpha1.FrontendSpec{cluster.GetFrontend()}, cluster.GetFrontendGroups()...)
+	for _, frontend := range frontends {
+		if frontend.GetPodDisruptionBudget().IsEnabled() {
+			expected[common.ResourceName(cluster.Name, v1alpha1.FrontendRoleKind, frontend.GetName())] = true
+		}
+	}
+
+	return expected
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"testing"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+)
+
+func TestSweepPodDisruptionBudgets(t *testing.T) {
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "test-uid"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			Meta: &v1alpha1.MetaSpec{
+				ComponentSpec: v1alpha1.ComponentSpec{PodDisruptionBudget: &v1alpha1.PodDisruptionBudgetSpec{Enabled: ptr.To(true)}},
+			},
+			Datanode: &v1alpha1.DatanodeSpec{},
+			FrontendGroups: []*v1alpha1.FrontendSpec{
+				{
+					Name:          "read",
+					ComponentSpec: v1alpha1.ComponentSpec{PodDisruptionBudget: &v1alpha1.PodDisruptionBudgetSpec{Enabled: ptr.To(true)}},
+				},
+			},
+		},
+	}
+
+	objects := []client.Object{cluster}
+	for _, name := range []string{"test-meta", "test-datanode", "test-frontend-read", "test-frontend-write"} {
+		pdb := &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		if err := controllerutil.SetControllerReference(cluster, pdb, testScheme); err != nil {
+			t.Fatal(err)
+		}
+		objects = append(objects, pdb)
+	}
+	// The PodDisruptionBudget that is not created by the operator.
+	objects = append(objects, &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: "test-flownode", Namespace: "default"}})
+
+	r := newTestReconciler(objects...)
+
+	ctx := context.Background()
+	if err := r.sweepPodDisruptionBudgets(ctx, cluster); err != nil {
+		t.Fatal(err)
+	}
+
+	var pdbs policyv1.PodDisruptionBudgetList
+	if err := r.List(ctx, &pdbs, client.InNamespace("default")); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, pdb := range pdbs.Items {
+		names = append(names, pdb.Name)
+	}
+
+	if len(names) != 3 || names[0] != "test-flownode" || names[1] != "test-frontend-read" || names[2] != "test-meta" {
+		t.Errorf("expected the PodDisruptionBudgets [test-flownode test-frontend-read test-meta] to be kept, got: %v", names)
+	}
+}
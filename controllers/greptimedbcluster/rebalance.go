@@ -0,0 +1,180 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/greptimedbcluster/deployers"
+)
+
+// rebalanceCheckInterval is the interval to check the progress of the region migrations.
+const rebalanceCheckInterval = 10 * time.Second
+
+// rebalanceRegions migrates the regions to the new datanodes after the datanodes are scaled out and ready.
+// It returns the duration after which the rebalance should be checked again.
+func (r *Reconciler) rebalanceRegions(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) (time.Duration, error) {
+	var (
+		original  = cluster.Status.DeepCopy()
+		running   int
+		pending   int32
+		requeue   bool
+		groupIDs  []*int32
+		datanodes []*v1alpha1.DatanodeSpec
+	)
+
+	if datanode := cluster.GetDatanode(); datanode != nil {
+		groupIDs = append(groupIDs, nil)
+		datanodes = append(datanodes, datanode)
+	}
+	for i, group := range cluster.GetDatanodeGroups() {
+		groupIDs = append(groupIDs, ptr.To(int32(i)))
+		datanodes = append(datanodes, group)
+	}
+
+	for i, spec := range datanodes {
+		if !spec.GetRebalance().IsEnabled() {
+			continue
+		}
+
+		status, err := r.rebalanceStatefulSet(cluster, groupIDs[i], spec)
+		if err != nil {
+			// The metasrv may be temporarily unavailable, so we retry later instead of failing the whole reconciliation.
+			klog.Errorf("Failed to rebalance the regions of the cluster '%s/%s': %v", cluster.Namespace, cluster.Name, err)
+			requeue = true
+			continue
+		}
+
+		if status != nil {
+			running += len(status.Migrations)
+			pending += status.PendingMigrations
+			requeue = true
+		}
+	}
+
+	if running > 0 || pending > 0 {
+		cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeRebalancing, corev1.ConditionTrue, "Rebalancing",
+			fmt.Sprintf("%d region migrations are running, %d are pending", running, pending)))
+	} else if condition := cluster.Status.GetCondition(v1alpha1.ConditionTypeRebalancing); condition != nil && condition.Status == corev1.ConditionTrue && !requeue {
+		cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeRebalancing, corev1.ConditionFalse, "RebalanceCompleted", "the regions are balanced"))
+	}
+
+	if !equality.Semantic.DeepEqual(original, &cluster.Status) {
+		if err := deployers.UpdateStatus(ctx, cluster, r.Client); err != nil {
+			return 0, err
+		}
+	}
+
+	if requeue {
+		return rebalanceCheckInterval, nil
+	}
+
+	return 0, nil
+}
+
+// rebalanceStatefulSet submits the region migrations of the datanode StatefulSet with the concurrency limit.
+// It returns the rebalance status if the rebalance is in progress.
+func (r *Reconciler) rebalanceStatefulSet(cluster *v1alpha1.GreptimeDBCluster, groupID *int32, spec *v1alpha1.DatanodeSpec) (*v1alpha1.DatanodeRebalanceStatus, error) {
+	var (
+		name     = common.ResourceName(cluster.Name, v1alpha1.DatanodeRoleKind, spec.GetName())
+		replicas = ptr.Deref(spec.GetReplicas(), 0)
+		status   = cluster.Status.Datanode.GetRebalance(name)
+	)
+
+	// Record the replicas for the first time, or the datanodes are scaled in.
+	if status == nil || replicas < status.BalancedReplicas {
+		cluster.Status.Datanode.SetRebalance(v1alpha1.DatanodeRebalanceStatus{StatefulSet: name, BalancedReplicas: replicas})
+		return nil, nil
+	}
+
+	if replicas == status.BalancedReplicas {
+		return nil, nil
+	}
+
+	metaHTTPServiceURL := r.metaHTTPServiceURL(cluster)
+	regions, err := common.GetDatanodeRegions(metaHTTPServiceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.StartTime == nil {
+		status.StartTime = ptrNow()
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "RebalanceStarted",
+			fmt.Sprintf("Rebalancing the regions of statefulset '%s' after scaling out from %d to %d replicas", name, status.BalancedReplicas, replicas))
+	}
+
+	// The submitted migrations are running until the regions leave the source datanodes.
+	var running []v1alpha1.RegionMigrationStatus
+	for _, migration := range status.Migrations {
+		index := slices.Index(regions[migration.FromPeerID], migration.RegionID)
+		if index < 0 || migration.SubmitTime == nil || time.Since(migration.SubmitTime.Time) >= common.RegionMigrationTimeout {
+			continue
+		}
+
+		running = append(running, migration)
+
+		// Plan the rest migrations as if the running migrations are finished.
+		regions[migration.FromPeerID] = slices.Delete(regions[migration.FromPeerID], index, index+1)
+		regions[migration.ToPeerID] = append(regions[migration.ToPeerID], migration.RegionID)
+	}
+
+	var nodeIDs []uint64
+	for ordinal := int32(0); ordinal < replicas; ordinal++ {
+		nodeIDs = append(nodeIDs, deployers.DatanodeNodeID(groupID, spec, ordinal))
+	}
+
+	planned := common.PlanRegionRebalance(regions, nodeIDs)
+	if len(planned) == 0 && len(running) == 0 {
+		klog.Infof("The regions of statefulset '%s/%s' are balanced", cluster.Namespace, name)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "RebalanceCompleted", fmt.Sprintf("The regions of statefulset '%s' are balanced", name))
+		cluster.Status.Datanode.SetRebalance(v1alpha1.DatanodeRebalanceStatus{StatefulSet: name, BalancedReplicas: replicas})
+		return nil, nil
+	}
+
+	maxConcurrentMigrations := int(spec.GetRebalance().GetMaxConcurrentMigrations())
+	for len(planned) > 0 && len(running) < maxConcurrentMigrations {
+		migration := planned[0]
+		procedureID, err := common.SubmitRegionMigration(metaHTTPServiceURL, migration)
+		if err != nil {
+			// Record the submitted migrations before returning the error.
+			status.Migrations = running
+			return nil, err
+		}
+		planned = planned[1:]
+
+		running = append(running, v1alpha1.RegionMigrationStatus{
+			RegionID:    migration.RegionID,
+			FromPeerID:  migration.FromPeerID,
+			ToPeerID:    migration.ToPeerID,
+			ProcedureID: procedureID,
+			SubmitTime:  ptrNow(),
+		})
+	}
+
+	status.Migrations = running
+	status.PendingMigrations = int32(len(planned))
+
+	return status, nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+)
+
+// fakeMetasrv serves the heartbeats of the datanodes and records the submitted region migrations.
+type fakeMetasrv struct {
+	sync.Mutex
+
+	// regions are the leader regions of the datanodes.
+	regions    map[uint64][]uint64
+	heartbeats int
+	submitted  []string
+}
+
+func (m *fakeMetasrv) setRegions(regions map[uint64][]uint64) {
+	m.Lock()
+	defer m.Unlock()
+	m.regions = regions
+}
+
+func (m *fakeMetasrv) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.Lock()
+	defer m.Unlock()
+
+	switch r.URL.Path {
+	case "/admin/heartbeat":
+		m.heartbeats++
+		fmt.Fprint(w, "[")
+		first := true
+		for id, regionIDs := range m.regions {
+			if !first {
+				fmt.Fprint(w, ",")
+			}
+			first = false
+			fmt.Fprintf(w, `{"stats": [{"id": %d, "addr": "datanode-%d", "region_stats": [`, id, id)
+			for i, regionID := range regionIDs {
+				if i > 0 {
+					fmt.Fprint(w, ",")
+				}
+				fmt.Fprintf(w, `{"id": %d, "role": "Leader"}`, regionID)
+			}
+			fmt.Fprint(w, "]}]}")
+		}
+		fmt.Fprint(w, "]")
+	case "/admin/region-migration":
+		query := r.URL.Query()
+		m.submitted = append(m.submitted, fmt.Sprintf("%s:%s->%s", query.Get("region_id"), query.Get("from_peer_id"), query.Get("to_peer_id")))
+		fmt.Fprintf(w, `{"procedure_id": "procedure-%d"}`, len(m.submitted))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestRebalanceRegions(t *testing.T) {
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			Meta: &v1alpha1.MetaSpec{HTTPPort: 4000},
+			Datanode: &v1alpha1.DatanodeSpec{
+				ComponentSpec: v1alpha1.ComponentSpec{Replicas: ptr.To(int32(2))},
+				Rebalance:     &v1alpha1.RebalancePolicy{Enabled: true, MaxConcurrentMigrations: ptr.To(int32(1))},
+			},
+		},
+	}
+
+	metasrv := &fakeMetasrv{regions: map[uint64][]uint64{0: {1, 2, 3}, 1: {4, 5, 6}}}
+	server := httptest.NewServer(metasrv)
+	defer server.Close()
+
+	r := newTestReconciler(cluster)
+	r.metaHTTPServiceURL = func(_ *v1alpha1.GreptimeDBCluster) string {
+		return server.URL
+	}
+
+	ctx := context.Background()
+	rebalance := func(wantRequeue bool) {
+		t.Helper()
+		requeueAfter, err := r.rebalanceRegions(ctx, cluster)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (requeueAfter > 0) != wantRequeue {
+			t.Fatalf("want requeue %v, got: %s", wantRequeue, requeueAfter)
+		}
+	}
+
+	// The replicas are recorded for the first time without rebalancing.
+	rebalance(false)
+	if status := cluster.Status.Datanode.GetRebalance("test-datanode"); status == nil || status.BalancedReplicas != 2 {
+		t.Fatalf("expected the balanced replicas to be recorded, got: %+v", status)
+	}
+	if metasrv.heartbeats != 0 {
+		t.Errorf("expected no request to the metasrv before scaling out, got: %d", metasrv.heartbeats)
+	}
+
+	// The regions are migrated to the new datanode after scaling out, one migration at a time.
+	cluster.Spec.Datanode.Replicas = ptr.To(int32(3))
+	metasrv.setRegions(map[uint64][]uint64{0: {1, 2, 3}, 1: {4, 5, 6}, 2: {}})
+	rebalance(true)
+
+	if want := []string{"3:0->2"}; !reflect.DeepEqual(metasrv.submitted, want) {
+		t.Fatalf("unexpected region migrations, want: %v, got: %v", want, metasrv.submitted)
+	}
+	status := cluster.Status.Datanode.GetRebalance("test-datanode")
+	if status == nil || status.StartTime == nil || len(status.Migrations) != 1 || status.PendingMigrations != 1 {
+		t.Fatalf("unexpected rebalance status: %+v", status)
+	}
+	if condition := cluster.Status.GetCondition(v1alpha1.ConditionTypeRebalancing); condition == nil || condition.Status != corev1.ConditionTrue {
+		t.Errorf("expected the Rebalancing condition to be true, got: %+v", condition)
+	}
+
+	// The running migration is not submitted again across the requeues.
+	for i := 0; i < 3; i++ {
+		rebalance(true)
+	}
+	if len(metasrv.submitted) != 1 {
+		t.Fatalf("expected the running migration not to be submitted again, got: %v", metasrv.submitted)
+	}
+
+	// The next migration is submitted after the running one is finished.
+	metasrv.setRegions(map[uint64][]uint64{0: {1, 2}, 1: {4, 5, 6}, 2: {3}})
+	rebalance(true)
+	rebalance(true)
+	if want := []string{"3:0->2", "6:1->2"}; !reflect.DeepEqual(metasrv.submitted, want) {
+		t.Fatalf("unexpected region migrations, want: %v, got: %v", want, metasrv.submitted)
+	}
+
+	// The rebalance is completed after all the migrations are finished.
+	metasrv.setRegions(map[uint64][]uint64{0: {1, 2}, 1: {4, 5}, 2: {3, 6}})
+	rebalance(false)
+	if status := cluster.Status.Datanode.GetRebalance("test-datanode"); status.BalancedReplicas != 3 || len(status.Migrations) != 0 || status.StartTime != nil {
+		t.Errorf("expected the rebalance to be completed, got: %+v", status)
+	}
+	if condition := cluster.Status.GetCondition(v1alpha1.ConditionTypeRebalancing); condition == nil || condition.Status != corev1.ConditionFalse {
+		t.Errorf("expected the Rebalancing condition to be false, got: %+v", condition)
+	}
+
+	// No more request is sent to the metasrv once the regions are balanced.
+	heartbeats := metasrv.heartbeats
+	rebalance(false)
+	if metasrv.heartbeats != heartbeats || len(metasrv.submitted) != 2 {
+		t.Errorf("expected no more request to the metasrv, got: %d heartbeats, %v", metasrv.heartbeats-heartbeats, metasrv.submitted)
+	}
+
+	current := new(v1alpha1.GreptimeDBCluster)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(cluster), current); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(current.Status.Datanode.Rebalances, cluster.Status.Datanode.Rebalances) {
+		t.Errorf("expected the rebalance status to be persisted, got: %+v", current.Status.Datanode.Rebalances)
+	}
+}
+
+func TestRebalanceRegionsDisabled(t *testing.T) {
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			Meta: &v1alpha1.MetaSpec{HTTPPort: 4000},
+			Datanode: &v1alpha1.DatanodeSpec{
+				ComponentSpec: v1alpha1.ComponentSpec{Replicas: ptr.To(int32(3))},
+			},
+		},
+		Status: v1alpha1.GreptimeDBClusterStatus{
+			Datanode: v1alpha1.DatanodeStatus{
+				Rebalances: []v1alpha1.DatanodeRebalanceStatus{{StatefulSet: "test-datanode", BalancedReplicas: 2}},
+			},
+		},
+	}
+
+	metasrv := &fakeMetasrv{regions: map[uint64][]uint64{0: {1, 2, 3}, 1: {4, 5, 6}, 2: {}}}
+	server := httptest.NewServer(metasrv)
+	defer server.Close()
+
+	r := newTestReconciler(cluster)
+	r.metaHTTPServiceURL = func(_ *v1alpha1.GreptimeDBCluster) string {
+		return server.URL
+	}
+
+	// The regions are not rebalanced after scaling out unless it's opted in.
+	requeueAfter, err := r.rebalanceRegions(context.Background(), cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requeueAfter != 0 || metasrv.heartbeats != 0 || len(metasrv.submitted) != 0 {
+		t.Errorf("expected no rebalance, got: requeue after %s, %d heartbeats, %v", requeueAfter, metasrv.heartbeats, metasrv.submitted)
+	}
+	if condition := cluster.Status.GetCondition(v1alpha1.ConditionTypeRebalancing); condition != nil {
+		t.Errorf("expected no Rebalancing condition, got: %+v", condition)
+	}
+}
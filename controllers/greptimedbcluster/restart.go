@@ -0,0 +1,160 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/greptimedbcluster/deployers"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+)
+
+// restartRequest is the restart request of the component or the group.
+type restartRequest struct {
+	// group is the name of the group, and it's empty if the component has no groups.
+	group       string
+	workload    string
+	restartedAt *metav1.Time
+}
+
+// syncRestartStatus records the restart requests that are applied to the workloads of the component in the status,
+// and marks them as completed when the component is ready.
+func (r *Reconciler) syncRestartStatus(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, kind v1alpha1.RoleKind, ready bool) error {
+	changed := false
+	for _, request := range restartRequests(cluster, kind) {
+		applied, err := r.isRestartApplied(ctx, cluster.Namespace, kind, request)
+		if err != nil {
+			return err
+		}
+
+		// The restart is not applied yet, for example, it's waiting for the maintenance window.
+		if !applied {
+			continue
+		}
+
+		status := cluster.Status.GetRestart(kind, request.group)
+		if status == nil || !status.RestartedAt.Equal(request.restartedAt) {
+			klog.Infof("Restart the pods of '%s/%s' requested at %s", cluster.Namespace, request.workload, request.restartedAt)
+			r.Recorder.Event(cluster, corev1.EventTypeNormal, "RestartStarted", fmt.Sprintf("Restart of '%s' requested at %s started", request.workload, deployers.FormatRestartedAt(request.restartedAt)))
+
+			setRestartStatus(cluster, v1alpha1.RestartStatus{
+				Component:   kind,
+				Name:        request.group,
+				RestartedAt: *request.restartedAt,
+			})
+			status = cluster.Status.GetRestart(kind, request.group)
+			changed = true
+		}
+
+		if ready && status.CompletionTime == nil {
+			status.CompletionTime = ptrNow()
+			r.Recorder.Event(cluster, corev1.EventTypeNormal, "RestartCompleted", fmt.Sprintf("Restart of '%s' requested at %s completed", request.workload, deployers.FormatRestartedAt(request.restartedAt)))
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return deployers.UpdateStatus(ctx, cluster, r.Client)
+}
+
+// isRestartApplied checks whether the pod template of the workload has the restartedAt of the request.
+func (r *Reconciler) isRestartApplied(ctx context.Context, namespace string, kind v1alpha1.RoleKind, request restartRequest) (bool, error) {
+	var (
+		objectKey = client.ObjectKey{Namespace: namespace, Name: request.workload}
+		template  *corev1.PodTemplateSpec
+	)
+
+	if kind == v1alpha1.MetaRoleKind || kind == v1alpha1.FrontendRoleKind {
+		deployment := new(appsv1.Deployment)
+		if err := r.Get(ctx, objectKey, deployment); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		template = &deployment.Spec.Template
+	} else {
+		sts := new(appsv1.StatefulSet)
+		if err := r.Get(ctx, objectKey, sts); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		template = &sts.Spec.Template
+	}
+
+	return template.Annotations[deployer.RestartedAt] == deployers.FormatRestartedAt(request.restartedAt), nil
+}
+
+// restartRequests returns the restart requests of the component and its groups.
+func restartRequests(cluster *v1alpha1.GreptimeDBCluster, kind v1alpha1.RoleKind) []restartRequest {
+	var requests []restartRequest
+	add := func(group string, restartedAt *metav1.Time) {
+		if restartedAt == nil {
+			return
+		}
+		requests = append(requests, restartRequest{
+			group:       group,
+			workload:    common.ResourceName(cluster.Name, kind, group),
+			restartedAt: restartedAt,
+		})
+	}
+
+	switch kind {
+	case v1alpha1.MetaRoleKind:
+		add("", cluster.GetMeta().GetRestartedAt())
+	case v1alpha1.FlownodeRoleKind:
+		if groups := cluster.GetFlownodeGroups(); len(groups) > 0 {
+			for _, group := range groups {
+				add(group.GetName(), group.GetRestartedAt())
+			}
+		} else {
+			add("", cluster.GetFlownode().GetRestartedAt())
+		}
+	case v1alpha1.DatanodeRoleKind:
+		if groups := cluster.GetDatanodeGroups(); len(groups) > 0 {
+			for _, group := range groups {
+				add(group.GetName(), group.GetRestartedAt())
+			}
+		} else {
+			add("", cluster.GetDatanode().GetRestartedAt())
+		}
+	case v1alpha1.FrontendRoleKind:
+		if groups := cluster.GetFrontendGroups(); len(groups) > 0 {
+			for _, group := range groups {
+				add(group.GetName(), group.GetRestartedAt())
+			}
+		} else {
+			add("", cluster.GetFrontend().GetRestartedAt())
+		}
+	}
+
+	return requests
+}
+
+func setRestartStatus(cluster *v1alpha1.GreptimeDBCluster, status v1alpha1.RestartStatus) {
+	if current := cluster.Status.GetRestart(status.Component, status.Name); current != nil {
+		*current = status
+		return
+	}
+	cluster.Status.Restarts = append(cluster.Status.Restarts, status)
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+)
+
+func TestSyncRestartStatus(t *testing.T) {
+	restartedAt := metav1.NewTime(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			Frontend: &v1alpha1.FrontendSpec{
+				ComponentSpec: v1alpha1.ComponentSpec{RestartedAt: &restartedAt},
+			},
+			Meta: &v1alpha1.MetaSpec{
+				ComponentSpec: v1alpha1.ComponentSpec{RestartedAt: &restartedAt},
+			},
+		},
+	}
+
+	// The restart of the frontend is applied, but the restart of the meta is still held.
+	frontend := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test-frontend", Namespace: "default"}}
+	frontend.Spec.Template.Annotations = map[string]string{deployer.RestartedAt: "2026-10-16T10:00:00Z"}
+	meta := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test-meta", Namespace: "default"}}
+
+	r := newTestReconciler(cluster, frontend, meta)
+
+	ctx := context.Background()
+	if err := r.syncRestartStatus(ctx, cluster, v1alpha1.MetaRoleKind, true); err != nil {
+		t.Fatal(err)
+	}
+	if status := cluster.Status.GetRestart(v1alpha1.MetaRoleKind, ""); status != nil {
+		t.Errorf("expected no restart status of the meta before the restart is applied, got: %v", status)
+	}
+
+	if err := r.syncRestartStatus(ctx, cluster, v1alpha1.FrontendRoleKind, false); err != nil {
+		t.Fatal(err)
+	}
+	status := cluster.Status.GetRestart(v1alpha1.FrontendRoleKind, "")
+	if status == nil || !status.RestartedAt.Equal(&restartedAt) || status.CompletionTime != nil {
+		t.Fatalf("expected the restart of the frontend to be in progress, got: %v", status)
+	}
+
+	if err := r.syncRestartStatus(ctx, cluster, v1alpha1.FrontendRoleKind, true); err != nil {
+		t.Fatal(err)
+	}
+	if status := cluster.Status.GetRestart(v1alpha1.FrontendRoleKind, ""); status.CompletionTime == nil {
+		t.Errorf("expected the restart of the frontend to be completed, got: %v", status)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+)
+
+// sweepOrphanPVCs deletes the datanode PVCs of the removed replicas if the storage retain policy when scaled is `Delete`.
+// The StatefulSet deletes the PVCs by itself after the retention policy is set,
+// but the PVCs that are left by the scale-in before the policy is set have to be deleted by the operator.
+func (r *Reconciler) sweepOrphanPVCs(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) error {
+	var datanodes []*v1alpha1.DatanodeSpec
+	if datanode := cluster.GetDatanode(); datanode != nil {
+		datanodes = append(datanodes, datanode)
+	}
+	datanodes = append(datanodes, cluster.GetDatanodeGroups()...)
+
+	for _, spec := range datanodes {
+		fs := spec.GetFileStorage()
+		if !fs.IsUsePVC() || fs.GetPolicyWhenScaled() != v1alpha1.StorageRetainPolicyTypeDelete {
+			continue
+		}
+
+		name := common.ResourceName(cluster.Name, v1alpha1.DatanodeRoleKind, spec.GetName())
+		sts := new(appsv1.StatefulSet)
+		if err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: name}, sts); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		// The replicas of the StatefulSet may not be decreased yet when the regions are being migrated.
+		replicas := max(ptr.Deref(spec.GetReplicas(), 0), ptr.Deref(sts.Spec.Replicas, 0))
+
+		for _, fsType := range []common.FileStorageType{common.FileStorageTypeDatanode, common.FileStorageTypeWAL, common.FileStorageTypeCache} {
+			claims, err := common.GetPVCs(ctx, r.Client, cluster.Namespace, name, fsType)
+			if err != nil {
+				return err
+			}
+
+			for _, claim := range claims {
+				ordinal, ok := pvcOrdinal(claim.Name, name)
+				if !ok || ordinal < replicas || claim.DeletionTimestamp != nil {
+					continue
+				}
+
+				// The pod may be still terminating after the scale-in.
+				podName := fmt.Sprintf("%s-%d", name, ordinal)
+				if err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: podName}, new(corev1.Pod)); err == nil {
+					continue
+				} else if !k8serrors.IsNotFound(err) {
+					return err
+				}
+
+				klog.Infof("Delete the orphan PVC '%s/%s' of the removed datanode '%s'", claim.Namespace, claim.Name, podName)
+				if err := r.Delete(ctx, &claim); err != nil && !k8serrors.IsNotFound(err) {
+					return err
+				}
+				r.Recorder.Event(cluster, corev1.EventTypeNormal, "OrphanPVCDeleted", fmt.Sprintf("Deleted the PVC '%s' of the removed datanode '%s'", claim.Name, podName))
+			}
+		}
+	}
+
+	return nil
+}
+
+// pvcOrdinal returns the ordinal of the PVC that is created by the StatefulSet, and the name of the PVC is `${template}-${statefulset}-${ordinal}`.
+func pvcOrdinal(claimName, stsName string) (int32, bool) {
+	index := strings.LastIndex(claimName, "-")
+	if index < 0 || !strings.HasSuffix(claimName[:index], "-"+stsName) {
+		return 0, false
+	}
+
+	ordinal, err := strconv.ParseInt(claimName[index+1:], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return int32(ordinal), true
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/constant"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+)
+
+func TestSweepOrphanPVCs(t *testing.T) {
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			Datanode: &v1alpha1.DatanodeSpec{
+				ComponentSpec: v1alpha1.ComponentSpec{Replicas: ptr.To(int32(2))},
+				Storage: &v1alpha1.DatanodeStorageSpec{
+					FileStorage: &v1alpha1.FileStorage{
+						Name:                          "datanode",
+						StorageRetainPolicyWhenScaled: v1alpha1.StorageRetainPolicyTypeDelete,
+					},
+				},
+			},
+		},
+	}
+
+	objects := []client.Object{
+		cluster,
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-datanode", Namespace: "default"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(2))},
+		},
+		// The pod of the removed datanode is still terminating.
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-datanode-3", Namespace: "default"}},
+	}
+
+	for ordinal := 0; ordinal < 4; ordinal++ {
+		for _, fsType := range []common.FileStorageType{common.FileStorageTypeDatanode, common.FileStorageTypeWAL} {
+			labels := map[string]string{constant.GreptimeDBComponentName: "test-datanode"}
+			if fsType != common.FileStorageTypeDatanode {
+				labels[common.FileStorageTypeLabelKey] = string(fsType)
+			}
+			objects = append(objects, &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-test-datanode-%d", fsType, ordinal),
+					Namespace: "default",
+					Labels:    labels,
+				},
+			})
+		}
+	}
+
+	r := newTestReconciler(objects...)
+
+	ctx := context.Background()
+	if err := r.sweepOrphanPVCs(ctx, cluster); err != nil {
+		t.Fatal(err)
+	}
+
+	for ordinal := 0; ordinal < 4; ordinal++ {
+		for _, fsType := range []common.FileStorageType{common.FileStorageTypeDatanode, common.FileStorageTypeWAL} {
+			name := fmt.Sprintf("%s-test-datanode-%d", fsType, ordinal)
+			err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, new(corev1.PersistentVolumeClaim))
+
+			wantDeleted := ordinal == 2
+			if deleted := k8serrors.IsNotFound(err); deleted != wantDeleted {
+				t.Errorf("PVC '%s': want deleted %v, got: %v", name, wantDeleted, err)
+			}
+		}
+	}
+}
+
+func TestHibernationPVCRetentionPolicy(t *testing.T) {
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				deployer.LastAppliedResourceSpec: `{"persistentVolumeClaimRetentionPolicy":{"whenDeleted":"Retain","whenScaled":"Delete"}}`,
+			},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To(int32(3)),
+			PersistentVolumeClaimRetentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenDeleted: appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+				WhenScaled:  appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+			},
+		},
+	}
+
+	// The PVCs are kept when the StatefulSet is scaled to zero for hibernation.
+	hibernated := setWorkloadReplicas(sts, 0).(*appsv1.StatefulSet)
+	if policy := hibernated.Spec.PersistentVolumeClaimRetentionPolicy; policy.WhenScaled != appsv1.RetainPersistentVolumeClaimRetentionPolicyType {
+		t.Fatalf("expected the PVCs to be retained when hibernated, got: %v", policy)
+	}
+
+	// The policy is restored from the last applied spec when it's woken up.
+	awake := setWorkloadReplicas(hibernated, 3).(*appsv1.StatefulSet)
+	if policy := awake.Spec.PersistentVolumeClaimRetentionPolicy; policy.WhenScaled != appsv1.DeletePersistentVolumeClaimRetentionPolicyType {
+		t.Fatalf("expected the policy to be restored when woken up, got: %v", policy)
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/constant"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/greptimedbcluster/deployers"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/util"
+	k8sutils "github.com/GreptimeTeam/greptimedb-operator/pkg/util/k8s"
+)
+
+const (
+	reasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+)
+
+// checkProgressDeadline rolls back the workloads that are not ready when the progress deadline is exceeded.
+// Otherwise, it requeues the cluster before the deadline.
+func (r *Reconciler) checkProgressDeadline(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, requeueAfter time.Duration) (ctrl.Result, error) {
+	deadline := cluster.GetRolloutPolicy().GetProgressDeadline()
+	if deadline == nil {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	progressing := cluster.Status.GetCondition(v1alpha1.ConditionTypeProgressing)
+	if progressing == nil || progressing.Status != corev1.ConditionTrue {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	remaining := deadline.Duration - time.Since(progressing.LastTransitionTime.Time)
+	if remaining > 0 {
+		if requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	return ctrl.Result{}, r.rollback(ctx, cluster, deadline.Duration)
+}
+
+// rollback re-applies the previous applied spec of the StatefulSets and Deployments that are not ready,
+// and records the generation of the cluster, so the failed spec will not be applied again.
+func (r *Reconciler) rollback(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, deadline time.Duration) error {
+	rolledBack, err := r.rollbackWorkloads(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("the components are not ready in %s", deadline)
+
+	// Nothing can be rolled back, for example, the cluster is just created.
+	if len(rolledBack) == 0 {
+		klog.Warningf("The progress deadline of the cluster '%s/%s' is exceeded, but there is nothing to roll back", cluster.Namespace, cluster.Name)
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, reasonProgressDeadlineExceeded, fmt.Sprintf("Progress deadline exceeded: %s", message))
+		cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeProgressing, corev1.ConditionFalse, reasonProgressDeadlineExceeded, message))
+		return deployers.UpdateStatus(ctx, cluster, r.Client)
+	}
+
+	message = fmt.Sprintf("%s, rolled back %s", message, strings.Join(rolledBack, ", "))
+	klog.Warningf("Roll back the cluster '%s/%s': %s", cluster.Namespace, cluster.Name, message)
+	r.Recorder.Event(cluster, corev1.EventTypeWarning, "RolledBack", fmt.Sprintf("Rolled back generation %d: %s", cluster.Generation, message))
+
+	cluster.Status.RolledBackGeneration = cluster.Generation
+	cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeRolledBack, corev1.ConditionTrue, reasonProgressDeadlineExceeded, message))
+	cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeProgressing, corev1.ConditionFalse, reasonProgressDeadlineExceeded, message))
+
+	return deployers.UpdateStatus(ctx, cluster, r.Client)
+}
+
+// syncRolledBack waits for the rolled back workloads to be ready without applying the failed spec again.
+// The status of the components is still refreshed, and the orphan PVCs and the disabled PodDisruptionBudgets are still swept.
+func (r *Reconciler) syncRolledBack(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) (ctrl.Result, error) {
+	for _, d := range r.Deployers {
+		if _, ok := componentKind(d); !ok {
+			continue
+		}
+		if _, err := d.CheckAndUpdateStatus(ctx, cluster); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.sweepOrphanPVCs(ctx, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.sweepPodDisruptionBudgets(ctx, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	deployments, statefulSets, err := r.listWorkloads(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	for i := range deployments {
+		if !k8sutils.IsDeploymentRolledOut(&deployments[i]) {
+			return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+		}
+	}
+
+	for i := range statefulSets {
+		if !k8sutils.IsStatefulSetRolledOut(&statefulSets[i]) {
+			return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+		}
+	}
+
+	if err := r.updateClusterStatus(ctx, cluster, v1alpha1.PhaseRunning); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// clearRolledBack marks the RolledBack condition as false when the cluster spec is changed after the rollback.
+func (r *Reconciler) clearRolledBack(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) error {
+	condition := cluster.Status.GetCondition(v1alpha1.ConditionTypeRolledBack)
+	if condition == nil || condition.Status != corev1.ConditionTrue || cluster.Status.RolledBackGeneration == cluster.Generation {
+		return nil
+	}
+
+	cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeRolledBack, corev1.ConditionFalse, "SpecChanged", "the cluster spec is changed after the rollback"))
+
+	return deployers.UpdateStatus(ctx, cluster, r.Client)
+}
+
+func (r *Reconciler) rollbackWorkloads(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) ([]string, error) {
+	deployments, statefulSets, err := r.listWorkloads(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var rolledBack []string
+
+	for i := range deployments {
+		deployment := &deployments[i]
+		previous := previousAppliedResourceSpec(deployment)
+		if previous == "" || k8sutils.IsDeploymentRolledOut(deployment) {
+			continue
+		}
+
+		var spec appsv1.DeploymentSpec
+		if err := json.Unmarshal([]byte(previous), &spec); err != nil {
+			return nil, err
+		}
+
+		if err := r.rollbackConfigMap(ctx, cluster.Namespace, deployment.Name, &spec.Template); err != nil {
+			return nil, err
+		}
+
+		patched := deployment.DeepCopy()
+		patched.Spec = spec
+		restoreLastAppliedResourceSpec(patched, previous)
+		if err := r.Patch(ctx, patched, client.MergeFrom(deployment)); err != nil {
+			return nil, err
+		}
+
+		rolledBack = append(rolledBack, fmt.Sprintf("deployment '%s'", deployment.Name))
+	}
+
+	for i := range statefulSets {
+		sts := &statefulSets[i]
+		previous := previousAppliedResourceSpec(sts)
+		if previous == "" || k8sutils.IsStatefulSetRolledOut(sts) {
+			continue
+		}
+
+		var spec appsv1.StatefulSetSpec
+		if err := json.Unmarshal([]byte(previous), &spec); err != nil {
+			return nil, err
+		}
+
+		// The volume claim templates are immutable and the expanded PVCs can't be shrunk, so the live ones are kept.
+		// If they are changed by the failed spec, the change of the storage will be handled when the spec is changed again.
+		spec.VolumeClaimTemplates = sts.Spec.VolumeClaimTemplates
+
+		if err := r.rollbackConfigMap(ctx, cluster.Namespace, sts.Name, &spec.Template); err != nil {
+			return nil, err
+		}
+
+		patched := sts.DeepCopy()
+		patched.Spec = spec
+		restoreLastAppliedResourceSpec(patched, previous)
+		if err := r.Patch(ctx, patched, client.MergeFrom(sts)); err != nil {
+			return nil, err
+		}
+
+		// The StatefulSet will not replace the pods that are not ready with the rolling update,
+		// so the broken pods are deleted to be recreated with the previous spec.
+		if err := r.deleteNotReadyPods(ctx, sts); err != nil {
+			return nil, err
+		}
+
+		rolledBack = append(rolledBack, fmt.Sprintf("statefulset '%s'", sts.Name))
+	}
+
+	return rolledBack, nil
+}
+
+// rollbackConfigMap restores the config of the component if the previous pod template is generated with the previous config.
+func (r *Reconciler) rollbackConfigMap(ctx context.Context, namespace, name string, template *corev1.PodTemplateSpec) error {
+	configHash, ok := template.Annotations[deployer.ConfigHash]
+	if !ok {
+		return nil
+	}
+
+	configMap := new(corev1.ConfigMap)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, configMap); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	// The config is not changed.
+	if util.CalculateConfigHash([]byte(configMap.Data[constant.GreptimeDBConfigFileName])) == configHash {
+		return nil
+	}
+
+	previous := previousAppliedResourceSpec(configMap)
+	if previous == "" {
+		return nil
+	}
+
+	var data map[string]string
+	if err := json.Unmarshal([]byte(previous), &data); err != nil {
+		return err
+	}
+
+	if util.CalculateConfigHash([]byte(data[constant.GreptimeDBConfigFileName])) != configHash {
+		klog.Warningf("The previous config of '%s/%s' does not match the previous pod template, skip rolling back the config", namespace, name)
+		return nil
+	}
+
+	patched := configMap.DeepCopy()
+	patched.Data = data
+	restoreLastAppliedResourceSpec(patched, previous)
+
+	return r.Patch(ctx, patched, client.MergeFrom(configMap))
+}
+
+func (r *Reconciler) deleteNotReadyPods(ctx context.Context, sts *appsv1.StatefulSet) error {
+	pods := new(corev1.PodList)
+	if err := r.List(ctx, pods, client.InNamespace(sts.Namespace), client.MatchingLabels(sts.Spec.Selector.MatchLabels)); err != nil {
+		return err
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if k8sutils.IsPodReady(pod) || pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		klog.Infof("Delete the pod '%s/%s' that is not ready for rolling back", pod.Namespace, pod.Name)
+		if err := r.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listWorkloads lists the Deployments and StatefulSets that are controlled by the cluster.
+func (r *Reconciler) listWorkloads(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) ([]appsv1.Deployment, []appsv1.StatefulSet, error) {
+	deploymentList := new(appsv1.DeploymentList)
+	if err := r.List(ctx, deploymentList, client.InNamespace(cluster.Namespace)); err != nil {
+		return nil, nil, err
+	}
+
+	statefulSetList := new(appsv1.StatefulSetList)
+	if err := r.List(ctx, statefulSetList, client.InNamespace(cluster.Namespace)); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		deployments  []appsv1.Deployment
+		statefulSets []appsv1.StatefulSet
+	)
+
+	for _, deployment := range deploymentList.Items {
+		if metav1.IsControlledBy(&deployment, cluster) {
+			deployments = append(deployments, deployment)
+		}
+	}
+
+	for _, sts := range statefulSetList.Items {
+		if metav1.IsControlledBy(&sts, cluster) {
+			statefulSets = append(statefulSets, sts)
+		}
+	}
+
+	return deployments, statefulSets, nil
+}
+
+// previousAppliedResourceSpec returns the previous applied spec of the object if it's different from the last applied spec.
+func previousAppliedResourceSpec(object client.Object) string {
+	annotations := object.GetAnnotations()
+	previous := annotations[deployer.PreviousAppliedResourceSpec]
+	if previous == annotations[deployer.LastAppliedResourceSpec] {
+		return ""
+	}
+	return previous
+}
+
+// restoreLastAppliedResourceSpec makes the previous applied spec as the last applied spec,
+// so the deployers can compare the rolled back object with the new generated object.
+func restoreLastAppliedResourceSpec(object client.Object, previous string) {
+	annotations := object.GetAnnotations()
+	annotations[deployer.LastAppliedResourceSpec] = previous
+	delete(annotations, deployer.PreviousAppliedResourceSpec)
+	object.SetAnnotations(annotations)
+}
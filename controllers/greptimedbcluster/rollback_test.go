@@ -0,0 +1,275 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/constant"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/util"
+)
+
+func TestRollbackWorkloads(t *testing.T) {
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid", Generation: 2},
+	}
+
+	newSpec := func(image, config string) appsv1.DeploymentSpec {
+		return appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test-frontend"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "test-frontend"},
+					Annotations: map[string]string{deployer.ConfigHash: util.CalculateConfigHash([]byte(config))},
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "frontend", Image: image}}},
+			},
+		}
+	}
+
+	marshal := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	var (
+		goodSpec   = newSpec("greptime/greptimedb:v0.14.0", "good")
+		badSpec    = newSpec("greptime/greptimedb:v0.14.1", "bad")
+		goodConfig = map[string]string{constant.GreptimeDBConfigFileName: "good"}
+		badConfig  = map[string]string{constant.GreptimeDBConfigFileName: "bad"}
+	)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-frontend",
+			Namespace: "default",
+			Annotations: map[string]string{
+				deployer.LastAppliedResourceSpec:     marshal(badSpec),
+				deployer.PreviousAppliedResourceSpec: marshal(goodSpec),
+			},
+		},
+		Spec: badSpec,
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-frontend",
+			Namespace: "default",
+			Annotations: map[string]string{
+				deployer.LastAppliedResourceSpec:     marshal(badConfig),
+				deployer.PreviousAppliedResourceSpec: marshal(goodConfig),
+			},
+		},
+		Data: badConfig,
+	}
+
+	for _, obj := range []client.Object{deployment, configMap} {
+		if err := controllerutil.SetControllerReference(cluster, obj, testScheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := newTestReconciler(cluster, deployment, configMap)
+
+	ctx := context.Background()
+	rolledBack, err := r.rollbackWorkloads(ctx, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rolledBack) != 1 {
+		t.Fatalf("expected 1 rolled back workload, got: %v", rolledBack)
+	}
+
+	gotDeployment := new(appsv1.Deployment)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(deployment), gotDeployment); err != nil {
+		t.Fatal(err)
+	}
+
+	if image := gotDeployment.Spec.Template.Spec.Containers[0].Image; image != "greptime/greptimedb:v0.14.0" {
+		t.Errorf("expected the image to be rolled back, got: %s", image)
+	}
+
+	if gotDeployment.Annotations[deployer.LastAppliedResourceSpec] != marshal(goodSpec) {
+		t.Errorf("expected the last applied spec to be the previous spec")
+	}
+
+	if _, ok := gotDeployment.Annotations[deployer.PreviousAppliedResourceSpec]; ok {
+		t.Errorf("expected the previous applied spec to be removed")
+	}
+
+	gotConfigMap := new(corev1.ConfigMap)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(configMap), gotConfigMap); err != nil {
+		t.Fatal(err)
+	}
+
+	if config := gotConfigMap.Data[constant.GreptimeDBConfigFileName]; config != "good" {
+		t.Errorf("expected the config to be rolled back, got: %s", config)
+	}
+}
+
+func TestRollbackStatefulSetWithVolumeClaimTemplates(t *testing.T) {
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid", Generation: 2},
+	}
+
+	newSpec := func(image, size string) appsv1.StatefulSetSpec {
+		return appsv1.StatefulSetSpec{
+			Replicas: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test-datanode"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test-datanode"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "datanode", Image: image}}},
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "datanode"},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	marshal := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	// The failed spec expanded the storage with the new image.
+	var (
+		goodSpec = newSpec("greptime/greptimedb:v0.14.0", "10Gi")
+		badSpec  = newSpec("greptime/greptimedb:v0.14.1", "20Gi")
+	)
+
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-datanode",
+			Namespace: "default",
+			Annotations: map[string]string{
+				deployer.LastAppliedResourceSpec:     marshal(badSpec),
+				deployer.PreviousAppliedResourceSpec: marshal(goodSpec),
+			},
+		},
+		Spec: badSpec,
+	}
+	if err := controllerutil.SetControllerReference(cluster, sts, testScheme); err != nil {
+		t.Fatal(err)
+	}
+
+	r := newTestReconciler(cluster, sts)
+
+	ctx := context.Background()
+	rolledBack, err := r.rollbackWorkloads(ctx, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rolledBack) != 1 {
+		t.Fatalf("expected 1 rolled back workload, got: %v", rolledBack)
+	}
+
+	got := new(appsv1.StatefulSet)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(sts), got); err != nil {
+		t.Fatal(err)
+	}
+
+	if image := got.Spec.Template.Spec.Containers[0].Image; image != "greptime/greptimedb:v0.14.0" {
+		t.Errorf("expected the image to be rolled back, got: %s", image)
+	}
+
+	// The volume claim templates are immutable, so they are not rolled back.
+	if size := got.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "20Gi" {
+		t.Errorf("expected the volume claim templates to be kept, got: %s", size.String())
+	}
+}
+
+func TestSyncRolledBack(t *testing.T) {
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid", Generation: 2},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			Meta:     &v1alpha1.MetaSpec{},
+			Datanode: &v1alpha1.DatanodeSpec{},
+		},
+		Status: v1alpha1.GreptimeDBClusterStatus{RolledBackGeneration: 2},
+	}
+
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-datanode", Namespace: "default", Generation: 1},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(1))},
+	}
+
+	// The PodDisruptionBudget of the meta is disabled after the rollback.
+	pdb := &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: "test-meta", Namespace: "default"}}
+
+	for _, obj := range []client.Object{sts, pdb} {
+		if err := controllerutil.SetControllerReference(cluster, obj, testScheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := newTestReconciler(cluster, sts, pdb)
+
+	ctx := context.Background()
+	result, err := r.syncRolledBack(ctx, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The sweeps are not blocked by the rolled back workloads that are not ready.
+	if result.RequeueAfter == 0 {
+		t.Errorf("expected to wait for the rolled back statefulset")
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(pdb), new(policyv1.PodDisruptionBudget)); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the disabled PodDisruptionBudget to be deleted, got: %v", err)
+	}
+
+	sts.Status = appsv1.StatefulSetStatus{ObservedGeneration: 1, UpdatedReplicas: 1, ReadyReplicas: 1}
+	if err := r.Status().Update(ctx, sts); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = r.syncRolledBack(ctx, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RequeueAfter != 0 || cluster.Status.ClusterPhase != v1alpha1.PhaseRunning {
+		t.Errorf("expected the cluster to be running after the rolled back statefulset is ready, got: %s", cluster.Status.ClusterPhase)
+	}
+}
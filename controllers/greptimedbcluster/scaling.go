@@ -0,0 +1,232 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/greptimedbcluster/deployers"
+)
+
+// scalingQueryTimeout is the timeout to evaluate all the metrics of the scaling policy.
+const scalingQueryTimeout = 10 * time.Second
+
+// scaleDatanodes evaluates the scaling policies of the datanodes and changes the replicas in the cluster spec if the datanodes should be scaled.
+// The new replicas are applied by the datanode deployer in the next reconciliation, so the regions are migrated off the removed datanodes
+// before the scale-in, and rebalanced to the new datanodes after the scale-out.
+// It returns the duration after which the metrics should be evaluated again.
+func (r *Reconciler) scaleDatanodes(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) (time.Duration, error) {
+	if cluster.Status.ClusterPhase != v1alpha1.PhaseRunning {
+		return 0, nil
+	}
+
+	var (
+		original     = cluster.Status.DeepCopy()
+		now          = time.Now()
+		requeueAfter time.Duration
+		groupIDs     []*int32
+		datanodes    []*v1alpha1.DatanodeSpec
+	)
+
+	if datanode := cluster.GetDatanode(); datanode != nil {
+		groupIDs = append(groupIDs, nil)
+		datanodes = append(datanodes, datanode)
+	}
+	for i, group := range cluster.GetDatanodeGroups() {
+		groupIDs = append(groupIDs, ptr.To(int32(i)))
+		datanodes = append(datanodes, group)
+	}
+
+	for i, spec := range datanodes {
+		policy := spec.GetScalingPolicy()
+		if !policy.IsEnabled() {
+			continue
+		}
+
+		name := common.ResourceName(cluster.Name, v1alpha1.DatanodeRoleKind, spec.GetName())
+		status := cluster.Status.Datanode.GetScaling(name)
+		if status == nil {
+			status = &v1alpha1.DatanodeScalingStatus{StatefulSet: name}
+		}
+
+		if status.LastEvaluateTime != nil {
+			if wait := policy.GetInterval() - now.Sub(status.LastEvaluateTime.Time); wait > 0 {
+				requeueAfter = minRequeueAfter(requeueAfter, wait)
+				continue
+			}
+		}
+		requeueAfter = minRequeueAfter(requeueAfter, policy.GetInterval())
+
+		// The metrics are not stable until the regions are balanced.
+		if rebalance := cluster.Status.Datanode.GetRebalance(name); rebalance != nil && rebalance.StartTime != nil {
+			continue
+		}
+
+		values, err := r.evaluateScalingMetrics(ctx, cluster, policy)
+		if err != nil {
+			// The metrics may be temporarily unavailable, so we evaluate them later instead of failing the whole reconciliation.
+			klog.Errorf("Failed to evaluate the scaling metrics of datanode statefulset '%s/%s': %v", cluster.Namespace, name, err)
+			r.Recorder.Event(cluster, corev1.EventTypeWarning, "ScalingMetricsFailed", fmt.Sprintf("Failed to evaluate the scaling metrics of statefulset '%s': %v", name, err))
+			continue
+		}
+
+		status.LastEvaluateTime = &metav1.Time{Time: now}
+		status.Metrics = nil
+		for j, metric := range policy.Metrics {
+			status.Metrics = append(status.Metrics, v1alpha1.ScalingMetricStatus{Name: metric.Name, Value: strconv.FormatFloat(values[j], 'f', -1, 64)})
+		}
+
+		replicas := ptr.Deref(spec.GetReplicas(), 0)
+		desired, reason, err := desiredDatanodeReplicas(policy, replicas, values, status.LastScaleTime, now)
+		if err != nil {
+			return 0, err
+		}
+
+		if desired != replicas {
+			klog.Infof("Scale datanode statefulset '%s/%s' from %d to %d replicas: %s", cluster.Namespace, name, replicas, desired, reason)
+			if err := r.setDatanodeReplicas(ctx, cluster, groupIDs[i], desired); err != nil {
+				return 0, err
+			}
+			spec.Replicas = ptr.To(desired)
+
+			status.LastScaleTime = &metav1.Time{Time: now}
+			status.LastScaleReplicas = desired
+			r.Recorder.Event(cluster, corev1.EventTypeNormal, "DatanodeScaled",
+				fmt.Sprintf("Scaled datanode statefulset '%s' from %d to %d replicas: %s", name, replicas, desired, reason))
+		}
+
+		cluster.Status.Datanode.SetScaling(*status)
+	}
+
+	if !equality.Semantic.DeepEqual(original, &cluster.Status) {
+		if err := deployers.UpdateStatus(ctx, cluster, r.Client); err != nil {
+			return 0, err
+		}
+	}
+
+	return requeueAfter, nil
+}
+
+// evaluateScalingMetrics queries the values of the metrics of the scaling policy in order.
+func (r *Reconciler) evaluateScalingMetrics(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, policy *v1alpha1.DatanodeScalingPolicy) ([]float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, scalingQueryTimeout)
+	defer cancel()
+
+	endpoint := policy.Endpoint
+	if endpoint == "" {
+		endpoint = common.GetMonitoringPrometheusURL(cluster)
+	}
+
+	var values []float64
+	for _, metric := range policy.Metrics {
+		value, err := common.QueryPrometheus(ctx, endpoint, metric.Query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the metric '%s': %v", metric.Name, err)
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// setDatanodeReplicas sets the replicas of the datanode or the datanode group in the cluster spec.
+// The latest cluster is patched since the spec in the reconciliation is merged with the default values and the base template.
+func (r *Reconciler) setDatanodeReplicas(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, groupID *int32, replicas int32) error {
+	latest := new(v1alpha1.GreptimeDBCluster)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(cluster), latest); err != nil {
+		return err
+	}
+
+	patch := client.MergeFromWithOptions(latest.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if groupID == nil {
+		if latest.Spec.Datanode == nil {
+			return fmt.Errorf("the datanode of the cluster '%s/%s' is removed", cluster.Namespace, cluster.Name)
+		}
+		latest.Spec.Datanode.Replicas = ptr.To(replicas)
+	} else {
+		if int(*groupID) >= len(latest.Spec.DatanodeGroups) {
+			return fmt.Errorf("the datanode group %d of the cluster '%s/%s' is removed", *groupID, cluster.Namespace, cluster.Name)
+		}
+		latest.Spec.DatanodeGroups[*groupID].Replicas = ptr.To(replicas)
+	}
+
+	return r.Patch(ctx, latest, patch)
+}
+
+// desiredDatanodeReplicas returns the replicas that the datanodes should be scaled to by the values of the metrics, and the reason of the scaling.
+// The datanodes are scaled out if any of the metrics exceeds its scale-out threshold, or scaled in if all the metrics are below their scale-in thresholds.
+// The replicas out of the range of the policy are corrected immediately, otherwise the scaling waits for the cooldown since the last scaling.
+func desiredDatanodeReplicas(policy *v1alpha1.DatanodeScalingPolicy, replicas int32, values []float64, lastScaleTime *metav1.Time, now time.Time) (int32, string, error) {
+	if replicas < policy.MinReplicas {
+		return policy.MinReplicas, fmt.Sprintf("the replicas are less than the min replicas %d", policy.MinReplicas), nil
+	}
+
+	if replicas > policy.MaxReplicas {
+		return policy.MaxReplicas, fmt.Sprintf("the replicas are greater than the max replicas %d", policy.MaxReplicas), nil
+	}
+
+	var (
+		scaleOutReason string
+		scaleIn        = true
+	)
+
+	for i, metric := range policy.Metrics {
+		scaleOutThreshold, scaleInThreshold, err := metric.GetThresholds()
+		if err != nil {
+			return 0, "", err
+		}
+
+		if scaleOutThreshold != nil && values[i] > *scaleOutThreshold && scaleOutReason == "" {
+			scaleOutReason = fmt.Sprintf("the metric '%s' is %v, which exceeds %v", metric.Name, values[i], *scaleOutThreshold)
+		}
+
+		if scaleInThreshold == nil || values[i] >= *scaleInThreshold {
+			scaleIn = false
+		}
+	}
+
+	sinceLastScale := time.Duration(-1)
+	if lastScaleTime != nil {
+		sinceLastScale = now.Sub(lastScaleTime.Time)
+	}
+
+	if scaleOutReason != "" {
+		if sinceLastScale >= 0 && sinceLastScale < policy.GetScaleOutCooldown() {
+			return replicas, "", nil
+		}
+		return min(replicas+policy.GetScaleOutStep(), policy.MaxReplicas), scaleOutReason, nil
+	}
+
+	if scaleIn {
+		if sinceLastScale >= 0 && sinceLastScale < policy.GetScaleInCooldown() {
+			return replicas, "", nil
+		}
+		return max(replicas-policy.GetScaleInStep(), policy.MinReplicas), "all the metrics are below their scale-in thresholds", nil
+	}
+
+	return replicas, "", nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+)
+
+func TestDesiredDatanodeReplicas(t *testing.T) {
+	policy := &v1alpha1.DatanodeScalingPolicy{
+		Enabled:     true,
+		MinReplicas: 2,
+		MaxReplicas: 6,
+		Metrics: []v1alpha1.ScalingMetric{
+			{Name: "regions", Query: "regions", ScaleOutThreshold: "100", ScaleInThreshold: "20"},
+			{Name: "disk", Query: "disk", ScaleOutThreshold: "0.8", ScaleInThreshold: "0.3"},
+		},
+		ScaleOut: &v1alpha1.ScalingRule{Step: ptr.To(int32(3))},
+	}
+
+	now := time.Now()
+	recently := &metav1.Time{Time: now.Add(-time.Minute)}
+	longAgo := &metav1.Time{Time: now.Add(-time.Hour)}
+
+	tests := []struct {
+		name          string
+		replicas      int32
+		values        []float64
+		lastScaleTime *metav1.Time
+		want          int32
+	}{
+		{name: "stable", replicas: 3, values: []float64{50, 0.5}, want: 3},
+		{name: "scale out by any metric", replicas: 3, values: []float64{50, 0.9}, want: 6},
+		{name: "scale out up to the max replicas", replicas: 5, values: []float64{150, 0.5}, lastScaleTime: longAgo, want: 6},
+		{name: "scale out in cooldown", replicas: 3, values: []float64{150, 0.5}, lastScaleTime: recently, want: 3},
+		{name: "scale in by all metrics", replicas: 3, values: []float64{10, 0.1}, lastScaleTime: longAgo, want: 2},
+		{name: "not scale in by one metric", replicas: 3, values: []float64{10, 0.5}, want: 3},
+		{name: "scale in at the min replicas", replicas: 2, values: []float64{10, 0.1}, want: 2},
+		{name: "scale in in cooldown", replicas: 3, values: []float64{10, 0.1}, lastScaleTime: recently, want: 3},
+		{name: "less than the min replicas", replicas: 1, values: []float64{50, 0.5}, lastScaleTime: recently, want: 2},
+		{name: "greater than the max replicas", replicas: 8, values: []float64{150, 0.9}, lastScaleTime: recently, want: 6},
+	}
+
+	for _, test := range tests {
+		got, _, err := desiredDatanodeReplicas(policy, test.replicas, test.values, test.lastScaleTime, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("%s: want replicas %d, got %d", test.name, test.want, got)
+		}
+	}
+}
+
+func TestScaleDatanodes(t *testing.T) {
+	ingestionRate := "5000"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" || r.URL.Query().Get("query") != "sum(rate(greptime_table_operator_ingest_rows[5m]))" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"%s"]}]}}`, ingestionRate)
+	}))
+	defer server.Close()
+
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			DatanodeGroups: []*v1alpha1.DatanodeSpec{
+				{
+					Name:          "hot",
+					ComponentSpec: v1alpha1.ComponentSpec{Replicas: ptr.To(int32(2))},
+					ScalingPolicy: &v1alpha1.DatanodeScalingPolicy{
+						Enabled:     true,
+						MinReplicas: 1,
+						MaxReplicas: 4,
+						Endpoint:    server.URL,
+						Metrics: []v1alpha1.ScalingMetric{
+							{
+								Name:              "ingestion",
+								Query:             "sum(rate(greptime_table_operator_ingest_rows[5m]))",
+								ScaleOutThreshold: "1000",
+								ScaleInThreshold:  "100",
+							},
+						},
+					},
+				},
+				{
+					Name:          "cold",
+					ComponentSpec: v1alpha1.ComponentSpec{Replicas: ptr.To(int32(1))},
+				},
+			},
+		},
+		Status: v1alpha1.GreptimeDBClusterStatus{ClusterPhase: v1alpha1.PhaseRunning},
+	}
+
+	r := newTestReconciler(cluster)
+
+	ctx := context.Background()
+	requeueAfter, err := r.scaleDatanodes(ctx, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requeueAfter != v1alpha1.DefaultScalingInterval {
+		t.Errorf("expected to evaluate the metrics again after %v, got: %v", v1alpha1.DefaultScalingInterval, requeueAfter)
+	}
+
+	latest := new(v1alpha1.GreptimeDBCluster)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(cluster), latest); err != nil {
+		t.Fatal(err)
+	}
+
+	if replicas := *latest.Spec.DatanodeGroups[0].Replicas; replicas != 3 {
+		t.Errorf("expected the hot datanodes to be scaled out to 3 replicas, got: %d", replicas)
+	}
+	if replicas := *latest.Spec.DatanodeGroups[1].Replicas; replicas != 1 {
+		t.Errorf("expected the cold datanodes without the scaling policy to be kept, got: %d", replicas)
+	}
+
+	status := latest.Status.Datanode.GetScaling("test-datanode-hot")
+	if status == nil || status.LastScaleTime == nil || status.LastScaleReplicas != 3 ||
+		len(status.Metrics) != 1 || status.Metrics[0].Value != ingestionRate {
+		t.Fatalf("unexpected scaling status: %+v", status)
+	}
+
+	// The metrics are not evaluated again before the interval.
+	ingestionRate = "10"
+	if _, err := r.scaleDatanodes(ctx, cluster); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(cluster), latest); err != nil {
+		t.Fatal(err)
+	}
+	if replicas := *latest.Spec.DatanodeGroups[0].Replicas; replicas != 3 {
+		t.Errorf("expected the replicas to be kept before the next evaluation, got: %d", replicas)
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/constant"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/greptimedbcluster/deployers"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+	k8sutils "github.com/GreptimeTeam/greptimedb-operator/pkg/util/k8s"
+)
+
+// upgradeOrder is the order of the components to be upgraded.
+var upgradeOrder = []v1alpha1.RoleKind{
+	v1alpha1.MetaRoleKind,
+	v1alpha1.DatanodeRoleKind,
+	v1alpha1.FlownodeRoleKind,
+	v1alpha1.FrontendRoleKind,
+}
+
+// prepareUpgrade starts the upgrade when the version of the spec is different from the running version.
+// It returns false if the upgrade is rejected and the cluster should not be synced.
+func (r *Reconciler) prepareUpgrade(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) (bool, error) {
+	var (
+		from    = cluster.Status.Version
+		to      = cluster.Spec.Version
+		upgrade = cluster.Status.Upgrade
+	)
+
+	// The cluster is just created or the version is not changed.
+	if from == "" || from == to {
+		// The version is changed back to the running version, so the last upgrade is cancelled.
+		if upgrade != nil && (upgrade.IsInProgress() || upgrade.Phase == v1alpha1.UpgradePhaseRejected) {
+			klog.Infof("The upgrade of the cluster '%s/%s' from '%s' to '%s' is cancelled", cluster.Namespace, cluster.Name, upgrade.FromVersion, upgrade.ToVersion)
+			r.Recorder.Event(cluster, corev1.EventTypeNormal, "UpgradeCancelled", fmt.Sprintf("Upgrade to '%s' is cancelled", upgrade.ToVersion))
+			cluster.Status.Upgrade = nil
+			cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeUpgrading, corev1.ConditionFalse, "UpgradeCancelled", "the upgrade is cancelled"))
+			if err := deployers.UpdateStatus(ctx, cluster, r.Client); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+
+	// The upgrade is already handled.
+	if upgrade != nil && upgrade.FromVersion == from && upgrade.ToVersion == to {
+		return upgrade.Phase != v1alpha1.UpgradePhaseRejected, nil
+	}
+
+	if err := v1alpha1.CheckUpgradePath(from, to); err != nil {
+		klog.Errorf("Reject the upgrade of the cluster '%s/%s': %v", cluster.Namespace, cluster.Name, err)
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "UpgradeRejected", fmt.Sprintf("Upgrade rejected: %v", err))
+		cluster.Status.Upgrade = &v1alpha1.UpgradeStatus{
+			FromVersion: from,
+			ToVersion:   to,
+			Phase:       v1alpha1.UpgradePhaseRejected,
+			Message:     err.Error(),
+		}
+		cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeUpgrading, corev1.ConditionFalse, "UpgradeRejected", err.Error()))
+		return false, deployers.UpdateStatus(ctx, cluster, r.Client)
+	}
+
+	upgrade = &v1alpha1.UpgradeStatus{
+		FromVersion: from,
+		ToVersion:   to,
+		Phase:       v1alpha1.UpgradePhaseUpgrading,
+		StartTime:   ptrNow(),
+	}
+	for _, kind := range upgradeOrder {
+		if hasComponent(cluster, kind) {
+			upgrade.Components = append(upgrade.Components, v1alpha1.ComponentUpgradeStatus{
+				Component: kind,
+				Phase:     v1alpha1.UpgradePhasePending,
+			})
+		}
+	}
+	if len(upgrade.Components) > 0 {
+		upgrade.CurrentComponent = upgrade.Components[0].Component
+	}
+	cluster.Status.Upgrade = upgrade
+
+	klog.Infof("Start to upgrade the cluster '%s/%s' from '%s' to '%s'", cluster.Namespace, cluster.Name, from, to)
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "UpgradeStarted", fmt.Sprintf("Upgrade from '%s' to '%s' started", from, to))
+	cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeUpgrading, corev1.ConditionTrue, "UpgradeStarted", fmt.Sprintf("upgrading from '%s' to '%s'", from, to)))
+
+	return true, deployers.UpdateStatus(ctx, cluster, r.Client)
+}
+
+// syncUpgradeProgress checks whether the component is fully rolled out to the new version.
+// It returns false if the next components should wait for the component.
+func (r *Reconciler) syncUpgradeProgress(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, kind v1alpha1.RoleKind) (bool, error) {
+	upgrade := cluster.Status.Upgrade
+	if !upgrade.IsInProgress() {
+		return true, nil
+	}
+
+	component := upgrade.GetComponent(kind)
+	if component == nil || component.Phase == v1alpha1.UpgradePhaseCompleted {
+		return true, nil
+	}
+
+	rolledOut, err := r.isComponentRolledOut(ctx, cluster, kind)
+	if err != nil {
+		return false, err
+	}
+
+	if !rolledOut {
+		if component.Phase == v1alpha1.UpgradePhasePending {
+			component.Phase = v1alpha1.UpgradePhaseUpgrading
+			upgrade.CurrentComponent = kind
+			if err := deployers.UpdateStatus(ctx, cluster, r.Client); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+
+	component.Phase = v1alpha1.UpgradePhaseCompleted
+	component.CompletionTime = ptrNow()
+	upgrade.CurrentComponent = ""
+	for _, c := range upgrade.Components {
+		if c.Phase != v1alpha1.UpgradePhaseCompleted {
+			upgrade.CurrentComponent = c.Component
+			break
+		}
+	}
+
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "ComponentUpgraded", fmt.Sprintf("Component '%s' is upgraded to '%s'", kind, upgrade.ToVersion))
+
+	return true, deployers.UpdateStatus(ctx, cluster, r.Client)
+}
+
+// completeUpgrade marks the upgrade as completed when all the components are rolled out.
+func (r *Reconciler) completeUpgrade(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster) error {
+	upgrade := cluster.Status.Upgrade
+	if !upgrade.IsInProgress() {
+		return nil
+	}
+
+	upgrade.Phase = v1alpha1.UpgradePhaseCompleted
+	upgrade.CurrentComponent = ""
+	upgrade.CompletionTime = ptrNow()
+	cluster.Status.Version = upgrade.ToVersion
+	cluster.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeUpgrading, corev1.ConditionFalse, "UpgradeCompleted", fmt.Sprintf("upgraded to '%s'", upgrade.ToVersion)))
+
+	klog.Infof("The cluster '%s/%s' is upgraded from '%s' to '%s'", cluster.Namespace, cluster.Name, upgrade.FromVersion, upgrade.ToVersion)
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "UpgradeCompleted", fmt.Sprintf("Upgrade from '%s' to '%s' completed", upgrade.FromVersion, upgrade.ToVersion))
+
+	return deployers.UpdateStatus(ctx, cluster, r.Client)
+}
+
+// isComponentRolledOut checks whether all the workloads of the component are rolled out to the latest pod template,
+// and all the pods are ready and running the image of the latest pod template.
+func (r *Reconciler) isComponentRolledOut(ctx context.Context, cluster *v1alpha1.GreptimeDBCluster, kind v1alpha1.RoleKind) (bool, error) {
+	for _, name := range componentResourceNames(cluster, kind) {
+		var (
+			template *corev1.PodTemplateSpec
+			selector *metav1.LabelSelector
+			replicas int32
+		)
+
+		objectKey := client.ObjectKey{Namespace: cluster.Namespace, Name: name}
+		if kind == v1alpha1.MetaRoleKind || kind == v1alpha1.FrontendRoleKind {
+			deployment := new(appsv1.Deployment)
+			if err := r.Get(ctx, objectKey, deployment); err != nil {
+				return false, client.IgnoreNotFound(err)
+			}
+			if !k8sutils.IsDeploymentRolledOut(deployment) {
+				return false, nil
+			}
+			template, selector, replicas = &deployment.Spec.Template, deployment.Spec.Selector, *deployment.Spec.Replicas
+		} else {
+			sts := new(appsv1.StatefulSet)
+			if err := r.Get(ctx, objectKey, sts); err != nil {
+				return false, client.IgnoreNotFound(err)
+			}
+			if !k8sutils.IsStatefulSetRolledOut(sts) {
+				return false, nil
+			}
+			template, selector, replicas = &sts.Spec.Template, sts.Spec.Selector, *sts.Spec.Replicas
+		}
+
+		pods := new(corev1.PodList)
+		if err := r.List(ctx, pods, client.InNamespace(cluster.Namespace), client.MatchingLabels(selector.MatchLabels)); err != nil {
+			return false, err
+		}
+
+		if int32(len(pods.Items)) != replicas {
+			return false, nil
+		}
+
+		image := template.Spec.Containers[constant.MainContainerIndex].Image
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if !k8sutils.IsPodReady(pod) || pod.Spec.Containers[constant.MainContainerIndex].Image != image {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// componentResourceNames returns the names of the workloads of the component.
+func componentResourceNames(cluster *v1alpha1.GreptimeDBCluster, kind v1alpha1.RoleKind) []string {
+	var groups []string
+	switch kind {
+	case v1alpha1.DatanodeRoleKind:
+		for _, group := range cluster.GetDatanodeGroups() {
+			groups = append(groups, group.GetName())
+		}
+	case v1alpha1.FlownodeRoleKind:
+		for _, group := range cluster.GetFlownodeGroups() {
+			groups = append(groups, group.GetName())
+		}
+	case v1alpha1.FrontendRoleKind:
+		for _, group := range cluster.GetFrontendGroups() {
+			groups = append(groups, group.GetName())
+		}
+	}
+
+	if len(groups) == 0 {
+		return []string{common.ResourceName(cluster.Name, kind)}
+	}
+
+	var names []string
+	for _, group := range groups {
+		names = append(names, common.ResourceName(cluster.Name, kind, group))
+	}
+	return names
+}
+
+func hasComponent(cluster *v1alpha1.GreptimeDBCluster, kind v1alpha1.RoleKind) bool {
+	switch kind {
+	case v1alpha1.MetaRoleKind:
+		return cluster.GetMeta() != nil
+	case v1alpha1.DatanodeRoleKind:
+		return cluster.GetDatanode() != nil || len(cluster.GetDatanodeGroups()) > 0
+	case v1alpha1.FlownodeRoleKind:
+		return cluster.GetFlownode() != nil || len(cluster.GetFlownodeGroups()) > 0
+	case v1alpha1.FrontendRoleKind:
+		return cluster.GetFrontend() != nil || len(cluster.GetFrontendGroups()) > 0
+	}
+	return false
+}
+
+// componentKind returns the role kind of the component that the deployer manages.
+func componentKind(d deployer.Deployer) (v1alpha1.RoleKind, bool) {
+	switch d.(type) {
+	case *deployers.MetaDeployer:
+		return v1alpha1.MetaRoleKind, true
+	case *deployers.DatanodeDeployer:
+		return v1alpha1.DatanodeRoleKind, true
+	case *deployers.FlownodeDeployer:
+		return v1alpha1.FlownodeRoleKind, true
+	case *deployers.FrontendDeployer:
+		return v1alpha1.FrontendRoleKind, true
+	}
+	return "", false
+}
+
+func ptrNow() *metav1.Time {
+	now := metav1.Now()
+	return &now
+}
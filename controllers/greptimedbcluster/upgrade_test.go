@@ -0,0 +1,273 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbcluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+)
+
+const (
+	oldImage = "greptime/greptimedb:v0.14.0"
+	newImage = "greptime/greptimedb:v0.15.0"
+)
+
+func TestUpgradeInOrder(t *testing.T) {
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			Version:  "v0.15.0",
+			Meta:     &v1alpha1.MetaSpec{},
+			Datanode: &v1alpha1.DatanodeSpec{},
+			Flownode: &v1alpha1.FlownodeSpec{},
+			Frontend: &v1alpha1.FrontendSpec{},
+		},
+		Status: v1alpha1.GreptimeDBClusterStatus{Version: "v0.14.0"},
+	}
+
+	objects := []client.Object{cluster}
+	for _, name := range []string{"test-meta", "test-frontend"} {
+		objects = append(objects, newUpgradeDeployment(name))
+		objects = append(objects, newUpgradePods(name, 1, oldImage)...)
+	}
+	for _, name := range []string{"test-datanode", "test-flownode"} {
+		objects = append(objects, newUpgradeStatefulSet(name))
+		objects = append(objects, newUpgradePods(name, 1, oldImage)...)
+	}
+
+	r := newTestReconciler(objects...)
+	ctx := context.Background()
+
+	ok, err := r.prepareUpgrade(ctx, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatalf("expected the upgrade to be accepted")
+	}
+
+	upgrade := cluster.Status.Upgrade
+	if upgrade.Phase != v1alpha1.UpgradePhaseUpgrading || upgrade.StartTime == nil {
+		t.Fatalf("expected the upgrade to be started, got: %+v", upgrade)
+	}
+	if condition := cluster.Status.GetCondition(v1alpha1.ConditionTypeUpgrading); condition == nil || condition.Status != corev1.ConditionTrue {
+		t.Errorf("expected the upgrading condition to be true, got: %+v", condition)
+	}
+	assertUpgradeComponents(t, cluster, v1alpha1.MetaRoleKind, []v1alpha1.UpgradePhase{
+		v1alpha1.UpgradePhasePending, v1alpha1.UpgradePhasePending, v1alpha1.UpgradePhasePending, v1alpha1.UpgradePhasePending,
+	})
+
+	// The meta is rolling out, so the other components wait for it.
+	stepUpgrade(ctx, t, r, cluster)
+	assertUpgradeComponents(t, cluster, v1alpha1.MetaRoleKind, []v1alpha1.UpgradePhase{
+		v1alpha1.UpgradePhaseUpgrading, v1alpha1.UpgradePhasePending, v1alpha1.UpgradePhasePending, v1alpha1.UpgradePhasePending,
+	})
+
+	// The meta is rolled out, and the datanode starts.
+	rollOutPods(ctx, t, r, "test-meta")
+	stepUpgrade(ctx, t, r, cluster)
+	assertUpgradeComponents(t, cluster, v1alpha1.DatanodeRoleKind, []v1alpha1.UpgradePhase{
+		v1alpha1.UpgradePhaseCompleted, v1alpha1.UpgradePhaseUpgrading, v1alpha1.UpgradePhasePending, v1alpha1.UpgradePhasePending,
+	})
+	if cluster.Status.Upgrade.Components[0].CompletionTime == nil {
+		t.Errorf("expected the completion time of the meta")
+	}
+
+	// The pods of the datanode are running the new image, but the StatefulSet is not fully rolled out yet.
+	rollOutPods(ctx, t, r, "test-datanode")
+	sts := new(appsv1.StatefulSet)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-datanode"}, sts); err != nil {
+		t.Fatal(err)
+	}
+	sts.Status.CurrentRevision = "old"
+	if err := r.Status().Update(ctx, sts); err != nil {
+		t.Fatal(err)
+	}
+	stepUpgrade(ctx, t, r, cluster)
+	assertUpgradeComponents(t, cluster, v1alpha1.DatanodeRoleKind, []v1alpha1.UpgradePhase{
+		v1alpha1.UpgradePhaseCompleted, v1alpha1.UpgradePhaseUpgrading, v1alpha1.UpgradePhasePending, v1alpha1.UpgradePhasePending,
+	})
+
+	sts.Status.CurrentRevision = sts.Status.UpdateRevision
+	if err := r.Status().Update(ctx, sts); err != nil {
+		t.Fatal(err)
+	}
+	stepUpgrade(ctx, t, r, cluster)
+	assertUpgradeComponents(t, cluster, v1alpha1.FlownodeRoleKind, []v1alpha1.UpgradePhase{
+		v1alpha1.UpgradePhaseCompleted, v1alpha1.UpgradePhaseCompleted, v1alpha1.UpgradePhaseUpgrading, v1alpha1.UpgradePhasePending,
+	})
+
+	// The frontend is the last one.
+	rollOutPods(ctx, t, r, "test-flownode")
+	stepUpgrade(ctx, t, r, cluster)
+	assertUpgradeComponents(t, cluster, v1alpha1.FrontendRoleKind, []v1alpha1.UpgradePhase{
+		v1alpha1.UpgradePhaseCompleted, v1alpha1.UpgradePhaseCompleted, v1alpha1.UpgradePhaseCompleted, v1alpha1.UpgradePhaseUpgrading,
+	})
+
+	rollOutPods(ctx, t, r, "test-frontend")
+	if !stepUpgrade(ctx, t, r, cluster) {
+		t.Fatalf("expected all the components to be upgraded")
+	}
+	assertUpgradeComponents(t, cluster, "", []v1alpha1.UpgradePhase{
+		v1alpha1.UpgradePhaseCompleted, v1alpha1.UpgradePhaseCompleted, v1alpha1.UpgradePhaseCompleted, v1alpha1.UpgradePhaseCompleted,
+	})
+
+	if err := r.completeUpgrade(ctx, cluster); err != nil {
+		t.Fatal(err)
+	}
+
+	got := new(v1alpha1.GreptimeDBCluster)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(cluster), got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Version != "v0.15.0" {
+		t.Errorf("expected the running version to be 'v0.15.0', got: '%s'", got.Status.Version)
+	}
+	if upgrade := got.Status.Upgrade; upgrade.Phase != v1alpha1.UpgradePhaseCompleted || upgrade.CompletionTime == nil || upgrade.CurrentComponent != "" {
+		t.Errorf("expected the upgrade to be completed, got: %+v", upgrade)
+	}
+	if condition := got.Status.GetCondition(v1alpha1.ConditionTypeUpgrading); condition == nil || condition.Status != corev1.ConditionFalse {
+		t.Errorf("expected the upgrading condition to be false, got: %+v", condition)
+	}
+}
+
+func TestUpgradeRejected(t *testing.T) {
+	cluster := &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       v1alpha1.GreptimeDBClusterSpec{Version: "v0.13.0", Meta: &v1alpha1.MetaSpec{}},
+		Status:     v1alpha1.GreptimeDBClusterStatus{Version: "v0.14.0"},
+	}
+
+	r := newTestReconciler(cluster)
+	ok, err := r.prepareUpgrade(context.Background(), cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatalf("expected the downgrade to be rejected")
+	}
+	if upgrade := cluster.Status.Upgrade; upgrade.Phase != v1alpha1.UpgradePhaseRejected || upgrade.Message == "" {
+		t.Errorf("expected the upgrade to be rejected with the reason, got: %+v", upgrade)
+	}
+}
+
+// stepUpgrade checks the upgrade progress of the components in the same order as the sync of the cluster,
+// and returns true if all the components are upgraded.
+func stepUpgrade(ctx context.Context, t *testing.T, r *Reconciler, cluster *v1alpha1.GreptimeDBCluster) bool {
+	t.Helper()
+	for _, kind := range upgradeOrder {
+		upgraded, err := r.syncUpgradeProgress(ctx, cluster, kind)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !upgraded {
+			return false
+		}
+	}
+	return true
+}
+
+func assertUpgradeComponents(t *testing.T, cluster *v1alpha1.GreptimeDBCluster, current v1alpha1.RoleKind, phases []v1alpha1.UpgradePhase) {
+	t.Helper()
+
+	upgrade := cluster.Status.Upgrade
+	var (
+		gotKinds  []v1alpha1.RoleKind
+		gotPhases []v1alpha1.UpgradePhase
+	)
+	for _, c := range upgrade.Components {
+		gotKinds = append(gotKinds, c.Component)
+		gotPhases = append(gotPhases, c.Phase)
+	}
+
+	if !reflect.DeepEqual(gotKinds, upgradeOrder) {
+		t.Errorf("unexpected upgrade order, want: %v, got: %v", upgradeOrder, gotKinds)
+	}
+	if !reflect.DeepEqual(gotPhases, phases) {
+		t.Errorf("unexpected component phases, want: %v, got: %v", phases, gotPhases)
+	}
+	if upgrade.CurrentComponent != current {
+		t.Errorf("unexpected current component, want: '%s', got: '%s'", current, upgrade.CurrentComponent)
+	}
+}
+
+func newUpgradeDeployment(name string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: newImage}}},
+			},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+}
+
+func newUpgradeStatefulSet(name string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: newImage}}},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{UpdatedReplicas: 1, ReadyReplicas: 1, CurrentRevision: "new", UpdateRevision: "new"},
+	}
+}
+
+func newUpgradePods(name string, replicas int, image string) []client.Object {
+	var pods []client.Object
+	for i := 0; i < replicas; i++ {
+		pods = append(pods, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%d", name, i), Namespace: "default", Labels: map[string]string{"app": name}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: image}}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		})
+	}
+	return pods
+}
+
+// rollOutPods updates the pods of the workload to the new image.
+func rollOutPods(ctx context.Context, t *testing.T, r *Reconciler, name string) {
+	t.Helper()
+
+	pods := new(corev1.PodList)
+	if err := r.List(ctx, pods, client.InNamespace("default"), client.MatchingLabels{"app": name}); err != nil {
+		t.Fatal(err)
+	}
+	for i := range pods.Items {
+		pods.Items[i].Spec.Containers[0].Image = newImage
+		if err := r.Update(ctx, &pods.Items[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
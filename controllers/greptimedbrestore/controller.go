@@ -0,0 +1,374 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbrestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/cmd/operator/app/options"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+)
+
+var (
+	defaultRequeueAfter = 10 * time.Second
+)
+
+// Reconciler reconciles a GreptimeDBRestore object.
+type Reconciler struct {
+	client.Client
+
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// Importer imports the databases from the object storage to the cluster.
+	Importer Importer
+}
+
+func Setup(mgr ctrl.Manager, _ *options.Options) error {
+	reconciler := &Reconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("greptimedbrestore-controller"),
+		Importer: &mysqlImporter{},
+	}
+	return reconciler.SetupWithManager(mgr)
+}
+
+// +kubebuilder:rbac:groups=greptime.io,resources=greptimedbrestores,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=greptime.io,resources=greptimedbrestores/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=greptime.io,resources=greptimedbbackups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=greptime.io,resources=greptimedbclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch;
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	klog.V(2).Infof("Reconciling GreptimeDBRestore: %s", req.NamespacedName)
+
+	var err error
+	restore := new(v1alpha1.GreptimeDBRestore)
+	if err := r.Get(ctx, req.NamespacedName, restore); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// The restore is a one-shot job and will not be executed again once it's finished.
+	if !restore.DeletionTimestamp.IsZero() || restore.IsFinished() {
+		return ctrl.Result{}, nil
+	}
+
+	defer func() {
+		if err != nil {
+			r.Recorder.Event(restore, corev1.EventTypeWarning, "ReconcileError", fmt.Sprintf("Reconcile error: %v", err))
+		}
+	}()
+
+	if err = restore.Validate(); err != nil {
+		r.Recorder.Event(restore, corev1.EventTypeWarning, "InvalidRestore", fmt.Sprintf("Invalid restore: %v", err))
+		return ctrl.Result{}, r.fail(ctx, restore, err)
+	}
+
+	if err = restore.Check(ctx, r.Client); err != nil {
+		r.Recorder.Event(restore, corev1.EventTypeWarning, "InvalidRestore", fmt.Sprintf("Invalid restore: %v", err))
+		return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+	}
+
+	originalObject := restore.DeepCopy()
+	if err = restore.SetDefaults(); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !cmp.Equal(originalObject.Spec, restore.Spec) {
+		// Update the default values to the restore spec if it is not set.
+		if err = r.Update(ctx, restore); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	cluster := new(v1alpha1.GreptimeDBCluster)
+	if err = r.Get(ctx, client.ObjectKey{Namespace: restore.Namespace, Name: restore.Spec.ClusterName}, cluster); err != nil {
+		if k8serrors.IsNotFound(err) {
+			err = nil
+			return r.pending(ctx, restore, fmt.Sprintf("cluster '%s' is not found", restore.Spec.ClusterName))
+		}
+		return ctrl.Result{}, err
+	}
+
+	if cluster.Status.ClusterPhase != v1alpha1.PhaseRunning {
+		return r.pending(ctx, restore, fmt.Sprintf("cluster '%s' is not running", cluster.Name))
+	}
+
+	var backup *v1alpha1.GreptimeDBBackup
+	if restore.Spec.BackupName != "" {
+		backup = new(v1alpha1.GreptimeDBBackup)
+		if err = r.Get(ctx, client.ObjectKey{Namespace: restore.Namespace, Name: restore.Spec.BackupName}, backup); err != nil {
+			if k8serrors.IsNotFound(err) {
+				err = nil
+				return r.pending(ctx, restore, fmt.Sprintf("backup '%s' is not found", restore.Spec.BackupName))
+			}
+			return ctrl.Result{}, err
+		}
+
+		switch backup.Status.BackupPhase {
+		case v1alpha1.BackupPhaseCompleted:
+		case v1alpha1.BackupPhaseFailed:
+			return ctrl.Result{}, r.fail(ctx, restore, fmt.Errorf("backup '%s' is failed", backup.Name))
+		default:
+			return r.pending(ctx, restore, fmt.Sprintf("backup '%s' is not completed", backup.Name))
+		}
+	}
+
+	if restore.Status.RestorePhase != v1alpha1.RestorePhaseRunning {
+		return r.start(ctx, restore, cluster, backup)
+	}
+
+	return r.restore(ctx, restore, cluster, backup)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.GreptimeDBRestore{}).
+		Complete(r)
+}
+
+// start resolves the location and the databases of the restore and moves the restore to the running phase.
+func (r *Reconciler) start(ctx context.Context, restore *v1alpha1.GreptimeDBRestore, cluster *v1alpha1.GreptimeDBCluster, backup *v1alpha1.GreptimeDBBackup) (ctrl.Result, error) {
+	location, err := r.location(ctx, restore, cluster, backup)
+	if err != nil {
+		return ctrl.Result{}, r.fail(ctx, restore, err)
+	}
+
+	databases := restore.Spec.Databases
+	if len(databases) == 0 && backup != nil {
+		databases = backup.Status.ExportedDatabases
+	}
+
+	restore.Status.Databases = nil
+	for _, database := range databases {
+		restore.Status.Databases = append(restore.Status.Databases, v1alpha1.RestoreDatabaseStatus{
+			Name:  database,
+			Phase: v1alpha1.RestorePhasePending,
+		})
+	}
+
+	restore.Status.RestorePhase = v1alpha1.RestorePhaseRunning
+	restore.Status.StartTime = ptrNow()
+	restore.Status.Location = location.URL
+	restore.Status.Message = ""
+	restore.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeProgressing, corev1.ConditionTrue, "RestoreStarted", "the restore is importing databases"))
+
+	r.Recorder.Event(restore, corev1.EventTypeNormal, "RestoreStarted", fmt.Sprintf("Start to import %d databases from '%s'", len(databases), location.URL))
+
+	if err := r.updateStatus(ctx, restore); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// restore makes progress on the next database that has not been imported. Every database goes through two reconciliations:
+// the pending database is checked whether it's empty and then marked as running; the running database is imported.
+// The running phase is persisted before importing, so the data imported by the restore itself will not be treated as
+// existing data after the operator restarts.
+func (r *Reconciler) restore(ctx context.Context, restore *v1alpha1.GreptimeDBRestore, cluster *v1alpha1.GreptimeDBCluster, backup *v1alpha1.GreptimeDBBackup) (ctrl.Result, error) {
+	var next *v1alpha1.RestoreDatabaseStatus
+	for i := range restore.Status.Databases {
+		if restore.Status.Databases[i].Phase != v1alpha1.RestorePhaseCompleted {
+			next = &restore.Status.Databases[i]
+			break
+		}
+	}
+
+	if next == nil {
+		return ctrl.Result{}, r.complete(ctx, restore)
+	}
+
+	conn, err := common.NewMySQLConnection(ctx, r.Client, cluster, restore.Spec.CredentialsSecretName)
+	if err != nil {
+		return ctrl.Result{}, r.fail(ctx, restore, err)
+	}
+
+	if next.Phase == v1alpha1.RestorePhasePending {
+		if !restore.Spec.Overwrite {
+			empty, err := r.Importer.IsDatabaseEmpty(ctx, conn, next.Name)
+			if err != nil {
+				klog.Errorf("Failed to check database '%s' of cluster '%s/%s': %v", next.Name, cluster.Namespace, cluster.Name, err)
+				return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+			}
+
+			if !empty {
+				cause := fmt.Errorf("database '%s' is not empty, set 'overwrite' to import into it", next.Name)
+				next.Phase = v1alpha1.RestorePhaseFailed
+				next.Message = cause.Error()
+				return ctrl.Result{}, r.fail(ctx, restore, cause)
+			}
+		}
+
+		next.Phase = v1alpha1.RestorePhaseRunning
+		if err := r.updateStatus(ctx, restore); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	location, err := r.location(ctx, restore, cluster, backup)
+	if err != nil {
+		return ctrl.Result{}, r.fail(ctx, restore, err)
+	}
+
+	klog.Infof("Import database '%s' of cluster '%s/%s' from '%s'", next.Name, cluster.Namespace, cluster.Name, location.DatabaseURL(next.Name))
+
+	// The restore from the raw object storage location has no table schemas, and the tables must already exist in the cluster.
+	rows, err := r.Importer.ImportDatabase(ctx, conn, next.Name, location, r.format(restore, backup), backup != nil)
+	if err != nil {
+		cause := fmt.Errorf("import database '%s' failed: %v", next.Name, err)
+		next.Phase = v1alpha1.RestorePhaseFailed
+		next.Message = cause.Error()
+		r.Recorder.Event(restore, corev1.EventTypeWarning, "ImportDatabaseFailed", fmt.Sprintf("Import database '%s' failed: %v", next.Name, err))
+		return ctrl.Result{}, r.fail(ctx, restore, cause)
+	}
+
+	next.Phase = v1alpha1.RestorePhaseCompleted
+	next.ImportedRows = rows
+	next.CompletionTime = ptrNow()
+
+	r.Recorder.Event(restore, corev1.EventTypeNormal, "DatabaseImported", fmt.Sprintf("Database '%s' is imported, %d rows", next.Name, rows))
+
+	if err := r.updateStatus(ctx, restore); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{Requeue: true}, nil
+}
+
+func (r *Reconciler) location(ctx context.Context, restore *v1alpha1.GreptimeDBRestore, cluster *v1alpha1.GreptimeDBCluster, backup *v1alpha1.GreptimeDBBackup) (*common.CopyLocation, error) {
+	var (
+		osp    *v1alpha1.ObjectStorageProviderSpec
+		prefix string
+	)
+
+	if backup != nil {
+		// Use the object storage that recorded by the backup, so the backup can be restored even if the source cluster is deleted.
+		osp = backup.Status.ObjectStorage
+		if osp == nil {
+			osp = backup.GetObjectStorage()
+		}
+		prefix = backup.Spec.Prefix
+	} else {
+		osp = restore.GetSource().GetObjectStorage()
+		prefix = restore.GetSource().Prefix
+	}
+
+	if osp == nil {
+		osp = cluster.GetObjectStorageProvider()
+	}
+
+	return common.NewCopyLocation(ctx, r.Client, restore.Namespace, osp, prefix)
+}
+
+func (r *Reconciler) format(restore *v1alpha1.GreptimeDBRestore, backup *v1alpha1.GreptimeDBBackup) v1alpha1.BackupFormat {
+	if restore.Spec.Format != "" {
+		return restore.Spec.Format
+	}
+
+	if backup != nil {
+		return backup.GetFormat()
+	}
+
+	return v1alpha1.DefaultBackupFormat
+}
+
+func (r *Reconciler) pending(ctx context.Context, restore *v1alpha1.GreptimeDBRestore, reason string) (ctrl.Result, error) {
+	klog.V(2).Infof("Restore '%s/%s' is pending: %s", restore.Namespace, restore.Name, reason)
+
+	// The restore will be continued when the cluster is running again.
+	if restore.Status.RestorePhase == v1alpha1.RestorePhaseRunning {
+		return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+	}
+
+	if restore.Status.RestorePhase != v1alpha1.RestorePhasePending || restore.Status.Message != reason {
+		restore.Status.RestorePhase = v1alpha1.RestorePhasePending
+		restore.Status.Message = reason
+		if err := r.updateStatus(ctx, restore); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+}
+
+func (r *Reconciler) complete(ctx context.Context, restore *v1alpha1.GreptimeDBRestore) error {
+	restore.Status.RestorePhase = v1alpha1.RestorePhaseCompleted
+	restore.Status.CompletionTime = ptrNow()
+	restore.Status.Message = ""
+	restore.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeProgressing, corev1.ConditionFalse, "RestoreCompleted", "the restore is completed"))
+
+	r.Recorder.Event(restore, corev1.EventTypeNormal, "RestoreCompleted", fmt.Sprintf("Restore is completed, %d databases are imported from '%s'", len(restore.Status.Databases), restore.Status.Location))
+
+	return r.updateStatus(ctx, restore)
+}
+
+func (r *Reconciler) fail(ctx context.Context, restore *v1alpha1.GreptimeDBRestore, cause error) error {
+	klog.Errorf("Restore '%s/%s' failed: %v", restore.Namespace, restore.Name, cause)
+
+	restore.Status.RestorePhase = v1alpha1.RestorePhaseFailed
+	restore.Status.CompletionTime = ptrNow()
+	restore.Status.Message = cause.Error()
+	restore.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeProgressing, corev1.ConditionFalse, "RestoreFailed", cause.Error()))
+
+	r.Recorder.Event(restore, corev1.EventTypeWarning, "RestoreFailed", fmt.Sprintf("Restore failed: %v", cause))
+
+	return r.updateStatus(ctx, restore)
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, restore *v1alpha1.GreptimeDBRestore) error {
+	restore.Status.ObservedGeneration = restore.Generation
+	return UpdateStatus(ctx, restore, r.Client)
+}
+
+func UpdateStatus(ctx context.Context, input *v1alpha1.GreptimeDBRestore, kc client.Client, opts ...client.SubResourceUpdateOption) error {
+	restore := input.DeepCopy()
+	status := restore.Status
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
+		objectKey := client.ObjectKey{Namespace: restore.Namespace, Name: restore.Name}
+		if err = kc.Get(ctx, objectKey, restore); err != nil {
+			return
+		}
+		restore.Status = status
+		return kc.Status().Update(ctx, restore, opts...)
+	})
+}
+
+func ptrNow() *metav1.Time {
+	now := metav1.Now()
+	return &now
+}
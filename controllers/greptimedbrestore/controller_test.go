@@ -0,0 +1,271 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbrestore
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+)
+
+func TestRestoreProgress(t *testing.T) {
+	restore := newRestore(false)
+	r := newTestReconciler(newCluster(), newBackup("db1", "db2"), restore)
+	importer := &fakeImporter{rows: map[string]int64{"db1": 10, "db2": 20}}
+	r.Importer = importer
+
+	// Every database is checked and marked as running before it's imported.
+	wantPhases := [][]v1alpha1.RestorePhase{
+		{v1alpha1.RestorePhasePending, v1alpha1.RestorePhasePending},
+		{v1alpha1.RestorePhaseRunning, v1alpha1.RestorePhasePending},
+		{v1alpha1.RestorePhaseCompleted, v1alpha1.RestorePhasePending},
+		{v1alpha1.RestorePhaseCompleted, v1alpha1.RestorePhaseRunning},
+		{v1alpha1.RestorePhaseCompleted, v1alpha1.RestorePhaseCompleted},
+	}
+	for i, want := range wantPhases {
+		reconcile(t, r, restore)
+		got := getRestore(t, r, restore)
+		if got.Status.RestorePhase != v1alpha1.RestorePhaseRunning {
+			t.Fatalf("step %d: expected the restore to be running, got: %s", i, got.Status.RestorePhase)
+		}
+		if phases := databasePhases(got); !reflect.DeepEqual(phases, want) {
+			t.Fatalf("step %d: unexpected database phases, want: %v, got: %v", i, want, phases)
+		}
+	}
+
+	reconcile(t, r, restore)
+	got := getRestore(t, r, restore)
+	if got.Status.RestorePhase != v1alpha1.RestorePhaseCompleted || got.Status.CompletionTime == nil {
+		t.Fatalf("expected the restore to be completed, got: %+v", got.Status)
+	}
+	if got.Status.Location != "s3://bucket/backups/" {
+		t.Errorf("unexpected location: %s", got.Status.Location)
+	}
+	for _, database := range got.Status.Databases {
+		if database.ImportedRows != importer.rows[database.Name] || database.CompletionTime == nil {
+			t.Errorf("unexpected status of database '%s': %+v", database.Name, database)
+		}
+	}
+
+	if want := []string{"db1", "db2"}; !reflect.DeepEqual(importer.checked, want) {
+		t.Errorf("expected to check %v, got: %v", want, importer.checked)
+	}
+	if want := []string{"db1", "db2"}; !reflect.DeepEqual(importer.imported, want) {
+		t.Errorf("expected to import %v, got: %v", want, importer.imported)
+	}
+	if !importer.createTables {
+		t.Errorf("expected to create the tables from the schemas of the backup")
+	}
+}
+
+func TestRestoreRefuseNonEmptyDatabase(t *testing.T) {
+	restore := newRestore(false)
+	r := newTestReconciler(newCluster(), newBackup("db1", "db2"), restore)
+	importer := &fakeImporter{nonEmpty: map[string]bool{"db1": true}}
+	r.Importer = importer
+
+	reconcileUntilFinished(t, r, restore)
+
+	got := getRestore(t, r, restore)
+	if got.Status.RestorePhase != v1alpha1.RestorePhaseFailed {
+		t.Fatalf("expected the restore to be failed, got: %s", got.Status.RestorePhase)
+	}
+	if want := []v1alpha1.RestorePhase{v1alpha1.RestorePhaseFailed, v1alpha1.RestorePhasePending}; !reflect.DeepEqual(databasePhases(got), want) {
+		t.Errorf("unexpected database phases, want: %v, got: %v", want, databasePhases(got))
+	}
+	if got.Status.Databases[0].Message == "" {
+		t.Errorf("expected the reason of the failed database")
+	}
+	if len(importer.imported) != 0 {
+		t.Errorf("expected no database to be imported, got: %v", importer.imported)
+	}
+}
+
+func TestRestoreOverwrite(t *testing.T) {
+	restore := newRestore(true)
+	r := newTestReconciler(newCluster(), newBackup("db1"), restore)
+	importer := &fakeImporter{nonEmpty: map[string]bool{"db1": true}, rows: map[string]int64{"db1": 5}}
+	r.Importer = importer
+
+	reconcileUntilFinished(t, r, restore)
+
+	got := getRestore(t, r, restore)
+	if got.Status.RestorePhase != v1alpha1.RestorePhaseCompleted {
+		t.Fatalf("expected the restore to be completed, got: %s", got.Status.RestorePhase)
+	}
+	if len(importer.checked) != 0 {
+		t.Errorf("expected no emptiness check when overwriting, got: %v", importer.checked)
+	}
+	if want := []string{"db1"}; !reflect.DeepEqual(importer.imported, want) {
+		t.Errorf("expected to import %v, got: %v", want, importer.imported)
+	}
+	if rows := got.Status.Databases[0].ImportedRows; rows != 5 {
+		t.Errorf("expected 5 imported rows, got: %d", rows)
+	}
+}
+
+func TestRestoreImportFailed(t *testing.T) {
+	restore := newRestore(false)
+	r := newTestReconciler(newCluster(), newBackup("db1", "db2"), restore)
+	importer := &fakeImporter{failures: map[string]error{"db2": fmt.Errorf("connection reset")}}
+	r.Importer = importer
+
+	reconcileUntilFinished(t, r, restore)
+
+	got := getRestore(t, r, restore)
+	if got.Status.RestorePhase != v1alpha1.RestorePhaseFailed || got.Status.CompletionTime == nil {
+		t.Fatalf("expected the restore to be failed, got: %+v", got.Status)
+	}
+	if want := []v1alpha1.RestorePhase{v1alpha1.RestorePhaseCompleted, v1alpha1.RestorePhaseFailed}; !reflect.DeepEqual(databasePhases(got), want) {
+		t.Errorf("unexpected database phases, want: %v, got: %v", want, databasePhases(got))
+	}
+	if got.Status.Databases[1].Message == "" || got.Status.Message == "" {
+		t.Errorf("expected the reason of the failure, got: %+v", got.Status)
+	}
+
+	// The failed restore is not retried.
+	imported := len(importer.imported)
+	reconcile(t, r, restore)
+	if len(importer.imported) != imported {
+		t.Errorf("expected the failed restore not to be retried")
+	}
+}
+
+type fakeImporter struct {
+	nonEmpty map[string]bool
+	rows     map[string]int64
+	failures map[string]error
+
+	checked      []string
+	imported     []string
+	createTables bool
+}
+
+var _ Importer = &fakeImporter{}
+
+func (i *fakeImporter) IsDatabaseEmpty(_ context.Context, _ *common.MySQLConnection, database string) (bool, error) {
+	i.checked = append(i.checked, database)
+	return !i.nonEmpty[database], nil
+}
+
+func (i *fakeImporter) ImportDatabase(_ context.Context, _ *common.MySQLConnection, database string, _ *common.CopyLocation, _ v1alpha1.BackupFormat, createTables bool) (int64, error) {
+	i.imported = append(i.imported, database)
+	i.createTables = createTables
+	if err := i.failures[database]; err != nil {
+		return 0, err
+	}
+	return i.rows[database], nil
+}
+
+// newTestReconciler returns a Reconciler backed by a fake client that is seeded with objs.
+func newTestReconciler(objs ...client.Object) *Reconciler {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+
+	return &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&v1alpha1.GreptimeDBRestore{}).
+			Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(100),
+	}
+}
+
+func newCluster() *v1alpha1.GreptimeDBCluster {
+	return &v1alpha1.GreptimeDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBClusterSpec{
+			Frontend:  &v1alpha1.FrontendSpec{},
+			MySQLPort: 4002,
+		},
+		Status: v1alpha1.GreptimeDBClusterStatus{ClusterPhase: v1alpha1.PhaseRunning},
+	}
+}
+
+func newBackup(databases ...string) *v1alpha1.GreptimeDBBackup {
+	return &v1alpha1.GreptimeDBBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"},
+		Spec:       v1alpha1.GreptimeDBBackupSpec{ClusterName: "test", Prefix: "backups"},
+		Status: v1alpha1.GreptimeDBBackupStatus{
+			BackupPhase:       v1alpha1.BackupPhaseCompleted,
+			ObjectStorage:     &v1alpha1.ObjectStorageProviderSpec{S3: &v1alpha1.S3Storage{Bucket: "bucket"}},
+			ExportedDatabases: databases,
+		},
+	}
+}
+
+func newRestore(overwrite bool) *v1alpha1.GreptimeDBRestore {
+	return &v1alpha1.GreptimeDBRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "default"},
+		Spec: v1alpha1.GreptimeDBRestoreSpec{
+			ClusterName: "test",
+			BackupName:  "backup",
+			Overwrite:   overwrite,
+		},
+	}
+}
+
+func reconcile(t *testing.T, r *Reconciler, restore *v1alpha1.GreptimeDBRestore) {
+	t.Helper()
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(restore)}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func reconcileUntilFinished(t *testing.T, r *Reconciler, restore *v1alpha1.GreptimeDBRestore) {
+	t.Helper()
+	for i := 0; i < 20; i++ {
+		reconcile(t, r, restore)
+		if getRestore(t, r, restore).IsFinished() {
+			return
+		}
+	}
+	t.Fatalf("the restore is not finished")
+}
+
+func getRestore(t *testing.T, r *Reconciler, restore *v1alpha1.GreptimeDBRestore) *v1alpha1.GreptimeDBRestore {
+	t.Helper()
+	got := new(v1alpha1.GreptimeDBRestore)
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(restore), got); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func databasePhases(restore *v1alpha1.GreptimeDBRestore) []v1alpha1.RestorePhase {
+	var phases []v1alpha1.RestorePhase
+	for _, database := range restore.Status.Databases {
+		phases = append(phases, database.Phase)
+	}
+	return phases
+}
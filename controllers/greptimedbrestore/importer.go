@@ -0,0 +1,146 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbrestore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+)
+
+// Importer imports the databases from the object storage to the cluster.
+type Importer interface {
+	// IsDatabaseEmpty returns true if the database does not exist or has no tables.
+	IsDatabaseEmpty(ctx context.Context, conn *common.MySQLConnection, database string) (bool, error)
+
+	// ImportDatabase creates the database, then imports the data from the location. If createTables is true,
+	// the tables are created from the schemas that saved next to the data by the backup before importing.
+	// It returns the number of the imported rows.
+	ImportDatabase(ctx context.Context, conn *common.MySQLConnection, database string, location *common.CopyLocation, format v1alpha1.BackupFormat, createTables bool) (int64, error)
+}
+
+type mysqlImporter struct{}
+
+var _ Importer = &mysqlImporter{}
+
+func (i *mysqlImporter) IsDatabaseEmpty(ctx context.Context, conn *common.MySQLConnection, database string) (bool, error) {
+	db, err := conn.Open("")
+	if err != nil {
+		return false, err
+	}
+	defer db.Close()
+
+	var count int64
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = ? AND table_type = 'BASE TABLE'", database).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count == 0, nil
+}
+
+func (i *mysqlImporter) ImportDatabase(ctx context.Context, conn *common.MySQLConnection, database string, location *common.CopyLocation, format v1alpha1.BackupFormat, createTables bool) (int64, error) {
+	if err := i.createDatabase(ctx, conn, database); err != nil {
+		return 0, err
+	}
+
+	db, err := conn.Open(database)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	if createTables {
+		schemas, err := i.loadSchemas(ctx, db, database, location)
+		if err != nil {
+			return 0, fmt.Errorf("load table schemas failed: %v", err)
+		}
+
+		for _, schema := range schemas {
+			// The table may already exist when the restore overwrites the database.
+			if _, err := db.ExecContext(ctx, createTableIfNotExists(schema)); err != nil {
+				return 0, fmt.Errorf("create table failed: %v", err)
+			}
+		}
+	}
+
+	result, err := db.ExecContext(ctx, common.CopyDatabaseFromSQL(database, location, format))
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return rows, nil
+}
+
+func (i *mysqlImporter) createDatabase(ctx context.Context, conn *common.MySQLConnection, database string) error {
+	db, err := conn.Open("")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", common.QuoteIdentifier(database)))
+	return err
+}
+
+// loadSchemas reads the `CREATE TABLE` statements saved by the backup through a temporary external table in the database.
+func (i *mysqlImporter) loadSchemas(ctx context.Context, db *sql.DB, database string, location *common.CopyLocation) ([]string, error) {
+	if _, err := db.ExecContext(ctx, common.CreateSchemaTableSQL(database, location)); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", common.QuoteIdentifier(common.BackupSchemaTableName))); err != nil {
+			klog.Warningf("Failed to drop the table '%s' of database '%s': %v", common.BackupSchemaTableName, database, err)
+		}
+	}()
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT ddl FROM %s ORDER BY idx", common.QuoteIdentifier(common.BackupSchemaTableName)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err := rows.Scan(&schema); err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+
+	return schemas, rows.Err()
+}
+
+// createTableIfNotExists makes the `CREATE TABLE` statement idempotent.
+// The output of `SHOW CREATE TABLE` already contains `IF NOT EXISTS` in most versions of GreptimeDB.
+func createTableIfNotExists(schema string) string {
+	const prefix = "CREATE TABLE "
+	trimmed := strings.TrimSpace(schema)
+	if !strings.HasPrefix(strings.ToUpper(trimmed), prefix) || strings.HasPrefix(strings.ToUpper(trimmed), prefix+"IF NOT EXISTS ") {
+		return schema
+	}
+	return prefix + "IF NOT EXISTS " + trimmed[len(prefix):]
+}
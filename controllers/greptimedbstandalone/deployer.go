@@ -18,13 +18,15 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,6 +45,8 @@ type StandaloneDeployer struct {
 
 	client.Client
 	deployer.DefaultDeployer
+
+	Recorder record.EventRecorder
 }
 
 var _ deployer.Deployer = &StandaloneDeployer{}
@@ -55,6 +59,8 @@ func NewStandaloneDeployer(mgr ctrl.Manager) *StandaloneDeployer {
 		DefaultDeployer: deployer.DefaultDeployer{
 			Client: mgr.GetClient(),
 		},
+
+		Recorder: mgr.GetEventRecorderFor("greptimedbstandalone-controller"),
 	}
 }
 
@@ -81,6 +87,7 @@ func (d *StandaloneDeployer) Generate(crdObject client.Object) ([]client.Object,
 		BuildConfigMap().
 		BuildStatefulSet().
 		BuildPodMonitor().
+		BuildPodDisruptionBudget().
 		SetControllerAndAnnotation().
 		Generate()
 
@@ -91,16 +98,77 @@ func (d *StandaloneDeployer) Generate(crdObject client.Object) ([]client.Object,
 	return objects, nil
 }
 
-func (d *StandaloneDeployer) CleanUp(ctx context.Context, crdObject client.Object) error {
+// Apply is re-implemented for standalone to expand the PVCs before applying the StatefulSet.
+func (d *StandaloneDeployer) Apply(ctx context.Context, crdObject client.Object, objects []client.Object) error {
 	standalone, err := d.getStandalone(crdObject)
 	if err != nil {
 		return err
 	}
 
-	if fs := standalone.GetDatanodeFileStorage(); fs != nil {
-		if fs.IsUseEmptyDir() {
+	if err := d.keepStorageSizes(ctx, standalone, objects); err != nil {
+		return err
+	}
+
+	for _, object := range objects {
+		sts, ok := object.(*appsv1.StatefulSet)
+		if !ok {
+			continue
+		}
+
+		recreated, err := d.expandPVCs(ctx, standalone, sts)
+		if err != nil {
+			return err
+		}
+
+		// The StatefulSet will be recreated with the expanded volume claim templates in the next reconciliation.
+		if recreated {
+			return deployer.ErrSyncNotReady
+		}
+	}
+
+	if !standalone.GetPodDisruptionBudget().IsEnabled() {
+		if err := d.deleteDisabledPodDisruptionBudget(ctx, standalone); err != nil {
+			return err
+		}
+	}
+
+	return d.DefaultDeployer.Apply(ctx, crdObject, objects)
+}
+
+// deleteDisabledPodDisruptionBudget deletes the PodDisruptionBudget of the standalone if it's disabled,
+// otherwise it will keep blocking the evictions of the pod.
+func (d *StandaloneDeployer) deleteDisabledPodDisruptionBudget(ctx context.Context, standalone *v1alpha1.GreptimeDBStandalone) error {
+	pdb := new(policyv1.PodDisruptionBudget)
+	objectKey := client.ObjectKey{
+		Namespace: standalone.Namespace,
+		Name:      common.ResourceName(standalone.Name, v1alpha1.StandaloneRoleKind),
+	}
+	if err := d.Get(ctx, objectKey, pdb); err != nil {
+		if errors.IsNotFound(err) {
 			return nil
 		}
+		return err
+	}
+
+	if !metav1.IsControlledBy(pdb, standalone) {
+		return nil
+	}
+
+	klog.Infof("Delete the PodDisruptionBudget '%s/%s' since it's disabled", pdb.Namespace, pdb.Name)
+	if err := d.Delete(ctx, pdb); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (d *StandaloneDeployer) CleanUp(ctx context.Context, crdObject client.Object) error {
+	standalone, err := d.getStandalone(crdObject)
+	if err != nil {
+		return err
+	}
+
+	if fs := standalone.GetDatanodeFileStorage(); fs.IsUsePVC() {
 		if fs.GetPolicy() == v1alpha1.StorageRetainPolicyTypeDelete {
 			if err := d.deleteStorage(ctx, standalone.Namespace, common.ResourceName(standalone.Name, v1alpha1.StandaloneRoleKind), common.FileStorageTypeDatanode); err != nil {
 				return err
@@ -146,6 +214,11 @@ func (d *StandaloneDeployer) CheckAndUpdateStatus(ctx context.Context, crdObject
 		return false, err
 	}
 
+	// Refresh the progress of expanding the PVCs, and it will be updated with the replicas.
+	if _, err := common.UpdatePVCResizeStatus(ctx, d.Client, standalone.Namespace, standalone.Status.PVCResizes); err != nil {
+		return false, err
+	}
+
 	standalone.Status.Replicas = *sts.Spec.Replicas
 	standalone.Status.ReadyReplicas = sts.Status.ReadyReplicas
 	if err = UpdateStatus(ctx, standalone, d.Client); err != nil {
@@ -163,6 +236,66 @@ func (d *StandaloneDeployer) getStandalone(crdObject client.Object) (*v1alpha1.G
 	return standalone, nil
 }
 
+// keepStorageSizes skips the decreases of the storage sizes instead of failing the sync, since the PVCs can't be shrunk.
+// The skipped decreases are reported by a Warning event and the StorageShrinkRejected condition.
+func (d *StandaloneDeployer) keepStorageSizes(ctx context.Context, standalone *v1alpha1.GreptimeDBStandalone, objects []client.Object) error {
+	var skipped []string
+	for _, object := range objects {
+		sts, ok := object.(*appsv1.StatefulSet)
+		if !ok {
+			continue
+		}
+
+		messages, err := common.KeepStorageSizes(ctx, d.Client, sts)
+		if err != nil {
+			return err
+		}
+		skipped = append(skipped, messages...)
+	}
+
+	condition := standalone.Status.GetCondition(v1alpha1.ConditionTypeStorageShrinkRejected)
+	if len(skipped) == 0 {
+		if condition == nil || condition.Status != corev1.ConditionTrue {
+			return nil
+		}
+		standalone.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeStorageShrinkRejected, corev1.ConditionFalse,
+			"StorageNotDecreased", "the storage sizes are not decreased"))
+		return UpdateStatus(ctx, standalone, d.Client)
+	}
+
+	message := strings.Join(skipped, "; ")
+	if condition != nil && condition.Status == corev1.ConditionTrue && condition.Message == message {
+		return nil
+	}
+
+	d.Recorder.Event(standalone, corev1.EventTypeWarning, "StorageShrinkRejected", message)
+	standalone.Status.SetCondition(*v1alpha1.NewCondition(v1alpha1.ConditionTypeStorageShrinkRejected, corev1.ConditionTrue,
+		"StorageShrinkRejected", message))
+
+	return UpdateStatus(ctx, standalone, d.Client)
+}
+
+// expandPVCs expands or modifies the PVCs if the volume claim templates are changed, and records the resize progress in the status.
+// It returns true if the StatefulSet is deleted to be recreated with the new volume claim templates.
+func (d *StandaloneDeployer) expandPVCs(ctx context.Context, standalone *v1alpha1.GreptimeDBStandalone, newSts *appsv1.StatefulSet) (bool, error) {
+	oldSts := new(appsv1.StatefulSet)
+	if err := d.Get(ctx, client.ObjectKeyFromObject(newSts), oldSts); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	statuses, recreated, err := common.ExpandStatefulSetPVCs(ctx, d.Client, oldSts, newSts)
+	if err != nil || !recreated {
+		return false, err
+	}
+
+	standalone.Status.PVCResizes = common.MergePVCResizeStatus(standalone.Status.PVCResizes, statuses)
+
+	return true, UpdateStatus(ctx, standalone, d.Client)
+}
+
 func (d *StandaloneDeployer) deleteStorage(ctx context.Context, namespace, resourceName string, fsType common.FileStorageType) error {
 	klog.Infof("Deleting standalone storage...")
 
@@ -276,8 +409,9 @@ func (b *standaloneBuilder) BuildStatefulSet() deployer.Builder {
 		},
 	}
 
-	if !b.standalone.GetDatanodeFileStorage().IsUseEmptyDir() {
-		sts.Spec.VolumeClaimTemplates = b.generatePVCs()
+	sts.Spec.VolumeClaimTemplates = b.generatePVCs()
+	if len(sts.Spec.VolumeClaimTemplates) > 0 {
+		sts.Spec.PersistentVolumeClaimRetentionPolicy = common.PVCRetentionPolicy(b.standalone.GetDatanodeFileStorage())
 	}
 
 	configData, err := dbconfig.FromStandalone(b.standalone)
@@ -318,6 +452,21 @@ func (b *standaloneBuilder) BuildPodMonitor() deployer.Builder {
 	return b
 }
 
+func (b *standaloneBuilder) BuildPodDisruptionBudget() deployer.Builder {
+	if b.Err != nil {
+		return b
+	}
+
+	if b.standalone == nil || !b.standalone.GetPodDisruptionBudget().IsEnabled() {
+		return b
+	}
+
+	b.Objects = append(b.Objects, common.GeneratePodDisruptionBudget(b.standalone.Namespace,
+		common.ResourceName(b.standalone.Name, v1alpha1.StandaloneRoleKind), b.standalone.GetPodDisruptionBudget()))
+
+	return b
+}
+
 func (b *standaloneBuilder) generatePodTemplateSpec() corev1.PodTemplateSpec {
 	template := common.GeneratePodTemplateSpec(v1alpha1.StandaloneRoleKind, b.standalone.Spec.Base)
 
@@ -328,9 +477,7 @@ func (b *standaloneBuilder) generatePodTemplateSpec() corev1.PodTemplateSpec {
 
 	b.addVolumeMounts(template)
 
-	if b.standalone.GetDatanodeFileStorage().IsUseEmptyDir() {
-		b.addEmptyDirAsStorage(template, b.standalone)
-	}
+	b.addEphemeralStorage(template)
 
 	template.Spec.Containers[constant.MainContainerIndex].Ports = b.containerPorts()
 	template.Labels = util.MergeStringMap(template.Labels, map[string]string{
@@ -370,17 +517,17 @@ func (b *standaloneBuilder) generatePVCs() []corev1.PersistentVolumeClaim {
 	var claims []corev1.PersistentVolumeClaim
 
 	// It's always not nil because it's the default value.
-	if fs := b.standalone.GetDatanodeFileStorage(); fs != nil {
+	if fs := b.standalone.GetDatanodeFileStorage(); fs.IsUsePVC() {
 		claims = append(claims, *common.FileStorageToPVC(b.standalone.Name, "", fs, common.FileStorageTypeDatanode, v1alpha1.StandaloneRoleKind))
 	}
 
 	// Allocate the standalone WAL storage for the raft-engine.
-	if fs := b.standalone.GetWALProvider().GetRaftEngineWAL().GetFileStorage(); fs != nil {
+	if fs := b.standalone.GetWALProvider().GetRaftEngineWAL().GetFileStorage(); fs.IsUsePVC() {
 		claims = append(claims, *common.FileStorageToPVC(b.standalone.Name, "", fs, common.FileStorageTypeWAL, v1alpha1.StandaloneRoleKind))
 	}
 
 	// Allocate the standalone cache file storage for the datanode.
-	if fs := b.standalone.GetObjectStorageProvider().GetCacheFileStorage(); fs != nil {
+	if fs := b.standalone.GetObjectStorageProvider().GetCacheFileStorage(); fs.IsUsePVC() {
 		claims = append(claims, *common.FileStorageToPVC(b.standalone.Name, "", fs, common.FileStorageTypeCache, v1alpha1.StandaloneRoleKind))
 	}
 
@@ -504,14 +651,20 @@ func (b *standaloneBuilder) addVolumeMounts(template *corev1.PodTemplateSpec) {
 	}
 }
 
-func (b *standaloneBuilder) addEmptyDirAsStorage(template *corev1.PodTemplateSpec, spec *v1alpha1.GreptimeDBStandalone) {
-	storageSize := resource.MustParse(spec.GetDatanodeFileStorage().GetSize())
-	template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
-		Name: spec.GetDatanodeFileStorage().GetName(),
-		VolumeSource: corev1.VolumeSource{
-			EmptyDir: &corev1.EmptyDirVolumeSource{
-				SizeLimit: &storageSize,
-			},
-		},
-	})
+// addEphemeralStorage adds the volumes of the datanode, WAL and cache storage that use the empty dir or the generic ephemeral volume.
+func (b *standaloneBuilder) addEphemeralStorage(template *corev1.PodTemplateSpec) {
+	storages := []struct {
+		fs     *v1alpha1.FileStorage
+		fsType common.FileStorageType
+	}{
+		{b.standalone.GetDatanodeFileStorage(), common.FileStorageTypeDatanode},
+		{b.standalone.GetWALProvider().GetRaftEngineWAL().GetFileStorage(), common.FileStorageTypeWAL},
+		{b.standalone.GetObjectStorageProvider().GetCacheFileStorage(), common.FileStorageTypeCache},
+	}
+
+	for _, storage := range storages {
+		if volume := common.FileStorageToVolume(b.standalone.Name, "", storage.fs, storage.fsType, v1alpha1.StandaloneRoleKind); volume != nil {
+			template.Spec.Volumes = append(template.Spec.Volumes, *volume)
+		}
+	}
 }
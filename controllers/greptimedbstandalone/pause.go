@@ -0,0 +1,45 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbstandalone
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/common"
+)
+
+// pause marks the standalone as paused. The operator will not touch the resources of the standalone until it's unpaused.
+func (r *Reconciler) pause(ctx context.Context, standalone *v1alpha1.GreptimeDBStandalone) error {
+	if !common.Pause(r.Recorder, standalone, &standalone.Status) {
+		return nil
+	}
+
+	return UpdateStatus(ctx, standalone, r.Client)
+}
+
+// resume reports the drift of the resources that are modified during the pause, and the drift will be overwritten by the next sync.
+func (r *Reconciler) resume(ctx context.Context, standalone *v1alpha1.GreptimeDBStandalone) error {
+	resumed, err := common.Resume(ctx, r.Client, r.Recorder, standalone, &standalone.Status, func() ([]client.Object, error) {
+		return r.Deployer.Generate(standalone)
+	})
+	if err != nil || !resumed {
+		return err
+	}
+
+	return UpdateStatus(ctx, standalone, r.Client)
+}
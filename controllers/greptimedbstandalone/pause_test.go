@@ -0,0 +1,216 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greptimedbstandalone
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GreptimeTeam/greptimedb-operator/apis/v1alpha1"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/deployer"
+)
+
+func TestReconcilePaused(t *testing.T) {
+	standalone := &v1alpha1.GreptimeDBStandalone{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "default",
+			Annotations: map[string]string{v1alpha1.PausedAnnotationKey: "true"},
+		},
+		Spec: v1alpha1.GreptimeDBStandaloneSpec{
+			Base: &v1alpha1.PodTemplateSpec{
+				MainContainer: &v1alpha1.MainContainerSpec{Image: "greptime/greptimedb:latest"},
+			},
+		},
+	}
+
+	// The StatefulSet is applied with the latest image and changed by hand during the pause.
+	sts := newStatefulSet("greptime/greptimedb:latest")
+	sts.Spec.Template.Spec.Containers[0].Image = "greptime/greptimedb:debug"
+
+	d := &fakeDeployer{objects: []client.Object{newStatefulSet("greptime/greptimedb:latest")}}
+	r := newTestReconciler(d, standalone, sts)
+
+	ctx := context.Background()
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(standalone)}
+
+	// The sync is skipped while the standalone is paused.
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(ctx, request); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d.synced != 0 {
+		t.Errorf("expected the sync to be skipped during the pause, got: %d", d.synced)
+	}
+
+	current := new(v1alpha1.GreptimeDBStandalone)
+	if err := r.Get(ctx, request.NamespacedName, current); err != nil {
+		t.Fatal(err)
+	}
+	if condition := current.Status.GetCondition(v1alpha1.ConditionTypePaused); condition == nil || condition.Status != corev1.ConditionTrue {
+		t.Fatalf("expected the Paused condition to be true, got: %+v", condition)
+	}
+	if events := pauseEvents(r.Recorder.(*record.FakeRecorder)); len(events) != 1 || !strings.Contains(events[0], "Paused") {
+		t.Errorf("expected a Paused event, got: %v", events)
+	}
+
+	// The drift is reported and overwritten by the sync after the standalone is resumed.
+	delete(current.Annotations, v1alpha1.PausedAnnotationKey)
+	if err := r.Update(ctx, current); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatal(err)
+	}
+	if d.synced != 1 {
+		t.Errorf("expected the standalone to be synced after the resume, got: %d", d.synced)
+	}
+
+	if err := r.Get(ctx, request.NamespacedName, current); err != nil {
+		t.Fatal(err)
+	}
+	condition := current.Status.GetCondition(v1alpha1.ConditionTypePaused)
+	if condition == nil || condition.Status != corev1.ConditionFalse ||
+		!strings.Contains(condition.Message, "StatefulSet 'test-standalone' (spec.template.spec.containers[0].image)") {
+		t.Errorf("expected the Paused condition to report the drifted fields, got: %+v", condition)
+	}
+
+	live := new(appsv1.StatefulSet)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(sts), live); err != nil {
+		t.Fatal(err)
+	}
+	var applied appsv1.StatefulSetSpec
+	if err := json.Unmarshal([]byte(live.Annotations[deployer.LastAppliedResourceSpec]), &applied); err != nil {
+		t.Fatal(err)
+	}
+	if image := applied.Template.Spec.Containers[0].Image; image != "greptime/greptimedb:debug" {
+		t.Errorf("expected the last applied spec to be reset to the live spec, got: %s", image)
+	}
+
+	// The drift is only reported once.
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatal(err)
+	}
+	if events := pauseEvents(r.Recorder.(*record.FakeRecorder)); len(events) != 1 || !strings.Contains(events[0], "Resumed") {
+		t.Errorf("expected a Resumed event, got: %v", events)
+	}
+}
+
+func newTestReconciler(d deployer.Deployer, objs ...client.Object) *Reconciler {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+
+	return &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&v1alpha1.GreptimeDBStandalone{}).
+			Build(),
+		EnableAdmissionWebhook: true,
+		Scheme:                 scheme,
+		Deployer:               d,
+		Recorder:               record.NewFakeRecorder(100),
+	}
+}
+
+func newStatefulSet(image string) *appsv1.StatefulSet {
+	sts := &appsv1.StatefulSet{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-standalone", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To(int32(1)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "standalone", Image: image}}},
+			},
+		},
+	}
+	data, _ := json.Marshal(sts.Spec)
+	sts.Annotations = map[string]string{deployer.LastAppliedResourceSpec: string(data)}
+	return sts
+}
+
+// pauseEvents drains the Paused and Resumed events of the recorder.
+func pauseEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			if strings.Contains(event, "Paused") || strings.Contains(event, "Resumed") {
+				events = append(events, event)
+			}
+		default:
+			return events
+		}
+	}
+}
+
+// fakeDeployer generates the fixed objects and counts the syncs.
+type fakeDeployer struct {
+	objects []client.Object
+	synced  int
+}
+
+var _ deployer.Deployer = &fakeDeployer{}
+
+func (d *fakeDeployer) Sync(_ context.Context, _ client.Object, _ deployer.ComponentOperator) error {
+	d.synced++
+	return nil
+}
+
+func (d *fakeDeployer) Generate(_ client.Object) ([]client.Object, error) {
+	var objects []client.Object
+	for _, object := range d.objects {
+		objects = append(objects, object.DeepCopyObject().(client.Object))
+	}
+	return objects, nil
+}
+
+func (d *fakeDeployer) Apply(_ context.Context, _ client.Object, _ []client.Object) error {
+	return nil
+}
+
+func (d *fakeDeployer) CleanUp(_ context.Context, _ client.Object) error {
+	return nil
+}
+
+func (d *fakeDeployer) CheckAndUpdateStatus(_ context.Context, _ client.Object) (bool, error) {
+	return true, nil
+}
+
+func (d *fakeDeployer) PreSyncHooks() []deployer.Hook {
+	return nil
+}
+
+func (d *fakeDeployer) PostSyncHooks() []deployer.Hook {
+	return nil
+}
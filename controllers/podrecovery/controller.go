@@ -0,0 +1,304 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podrecovery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/GreptimeTeam/greptimedb-operator/cmd/operator/app/options"
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/constant"
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/metrics"
+)
+
+// podNodeNameField is the field index of the pods by the name of the node that they are running on.
+const podNodeNameField = "spec.nodeName"
+
+// Reconciler force deletes the GreptimeDB pods that are stuck on the nodes that are not ready,
+// so the StatefulSets can recreate them on the other nodes.
+type Reconciler struct {
+	client.Client
+
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Clock    clock.PassiveClock
+
+	// FailedNodeTimeout is the duration that the node is not ready before its pods are force deleted.
+	FailedNodeTimeout time.Duration
+}
+
+func Setup(mgr ctrl.Manager, o *options.Options) error {
+	if !o.EnableFailedNodePodRecovery {
+		return nil
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podNodeNameField, podNodeName); err != nil {
+		return err
+	}
+
+	reconciler := &Reconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		Recorder:          mgr.GetEventRecorderFor("podrecovery-controller"),
+		Clock:             clock.RealClock{},
+		FailedNodeTimeout: o.FailedNodeTimeout,
+	}
+	return reconciler.SetupWithManager(mgr)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("podrecovery").
+		For(&corev1.Pod{}, builder.WithPredicates(predicate.NewPredicateFuncs(isGreptimeDBPod))).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.nodeToPods)).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;delete;
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=volumeattachments,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch;
+
+// Reconcile force deletes the pod if its node has been not ready for longer than the FailedNodeTimeout.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	klog.V(2).Infof("Reconciling Pod: %s", req.NamespacedName)
+
+	pod := new(corev1.Pod)
+	if err := r.Get(ctx, req.NamespacedName, pod); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Only the pods of the StatefulSets are stuck on the failed node since the StatefulSet
+	// will not create the pod with the same identity until the old one is deleted.
+	if pod.Spec.NodeName == "" || !isGreptimeDBPod(pod) || !isOwnedByStatefulSet(pod) {
+		return ctrl.Result{}, nil
+	}
+
+	node := new(corev1.Node)
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	notReadySince, ok := nodeNotReadySince(node)
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+
+	if elapsed := r.Clock.Since(notReadySince); elapsed < r.FailedNodeTimeout {
+		return ctrl.Result{RequeueAfter: r.FailedNodeTimeout - elapsed}, nil
+	}
+
+	// Collect the volumes before the pod is deleted because the ephemeral PVCs will be deleted with the pod.
+	volumes, err := r.podPersistentVolumes(ctx, pod)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.forceDeletePod(ctx, pod, node); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.deleteVolumeAttachments(ctx, pod, node, volumes); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) forceDeletePod(ctx context.Context, pod *corev1.Pod, node *corev1.Node) error {
+	klog.Infof("Force delete the pod '%s/%s' on the not ready node '%s'", pod.Namespace, pod.Name, node.Name)
+	if err := r.Delete(ctx, pod, client.GracePeriodSeconds(0)); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	r.Recorder.Event(pod, corev1.EventTypeWarning, "ForceDeletedOnFailedNode",
+		fmt.Sprintf("Force deleted the pod because the node '%s' has been not ready for more than %s", node.Name, r.FailedNodeTimeout))
+	metrics.RecordFailedNodePodDeletion(pod.Namespace, pod.Labels[constant.GreptimeDBComponentName], node.Name)
+
+	return nil
+}
+
+// deleteVolumeAttachments deletes the attachments of the pod volumes on the failed node,
+// otherwise the volumes can't be attached to the new node until the attachments are timed out.
+// The attachments are only deleted if the node is fenced, since a partitioned node may still be writing to the volumes.
+func (r *Reconciler) deleteVolumeAttachments(ctx context.Context, pod *corev1.Pod, node *corev1.Node, volumes sets.Set[string]) error {
+	if volumes.Len() == 0 {
+		return nil
+	}
+
+	if !r.isNodeFenced(node) {
+		klog.Infof("Keep the volume attachments of the pod '%s/%s' because the not ready node '%s' is not fenced", pod.Namespace, pod.Name, node.Name)
+		r.Recorder.Event(pod, corev1.EventTypeWarning, "VolumeAttachmentKept",
+			fmt.Sprintf("Kept the volume attachments on the not ready node '%s' because it's not fenced, taint the node with '%s' to detach the volumes after it's shut down",
+				node.Name, corev1.TaintNodeOutOfService))
+		return nil
+	}
+
+	var attachments storagev1.VolumeAttachmentList
+	if err := r.List(ctx, &attachments); err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments.Items {
+		if attachment.Spec.NodeName != node.Name || attachment.Spec.Source.PersistentVolumeName == nil ||
+			!volumes.Has(*attachment.Spec.Source.PersistentVolumeName) || attachment.DeletionTimestamp != nil {
+			continue
+		}
+
+		klog.Infof("Delete the volume attachment '%s' of the pod '%s/%s' on the not ready node '%s'", attachment.Name, pod.Namespace, pod.Name, node.Name)
+		if err := r.Delete(ctx, &attachment); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		r.Recorder.Event(pod, corev1.EventTypeWarning, "VolumeAttachmentDeleted",
+			fmt.Sprintf("Deleted the volume attachment '%s' of the volume '%s' on the not ready node '%s'", attachment.Name, *attachment.Spec.Source.PersistentVolumeName, node.Name))
+		metrics.RecordFailedNodeVolumeAttachmentDeletion(node.Name)
+	}
+
+	return nil
+}
+
+// isNodeFenced returns true if the node is tainted as out-of-service after it's shut down,
+// or it has been unreachable for longer than the FailedNodeTimeout.
+func (r *Reconciler) isNodeFenced(node *corev1.Node) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == corev1.TaintNodeOutOfService {
+			return true
+		}
+
+		if taint.Key == corev1.TaintNodeUnreachable && taint.Effect == corev1.TaintEffectNoExecute &&
+			taint.TimeAdded != nil && r.Clock.Since(taint.TimeAdded.Time) >= r.FailedNodeTimeout {
+			return true
+		}
+	}
+	return false
+}
+
+// podPersistentVolumes returns the names of the persistent volumes that are bound to the PVCs of the pod.
+func (r *Reconciler) podPersistentVolumes(ctx context.Context, pod *corev1.Pod) (sets.Set[string], error) {
+	volumes := sets.New[string]()
+	for _, volume := range pod.Spec.Volumes {
+		var claimName string
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		case volume.Ephemeral != nil:
+			// The PVC of the ephemeral volume is named as `${pod}-${volume}`.
+			claimName = fmt.Sprintf("%s-%s", pod.Name, volume.Name)
+		default:
+			continue
+		}
+
+		claim := new(corev1.PersistentVolumeClaim)
+		if err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: claimName}, claim); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		if claim.Spec.VolumeName != "" {
+			volumes.Insert(claim.Spec.VolumeName)
+		}
+	}
+
+	return volumes, nil
+}
+
+func (r *Reconciler) nodeToPods(ctx context.Context, obj client.Object) []reconcile.Request {
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return nil
+	}
+
+	if _, notReady := nodeNotReadySince(node); !notReady {
+		return nil
+	}
+
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.MatchingFields{podNodeNameField: node.Name}, client.HasLabels{constant.GreptimeDBComponentName}); err != nil {
+		klog.Errorf("Failed to list the pods on the node '%s': %v", node.Name, err)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, pod := range pods.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&pod)})
+	}
+
+	return requests
+}
+
+// nodeNotReadySince returns the time when the node became not ready, and false if the node is ready or its status is not reported yet.
+func nodeNotReadySince(node *corev1.Node) (time.Time, bool) {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			if condition.Status == corev1.ConditionTrue {
+				return time.Time{}, false
+			}
+			return condition.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func isGreptimeDBPod(obj client.Object) bool {
+	_, ok := obj.GetLabels()[constant.GreptimeDBComponentName]
+	return ok
+}
+
+func isOwnedByStatefulSet(pod *corev1.Pod) bool {
+	owner := metav1.GetControllerOf(pod)
+	return owner != nil && owner.Kind == "StatefulSet"
+}
+
+func podNodeName(obj client.Object) []string {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || pod.Spec.NodeName == "" {
+		return nil
+	}
+	return []string{pod.Spec.NodeName}
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podrecovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GreptimeTeam/greptimedb-operator/controllers/constant"
+)
+
+func TestReconcileFailedNodePod(t *testing.T) {
+	// The time of the conditions is serialized in seconds.
+	now := time.Now().Truncate(time.Second)
+
+	// The pod is force deleted 4 minutes later, and the node has been not ready for 6 minutes by then.
+	tests := []struct {
+		name         string
+		taints       []corev1.Taint
+		wantDetached bool
+	}{
+		{
+			name: "not fenced",
+		},
+		{
+			name:         "out of service",
+			taints:       []corev1.Taint{{Key: corev1.TaintNodeOutOfService, Effect: corev1.TaintEffectNoExecute}},
+			wantDetached: true,
+		},
+		{
+			name: "unreachable for longer than the timeout",
+			taints: []corev1.Taint{
+				{Key: corev1.TaintNodeUnreachable, Effect: corev1.TaintEffectNoExecute, TimeAdded: ptr.To(metav1.NewTime(now.Add(-2 * time.Minute)))},
+			},
+			wantDetached: true,
+		},
+		{
+			name: "unreachable for less than the timeout",
+			taints: []corev1.Taint{
+				{Key: corev1.TaintNodeUnreachable, Effect: corev1.TaintEffectNoExecute, TimeAdded: ptr.To(metav1.NewTime(now.Add(2 * time.Minute)))},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testReconcileFailedNodePod(t, now, tt.taints, tt.wantDetached)
+		})
+	}
+}
+
+func testReconcileFailedNodePod(t *testing.T, now time.Time, taints []corev1.Taint, wantDetached bool) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Spec:       corev1.NodeSpec{Taints: taints},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:               corev1.NodeReady,
+					Status:             corev1.ConditionUnknown,
+					LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Minute)),
+				},
+			},
+		},
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-datanode-0",
+			Namespace: "default",
+			Labels:    map[string]string{constant.GreptimeDBComponentName: "test-datanode"},
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "test-datanode", UID: "uid", Controller: ptr.To(true)},
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: node.Name,
+			Volumes: []corev1.Volume{
+				{
+					Name: "datanode",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "datanode-test-datanode-0"},
+					},
+				},
+				{
+					Name:         "wal",
+					VolumeSource: corev1.VolumeSource{Ephemeral: &corev1.EphemeralVolumeSource{}},
+				},
+			},
+		},
+	}
+
+	newAttachment := func(name, pv, nodeName string) *storagev1.VolumeAttachment {
+		return &storagev1.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: storagev1.VolumeAttachmentSpec{
+				NodeName: nodeName,
+				Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: ptr.To(pv)},
+			},
+		}
+	}
+
+	r := newTestReconciler(
+		node,
+		pod,
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "datanode-test-datanode-0", Namespace: "default"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-datanode"},
+		},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-datanode-0-wal", Namespace: "default"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-wal"},
+		},
+		newAttachment("va-datanode", "pv-datanode", node.Name),
+		newAttachment("va-wal", "pv-wal", node.Name),
+		newAttachment("va-other-node", "pv-datanode", "node-2"),
+		newAttachment("va-other-volume", "pv-other", node.Name),
+	)
+
+	clock := clocktesting.NewFakePassiveClock(now)
+	r.Clock = clock
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pod)}
+
+	// The node is not ready for less than the timeout.
+	result, err := r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RequeueAfter != 3*time.Minute {
+		t.Errorf("expected to requeue after 3m, got: %v", result.RequeueAfter)
+	}
+	if err := r.Get(ctx, req.NamespacedName, new(corev1.Pod)); err != nil {
+		t.Fatalf("expected the pod to be kept before the timeout, got: %v", err)
+	}
+
+	clock.SetTime(now.Add(4 * time.Minute))
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Get(ctx, req.NamespacedName, new(corev1.Pod)); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the pod to be deleted, got: %v", err)
+	}
+
+	// The volume attachments are only deleted if the node is fenced.
+	for name, wantDeleted := range map[string]bool{
+		"va-datanode":     wantDetached,
+		"va-wal":          wantDetached,
+		"va-other-node":   false,
+		"va-other-volume": false,
+	} {
+		err := r.Get(ctx, client.ObjectKey{Name: name}, new(storagev1.VolumeAttachment))
+		if deleted := k8serrors.IsNotFound(err); deleted != wantDeleted {
+			t.Errorf("volume attachment '%s': want deleted %v, got: %v", name, wantDeleted, err)
+		}
+	}
+}
+
+func TestReconcileReadyNodePod(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:               corev1.NodeReady,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+				},
+			},
+		},
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-datanode-0",
+			Namespace: "default",
+			Labels:    map[string]string{constant.GreptimeDBComponentName: "test-datanode"},
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "test-datanode", UID: "uid", Controller: ptr.To(true)},
+			},
+		},
+		Spec: corev1.PodSpec{NodeName: node.Name},
+	}
+
+	r := newTestReconciler(node, pod)
+
+	ctx := context.Background()
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pod)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("expected no requeue for the pod on the ready node, got: %v", result.RequeueAfter)
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(pod), new(corev1.Pod)); err != nil {
+		t.Errorf("expected the pod to be kept, got: %v", err)
+	}
+}
+
+// newTestReconciler returns a Reconciler backed by a fake client that is seeded with objs.
+func newTestReconciler(objs ...client.Object) *Reconciler {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+
+	return &Reconciler{
+		Client:            fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		Scheme:            scheme,
+		Recorder:          record.NewFakeRecorder(100),
+		Clock:             clocktesting.NewFakePassiveClock(time.Now()),
+		FailedNodeTimeout: 5 * time.Minute,
+	}
+}
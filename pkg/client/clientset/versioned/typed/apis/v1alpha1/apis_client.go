@@ -26,7 +26,10 @@ import (
 
 type ApisV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	GreptimeDBBackupsGetter
+	GreptimeDBBackupSchedulesGetter
 	GreptimeDBClustersGetter
+	GreptimeDBRestoresGetter
 	GreptimeDBStandalonesGetter
 }
 
@@ -35,10 +38,22 @@ type ApisV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *ApisV1alpha1Client) GreptimeDBBackups(namespace string) GreptimeDBBackupInterface {
+	return newGreptimeDBBackups(c, namespace)
+}
+
+func (c *ApisV1alpha1Client) GreptimeDBBackupSchedules(namespace string) GreptimeDBBackupScheduleInterface {
+	return newGreptimeDBBackupSchedules(c, namespace)
+}
+
 func (c *ApisV1alpha1Client) GreptimeDBClusters(namespace string) GreptimeDBClusterInterface {
 	return newGreptimeDBClusters(c, namespace)
 }
 
+func (c *ApisV1alpha1Client) GreptimeDBRestores(namespace string) GreptimeDBRestoreInterface {
+	return newGreptimeDBRestores(c, namespace)
+}
+
 func (c *ApisV1alpha1Client) GreptimeDBStandalones(namespace string) GreptimeDBStandaloneInterface {
 	return newGreptimeDBStandalones(c, namespace)
 }
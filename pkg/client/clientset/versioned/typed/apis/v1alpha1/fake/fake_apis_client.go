@@ -26,10 +26,22 @@ type FakeApisV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeApisV1alpha1) GreptimeDBBackups(namespace string) v1alpha1.GreptimeDBBackupInterface {
+	return newFakeGreptimeDBBackups(c, namespace)
+}
+
+func (c *FakeApisV1alpha1) GreptimeDBBackupSchedules(namespace string) v1alpha1.GreptimeDBBackupScheduleInterface {
+	return newFakeGreptimeDBBackupSchedules(c, namespace)
+}
+
 func (c *FakeApisV1alpha1) GreptimeDBClusters(namespace string) v1alpha1.GreptimeDBClusterInterface {
 	return newFakeGreptimeDBClusters(c, namespace)
 }
 
+func (c *FakeApisV1alpha1) GreptimeDBRestores(namespace string) v1alpha1.GreptimeDBRestoreInterface {
+	return newFakeGreptimeDBRestores(c, namespace)
+}
+
 func (c *FakeApisV1alpha1) GreptimeDBStandalones(namespace string) v1alpha1.GreptimeDBStandaloneInterface {
 	return newFakeGreptimeDBStandalones(c, namespace)
 }
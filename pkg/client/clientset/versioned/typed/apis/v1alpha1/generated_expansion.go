@@ -16,6 +16,12 @@
 
 package v1alpha1
 
+type GreptimeDBBackupExpansion interface{}
+
+type GreptimeDBBackupScheduleExpansion interface{}
+
 type GreptimeDBClusterExpansion interface{}
 
+type GreptimeDBRestoreExpansion interface{}
+
 type GreptimeDBStandaloneExpansion interface{}
@@ -22,8 +22,14 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// GreptimeDBBackups returns a GreptimeDBBackupInformer.
+	GreptimeDBBackups() GreptimeDBBackupInformer
+	// GreptimeDBBackupSchedules returns a GreptimeDBBackupScheduleInformer.
+	GreptimeDBBackupSchedules() GreptimeDBBackupScheduleInformer
 	// GreptimeDBClusters returns a GreptimeDBClusterInformer.
 	GreptimeDBClusters() GreptimeDBClusterInformer
+	// GreptimeDBRestores returns a GreptimeDBRestoreInformer.
+	GreptimeDBRestores() GreptimeDBRestoreInformer
 	// GreptimeDBStandalones returns a GreptimeDBStandaloneInformer.
 	GreptimeDBStandalones() GreptimeDBStandaloneInformer
 }
@@ -39,11 +45,26 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// GreptimeDBBackups returns a GreptimeDBBackupInformer.
+func (v *version) GreptimeDBBackups() GreptimeDBBackupInformer {
+	return &greptimeDBBackupInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// GreptimeDBBackupSchedules returns a GreptimeDBBackupScheduleInformer.
+func (v *version) GreptimeDBBackupSchedules() GreptimeDBBackupScheduleInformer {
+	return &greptimeDBBackupScheduleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // GreptimeDBClusters returns a GreptimeDBClusterInformer.
 func (v *version) GreptimeDBClusters() GreptimeDBClusterInformer {
 	return &greptimeDBClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// GreptimeDBRestores returns a GreptimeDBRestoreInformer.
+func (v *version) GreptimeDBRestores() GreptimeDBRestoreInformer {
+	return &greptimeDBRestoreInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // GreptimeDBStandalones returns a GreptimeDBStandaloneInformer.
 func (v *version) GreptimeDBStandalones() GreptimeDBStandaloneInformer {
 	return &greptimeDBStandaloneInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -51,8 +51,14 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=apis, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("greptimedbbackups"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apis().V1alpha1().GreptimeDBBackups().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("greptimedbbackupschedules"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apis().V1alpha1().GreptimeDBBackupSchedules().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("greptimedbclusters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apis().V1alpha1().GreptimeDBClusters().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("greptimedbrestores"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apis().V1alpha1().GreptimeDBRestores().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("greptimedbstandalones"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apis().V1alpha1().GreptimeDBStandalones().Informer()}, nil
 
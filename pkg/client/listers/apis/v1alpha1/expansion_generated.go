@@ -16,6 +16,22 @@
 
 package v1alpha1
 
+// GreptimeDBBackupListerExpansion allows custom methods to be added to
+// GreptimeDBBackupLister.
+type GreptimeDBBackupListerExpansion interface{}
+
+// GreptimeDBBackupNamespaceListerExpansion allows custom methods to be added to
+// GreptimeDBBackupNamespaceLister.
+type GreptimeDBBackupNamespaceListerExpansion interface{}
+
+// GreptimeDBBackupScheduleListerExpansion allows custom methods to be added to
+// GreptimeDBBackupScheduleLister.
+type GreptimeDBBackupScheduleListerExpansion interface{}
+
+// GreptimeDBBackupScheduleNamespaceListerExpansion allows custom methods to be added to
+// GreptimeDBBackupScheduleNamespaceLister.
+type GreptimeDBBackupScheduleNamespaceListerExpansion interface{}
+
 // GreptimeDBClusterListerExpansion allows custom methods to be added to
 // GreptimeDBClusterLister.
 type GreptimeDBClusterListerExpansion interface{}
@@ -24,6 +40,14 @@ type GreptimeDBClusterListerExpansion interface{}
 // GreptimeDBClusterNamespaceLister.
 type GreptimeDBClusterNamespaceListerExpansion interface{}
 
+// GreptimeDBRestoreListerExpansion allows custom methods to be added to
+// GreptimeDBRestoreLister.
+type GreptimeDBRestoreListerExpansion interface{}
+
+// GreptimeDBRestoreNamespaceListerExpansion allows custom methods to be added to
+// GreptimeDBRestoreNamespaceLister.
+type GreptimeDBRestoreNamespaceListerExpansion interface{}
+
 // GreptimeDBStandaloneListerExpansion allows custom methods to be added to
 // GreptimeDBStandaloneLister.
 type GreptimeDBStandaloneListerExpansion interface{}
@@ -30,6 +30,9 @@ type DatanodeConfig struct {
 	RPCBindAddr   *string `tomlmapping:"grpc.bind_addr"`
 	RPCServerAddr *string `tomlmapping:"grpc.server_addr"`
 
+	// Zone is the zone label of the datanode, which is used for the region placement.
+	Zone *string `tomlmapping:"labels.zone"`
+
 	// StorageConfig is the configuration for the storage.
 	StorageConfig `tomlmapping:",inline"`
 
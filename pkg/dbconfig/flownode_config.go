@@ -55,8 +55,8 @@ func (c *FlownodeConfig) ConfigureByCluster(cluster *v1alpha1.GreptimeDBCluster,
 		}
 	}
 
-	c.ConfigureLogging(cluster.GetFlownode().GetLogging())
-	c.ConfigureTracing(cluster.GetFlownode().GetTracing())
+	c.ConfigureLogging(flownodeSpec.GetLogging())
+	c.ConfigureTracing(flownodeSpec.GetTracing())
 
 	return nil
 }
@@ -20,8 +20,10 @@ import (
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -33,6 +35,12 @@ import (
 const (
 	LastAppliedResourceSpec = "controller.greptime.io/last-applied-resource-spec"
 	ConfigHash              = "controller.greptime.io/config-hash"
+
+	// RestartedAt is the pod template annotation that restarts the pods when the restartedAt of the component is changed.
+	RestartedAt = "controller.greptime.io/restarted-at"
+
+	// PreviousAppliedResourceSpec is the last applied resource spec before the latest update, which is used for rolling back.
+	PreviousAppliedResourceSpec = "controller.greptime.io/previous-applied-resource-spec"
 )
 
 // Builder is the interface for building K8s resources.
@@ -58,6 +66,12 @@ type Builder interface {
 	// BuildGreptimeDBStandalone builds a GreptimeDBStandalone.
 	BuildGreptimeDBStandalone() Builder
 
+	// BuildHorizontalPodAutoscaler builds a K8s horizontal pod autoscaler.
+	BuildHorizontalPodAutoscaler() Builder
+
+	// BuildPodDisruptionBudget builds a K8s pod disruption budget.
+	BuildPodDisruptionBudget() Builder
+
 	// SetControllerAndAnnotation sets the controller reference and annotation for the object.
 	SetControllerAndAnnotation() Builder
 
@@ -105,6 +119,14 @@ func (b *DefaultBuilder) BuildGreptimeDBStandalone() Builder {
 	return b
 }
 
+func (b *DefaultBuilder) BuildHorizontalPodAutoscaler() Builder {
+	return b
+}
+
+func (b *DefaultBuilder) BuildPodDisruptionBudget() Builder {
+	return b
+}
+
 func (b *DefaultBuilder) SetControllerAndAnnotation() Builder {
 	var (
 		spec       interface{}
@@ -134,6 +156,12 @@ func (b *DefaultBuilder) SetControllerAndAnnotation() Builder {
 		case *greptimev1alpha1.GreptimeDBStandalone:
 			spec = v.Spec
 			controlled = v
+		case *autoscalingv2.HorizontalPodAutoscaler:
+			spec = v.Spec
+			controlled = v
+		case *policyv1.PodDisruptionBudget:
+			spec = v.Spec
+			controlled = v
 		default:
 			b.Err = fmt.Errorf("unsupported object type: %T", obj)
 		}
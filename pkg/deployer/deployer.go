@@ -20,6 +20,7 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/GreptimeTeam/greptimedb-operator/pkg/util"
 	k8sutils "github.com/GreptimeTeam/greptimedb-operator/pkg/util/k8s"
 )
 
@@ -127,6 +128,7 @@ func (d *DefaultDeployer) Apply(ctx context.Context, _ client.Object, objects []
 
 			// If the spec or labels is not equal, update the object.
 			if !specEqual || !labelsEqual {
+				SetPreviousAppliedResourceSpec(oldObject, newObject)
 				newObject.SetResourceVersion(oldObject.GetResourceVersion())
 				if err := d.Patch(ctx, newObject, client.MergeFrom(oldObject)); err != nil {
 					return err
@@ -145,6 +147,25 @@ func (d *DefaultDeployer) Apply(ctx context.Context, _ client.Object, objects []
 	return nil
 }
 
+// SetPreviousAppliedResourceSpec keeps the last applied resource spec of the old object in the new object before updating,
+// so the object can be rolled back to the previous spec if the update fails to be ready.
+func SetPreviousAppliedResourceSpec(oldObject, newObject client.Object) {
+	spec, ok := oldObject.GetAnnotations()[LastAppliedResourceSpec]
+	if !ok {
+		return
+	}
+
+	// The spec is not changed, for example, only the labels are updated.
+	if spec == newObject.GetAnnotations()[LastAppliedResourceSpec] {
+		spec = oldObject.GetAnnotations()[PreviousAppliedResourceSpec]
+		if spec == "" {
+			return
+		}
+	}
+
+	newObject.SetAnnotations(util.MergeStringMap(newObject.GetAnnotations(), map[string]string{PreviousAppliedResourceSpec: spec}))
+}
+
 func (d *DefaultDeployer) CleanUp(_ context.Context, _ client.Object) error {
 	return nil
 }
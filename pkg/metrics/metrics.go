@@ -103,6 +103,22 @@ var (
 	)
 )
 
+var (
+	failedNodePodDeletions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricName("failed_node_pod_deletions_total"),
+		Help: "The number of the pods that are force deleted because their nodes are not ready.",
+	},
+		[]string{"namespace", "resource", "node"},
+	)
+
+	failedNodeVolumeAttachmentDeletions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricName("failed_node_volume_attachment_deletions_total"),
+		Help: "The number of the volume attachments that are deleted because their nodes are not ready.",
+	},
+		[]string{"node"},
+	)
+)
+
 var (
 	ErrEmptyEvents = errors.New("no events found")
 )
@@ -113,6 +129,18 @@ func init() {
 	metrics.Registry.MustRegister(podInitializingDuration)
 	metrics.Registry.MustRegister(podContainerStartupDuration)
 	metrics.Registry.MustRegister(podImagePullingDuration)
+	metrics.Registry.MustRegister(failedNodePodDeletions)
+	metrics.Registry.MustRegister(failedNodeVolumeAttachmentDeletions)
+}
+
+// RecordFailedNodePodDeletion counts the pod that is force deleted because its node is not ready.
+func RecordFailedNodePodDeletion(namespace, resource, node string) {
+	failedNodePodDeletions.WithLabelValues(namespace, resource, node).Inc()
+}
+
+// RecordFailedNodeVolumeAttachmentDeletion counts the volume attachment that is deleted because its node is not ready.
+func RecordFailedNodeVolumeAttachmentDeletion(node string) {
+	failedNodeVolumeAttachmentDeletions.WithLabelValues(node).Inc()
 }
 
 // MetricsCollector is used to collect pod metrics.
@@ -156,7 +184,7 @@ func (c *MetricsCollector) CollectClusterPodMetrics(ctx context.Context, cluster
 		}
 	}
 
-	if cluster.GetFlownode() != nil {
+	if cluster.GetFlownode() != nil || cluster.GetFlownodeGroups() != nil {
 		if err := c.collectPodMetricsByRole(ctx, cluster, greptimev1alpha1.FlownodeRoleKind); err != nil {
 			return err
 		}
@@ -184,6 +212,16 @@ func (c *MetricsCollector) collectPodMetricsByRole(ctx context.Context, cluster
 		}
 	}
 
+	if role == greptimev1alpha1.FlownodeRoleKind {
+		for _, flownode := range cluster.GetFlownodeGroups() {
+			flownodePods, err := c.getPods(ctx, cluster, role, flownode.Name)
+			if err != nil {
+				return err
+			}
+			podList = append(podList, flownodePods...)
+		}
+	}
+
 	pods, err := c.getPods(ctx, cluster, role, "")
 	if err != nil {
 		return err
@@ -0,0 +1,33 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cron parses the standard cron expressions that have five fields: minute, hour, day of month, month and day of week.
+package cron
+
+import (
+	robfigcron "github.com/robfig/cron/v3"
+)
+
+// parser parses the five fields, the descriptors like `@daily`, and the time zone prefix like `CRON_TZ=Asia/Shanghai`.
+var parser = robfigcron.NewParser(robfigcron.Minute | robfigcron.Hour | robfigcron.Dom | robfigcron.Month | robfigcron.Dow | robfigcron.Descriptor)
+
+// Schedule is the parsed cron expression.
+// Next returns the next time after the given time in its location, or in the location of the `CRON_TZ=` prefix if it's set.
+// It returns zero time if there is no matched time in five years.
+type Schedule = robfigcron.Schedule
+
+// Parse parses the cron expression, for example, `0 2 * * *`, `@daily` or `CRON_TZ=Asia/Shanghai 0 2 * * *`.
+func Parse(spec string) (Schedule, error) {
+	return parser.Parse(spec)
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Greptime Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	from := time.Date(2026, time.January, 30, 10, 15, 30, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		next time.Time
+	}{
+		{spec: "* * * * *", next: time.Date(2026, time.January, 30, 10, 16, 0, 0, time.UTC)},
+		{spec: "*/20 * * * *", next: time.Date(2026, time.January, 30, 10, 20, 0, 0, time.UTC)},
+		{spec: "0 2 * * *", next: time.Date(2026, time.January, 31, 2, 0, 0, 0, time.UTC)},
+		{spec: "@daily", next: time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)},
+		{spec: "@hourly", next: time.Date(2026, time.January, 30, 11, 0, 0, 0, time.UTC)},
+		{spec: "@weekly", next: time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{spec: "30 1 1 * *", next: time.Date(2026, time.February, 1, 1, 30, 0, 0, time.UTC)},
+		{spec: "0 0 * * sun", next: time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 0 15 * mon", next: time.Date(2026, time.February, 2, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 0 * * 5-6", next: time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 9-17/4 * * 1-5", next: time.Date(2026, time.January, 30, 13, 0, 0, 0, time.UTC)},
+		{spec: "0 0 29 2 *", next: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 0 30 2 *", next: time.Time{}},
+		// 02:00 in Asia/Shanghai is 18:00 in UTC.
+		{spec: "CRON_TZ=Asia/Shanghai 0 2 * * *", next: time.Date(2026, time.January, 30, 18, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		schedule, err := Parse(test.spec)
+		if err != nil {
+			t.Fatalf("parse cron expression %q: %v", test.spec, err)
+		}
+
+		if next := schedule.Next(from); !next.Equal(test.next) {
+			t.Errorf("cron expression %q: expected next: %v, got: %v", test.spec, test.next, next)
+		}
+	}
+}
+
+func TestScheduleNextAcrossDST(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("load location: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		next []time.Time
+	}{
+		{
+			// The clocks go back from 02:00 to 01:00 on 2026-11-01, and 01:30 occurs twice.
+			name: "hourly when the clocks go back",
+			spec: "30 * * * *",
+			from: time.Date(2026, time.November, 1, 0, 45, 0, 0, location),
+			next: []time.Time{
+				time.Date(2026, time.November, 1, 5, 30, 0, 0, time.UTC),
+				time.Date(2026, time.November, 1, 6, 30, 0, 0, time.UTC),
+				time.Date(2026, time.November, 1, 7, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			// The clocks go forward from 02:00 to 03:00 on 2026-03-08, and 02:30 doesn't exist.
+			name: "daily when the clocks go forward",
+			spec: "0 3 * * *",
+			from: time.Date(2026, time.March, 7, 12, 0, 0, 0, location),
+			next: []time.Time{
+				time.Date(2026, time.March, 8, 7, 0, 0, 0, time.UTC),
+				time.Date(2026, time.March, 9, 7, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			from := tt.from
+			for i, want := range tt.next {
+				next := schedule.Next(from)
+				if !next.Equal(want) {
+					t.Fatalf("step %d: expected next: %v, got: %v", i, want, next.UTC())
+				}
+				from = next
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "0 0 * * * *", "CRON_TZ=Mars/Olympus 0 2 * * *"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("expected error for cron expression %q", spec)
+		}
+	}
+}
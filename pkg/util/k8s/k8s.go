@@ -116,6 +116,54 @@ func IsStatefulSetReady(sts *appsv1.StatefulSet) bool {
 	return sts.Status.ReadyReplicas == *sts.Spec.Replicas && sts.Status.CurrentReplicas == *sts.Spec.Replicas
 }
 
+// IsDeploymentRolledOut checks if all the replicas of the deployment are updated to the latest pod template and available,
+// and there are no old replicas left.
+func IsDeploymentRolledOut(deployment *appsv1.Deployment) bool {
+	if deployment == nil || deployment.Spec.Replicas == nil {
+		return false
+	}
+
+	if deployment.Status.ObservedGeneration != deployment.Generation {
+		return false
+	}
+
+	replicas := *deployment.Spec.Replicas
+	return deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.Replicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas
+}
+
+// IsStatefulSetRolledOut checks if all the replicas of the statefulset are updated to the latest revision and ready.
+func IsStatefulSetRolledOut(sts *appsv1.StatefulSet) bool {
+	if sts == nil || sts.Spec.Replicas == nil {
+		return false
+	}
+
+	if sts.Status.ObservedGeneration != sts.Generation {
+		return false
+	}
+
+	replicas := *sts.Spec.Replicas
+	return sts.Status.UpdatedReplicas == replicas &&
+		sts.Status.ReadyReplicas == replicas &&
+		sts.Status.CurrentRevision == sts.Status.UpdateRevision
+}
+
+// IsPodReady checks if the pod is running and its Ready condition is true.
+func IsPodReady(pod *corev1.Pod) bool {
+	if pod == nil || pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
 // GetK8sResource returns a native K8s resource by namespace and name.
 func GetK8sResource(namespace, name string, obj client.Object) error {
 	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{})